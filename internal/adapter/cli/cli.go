@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	"github.com/3-lines-studio/datafrost/internal/usecase"
+	"github.com/3-lines-studio/datafrost/internal/usecase/port"
+)
+
+const (
+	ExitOK       = 0
+	ExitError    = 1
+	ExitUsage    = 2
+	ExitNotFound = 3
+)
+
+type CLI struct {
+	connections  *usecase.ConnectionUsecase
+	imports      *usecase.ConnectionImportUsecase
+	queries      *usecase.QueryUsecase
+	savedQueries *usecase.SavedQueryUsecase
+	workspace    *usecase.WorkspaceUsecase
+	writers      port.ExportWriterFactory
+	stdin        io.Reader
+	stdout       io.Writer
+	stderr       io.Writer
+}
+
+func New(
+	connections *usecase.ConnectionUsecase,
+	imports *usecase.ConnectionImportUsecase,
+	queries *usecase.QueryUsecase,
+	savedQueries *usecase.SavedQueryUsecase,
+	workspace *usecase.WorkspaceUsecase,
+	writers port.ExportWriterFactory,
+	stdin io.Reader,
+	stdout, stderr io.Writer,
+) *CLI {
+	return &CLI{
+		connections:  connections,
+		imports:      imports,
+		queries:      queries,
+		savedQueries: savedQueries,
+		workspace:    workspace,
+		writers:      writers,
+		stdin:        stdin,
+		stdout:       stdout,
+		stderr:       stderr,
+	}
+}
+
+func (c *CLI) Run(args []string) (int, bool) {
+	if len(args) == 0 {
+		return 0, false
+	}
+	switch args[0] {
+	case "query":
+		return c.Query(args[1:]), true
+	case "connections":
+		return c.Connections(args[1:]), true
+	case "export-workspace":
+		return c.ExportWorkspace(args[1:]), true
+	case "import-workspace":
+		return c.ImportWorkspace(args[1:]), true
+	}
+	return 0, false
+}
+
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		args = fs.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+func (c *CLI) fail(err error) int {
+	fmt.Fprintf(c.stderr, "datafrost: %v\n", err)
+	if errors.Is(err, usecase.ErrConnectionNotFound) || errors.Is(err, usecase.ErrQueryNotFound) {
+		return ExitNotFound
+	}
+	if errors.Is(err, usecase.ErrInvalidCredentials) || errors.Is(err, usecase.ErrUnknownSource) || errors.Is(err, usecase.ErrInvalidOnDuplicate) ||
+		errors.Is(err, usecase.ErrPassphraseRequired) || errors.Is(err, usecase.ErrInvalidEnvironment) ||
+		errors.Is(err, usecase.ErrInvalidColor) {
+		return ExitUsage
+	}
+	return ExitError
+}
+
+func flagExit(err error) int {
+	if errors.Is(err, flag.ErrHelp) {
+		return ExitOK
+	}
+	return ExitUsage
+}
+
+func (c *CLI) usage(fs *flag.FlagSet, format string, args ...any) int {
+	fmt.Fprintf(c.stderr, "datafrost: "+format+"\n", args...)
+	fs.Usage()
+	return ExitUsage
+}
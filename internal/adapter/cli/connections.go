@@ -0,0 +1,499 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/3-lines-studio/datafrost/internal/core/entity"
+)
+
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+type connectionInput struct {
+	Name        string         `json:"name"`
+	Type        string         `json:"type"`
+	Credentials map[string]any `json:"credentials"`
+	Group       *string        `json:"group"`
+	Tags        *[]string      `json:"tags"`
+	Environment *string        `json:"environment"`
+	Color       *string        `json:"color"`
+}
+
+type organizationFlags struct {
+	group, tags, environment, color *string
+}
+
+func addOrganizationFlags(fs *flag.FlagSet) organizationFlags {
+	return organizationFlags{
+		group:       fs.String("group", "", "Sidebar group (folder)"),
+		tags:        fs.String("tags", "", "Comma-separated tags"),
+		environment: fs.String("env", "", "Environment: dev, staging or prod"),
+		color:       fs.String("color", "", "Marker color as #rrggbb (defaults to the environment's color)"),
+	}
+}
+
+// Only flags given on the command line override the input, so an empty
+// --group "" can clear a group while an absent flag keeps it.
+func (f organizationFlags) apply(fs *flag.FlagSet, input *connectionInput) {
+	fs.Visit(func(fl *flag.Flag) {
+		switch fl.Name {
+		case "group":
+			input.Group = f.group
+		case "tags":
+			tags := splitTags(*f.tags)
+			input.Tags = &tags
+		case "env":
+			input.Environment = f.environment
+		case "color":
+			input.Color = f.color
+		}
+	})
+}
+
+func splitTags(value string) []string {
+	tags := []string{}
+	for _, tag := range strings.Split(value, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+func valueOr[T any](value *T) T {
+	var zero T
+	if value == nil {
+		return zero
+	}
+	return *value
+}
+
+func (c *CLI) Connections(args []string) int {
+	usage := func() {
+		fmt.Fprintln(c.stderr, "Usage: datafrost connections <list|add|edit|remove|test|import> [flags]")
+	}
+	if len(args) == 0 {
+		usage()
+		return ExitUsage
+	}
+
+	switch args[0] {
+	case "list", "ls":
+		return c.listConnections(args[1:])
+	case "add":
+		return c.addConnection(args[1:])
+	case "edit":
+		return c.editConnection(args[1:])
+	case "remove", "rm":
+		return c.removeConnection(args[1:])
+	case "test":
+		return c.testConnection(args[1:])
+	case "import":
+		return c.importConnections(args[1:])
+	case "-h", "--help", "help":
+		usage()
+		return ExitOK
+	}
+	fmt.Fprintf(c.stderr, "datafrost: unknown connections command %q\n", args[0])
+	usage()
+	return ExitUsage
+}
+
+func (c *CLI) listConnections(args []string) int {
+	fs := flag.NewFlagSet("connections list", flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	format := fs.String("format", "table", "Output format: table or json")
+	withCredentials := fs.Bool("credentials", false, "Include credentials in json output")
+	var filter entity.ConnectionFilter
+	fs.StringVar(&filter.Tag, "tag", "", "Only connections with this tag")
+	fs.StringVar(&filter.Environment, "env", "", "Only connections in this environment (dev, staging, prod)")
+	fs.StringVar(&filter.Group, "group", "", "Only connections in this group")
+	if _, err := parseInterspersed(fs, args); err != nil {
+		return flagExit(err)
+	}
+
+	connections, _, err := c.connections.ListFiltered(filter)
+	if err != nil {
+		return c.fail(err)
+	}
+
+	switch *format {
+	case "json":
+		out := make([]map[string]any, len(connections))
+		for i, conn := range connections {
+			out[i] = map[string]any{
+				"id":               conn.ID,
+				"name":             conn.Name,
+				"type":             conn.Type,
+				"group":            conn.Group,
+				"tags":             conn.Tags,
+				"environment":      conn.Environment,
+				"color":            conn.Color,
+				"created_at":       conn.CreatedAt,
+				"trust_references": conn.TrustReferences,
+			}
+			if *withCredentials {
+				out[i]["credentials"] = conn.Credentials
+			}
+		}
+		enc := json.NewEncoder(c.stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(out); err != nil {
+			return c.fail(err)
+		}
+	case "table":
+		table := newTableWriter(c.stdout, "")
+		_ = table.WriteHeader([]entity.ResultColumn{{Name: "id"}, {Name: "name"}, {Name: "type"}, {Name: "env"}, {Name: "group"}, {Name: "tags"}, {Name: "created_at"}})
+		for _, conn := range connections {
+			_ = table.WriteRow([]any{conn.ID, conn.Name, conn.Type, conn.Environment, conn.Group, strings.Join(conn.Tags, ","), conn.CreatedAt.Local().Format(time.DateTime)})
+		}
+		if err := table.Close(); err != nil {
+			return c.fail(err)
+		}
+	default:
+		return c.usage(fs, "unsupported format %q", *format)
+	}
+	return ExitOK
+}
+
+func (c *CLI) addConnection(args []string) int {
+	fs := flag.NewFlagSet("connections add", flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	name := fs.String("name", "", "Connection name")
+	adapterType := fs.String("type", "", "Adapter type (sqlite, turso, postgres, bigquery)")
+	fromStdin := fs.Bool("stdin", false, `Read {"name", "type", "credentials"} JSON from stdin`)
+	test := fs.Bool("test", false, "Test the connection before saving it")
+	var creds stringList
+	fs.Var(&creds, "cred", "Credential field as key=value; key=@file reads the value from a file (repeatable)")
+	organization := addOrganizationFlags(fs)
+	fs.Usage = func() {
+		fmt.Fprintln(c.stderr, "Usage: datafrost connections add --name <name> --type <type> --cred key=value ... | --stdin")
+		fs.PrintDefaults()
+	}
+	if _, err := parseInterspersed(fs, args); err != nil {
+		return flagExit(err)
+	}
+
+	input := connectionInput{Credentials: map[string]any{}}
+	if *fromStdin {
+		if err := c.readConnectionInput(&input); err != nil {
+			return c.usage(fs, "%v", err)
+		}
+	}
+	if *name != "" {
+		input.Name = *name
+	}
+	if *adapterType != "" {
+		input.Type = *adapterType
+	}
+	if err := applyCredentialFlags(input.Credentials, creds); err != nil {
+		return c.usage(fs, "%v", err)
+	}
+	organization.apply(fs, &input)
+	if input.Name == "" || input.Type == "" {
+		return c.usage(fs, "--name and --type are required")
+	}
+
+	if existing, err := c.connections.FindByName(input.Name); err == nil && existing.Name == input.Name {
+		return c.fail(fmt.Errorf("connection %q already exists (id %d)", input.Name, existing.ID))
+	}
+
+	credentials, err := c.connections.ValidateCredentials(input.Type, input.Credentials)
+	if err != nil {
+		return c.fail(err)
+	}
+	if *test {
+		if err := c.connections.Test(entity.TestConnectionRequest{Type: input.Type, Credentials: credentials, TrustReferences: true}); err != nil {
+			return c.fail(fmt.Errorf("connection test failed: %w", err))
+		}
+	}
+
+	// Connections typed in at the terminal are the user's own, so their
+	// file and cmd references are trusted.
+	conn, err := c.connections.Create(entity.CreateConnectionRequest{
+		Name:            input.Name,
+		Type:            input.Type,
+		Credentials:     credentials,
+		Group:           valueOr(input.Group),
+		Tags:            valueOr(input.Tags),
+		Environment:     valueOr(input.Environment),
+		Color:           valueOr(input.Color),
+		TrustReferences: true,
+	})
+	if err != nil {
+		return c.fail(err)
+	}
+	fmt.Fprintf(c.stdout, "Added connection %d (%s)\n", conn.ID, conn.Name)
+	return ExitOK
+}
+
+func (c *CLI) editConnection(args []string) int {
+	fs := flag.NewFlagSet("connections edit", flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	name := fs.String("name", "", "New connection name")
+	adapterType := fs.String("type", "", "New adapter type (clears existing credentials)")
+	fromStdin := fs.Bool("stdin", false, `Merge {"name", "type", "credentials"} JSON from stdin`)
+	test := fs.Bool("test", false, "Test the connection before saving it")
+	var creds, unset stringList
+	fs.Var(&creds, "cred", "Credential field as key=value; key=@file reads the value from a file (repeatable)")
+	fs.Var(&unset, "unset", "Remove a credential field (repeatable)")
+	trust := fs.Bool("trust-references", false, "Allow ${file:} and ${cmd:} references in the credentials (=false revokes)")
+	organization := addOrganizationFlags(fs)
+	fs.Usage = func() {
+		fmt.Fprintln(c.stderr, "Usage: datafrost connections edit <name|id> [--name <name>] [--cred key=value ...] [--unset key ...] [--stdin]")
+		fs.PrintDefaults()
+	}
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return flagExit(err)
+	}
+	if len(positional) != 1 {
+		return c.usage(fs, "expected exactly one connection name or id")
+	}
+
+	conn, err := c.connections.FindByName(positional[0])
+	if err != nil {
+		return c.fail(err)
+	}
+
+	input := connectionInput{Credentials: map[string]any{}}
+	if *fromStdin {
+		if err := c.readConnectionInput(&input); err != nil {
+			return c.usage(fs, "%v", err)
+		}
+	}
+	if *name != "" {
+		input.Name = *name
+	}
+	if *adapterType != "" {
+		input.Type = *adapterType
+	}
+	if err := applyCredentialFlags(input.Credentials, creds); err != nil {
+		return c.usage(fs, "%v", err)
+	}
+	organization.apply(fs, &input)
+
+	updated := entity.UpdateConnectionRequest{
+		Name:        conn.Name,
+		Type:        conn.Type,
+		Credentials: map[string]any{},
+		Group:       input.Group,
+		Tags:        input.Tags,
+		Environment: input.Environment,
+		Color:       input.Color,
+	}
+	fs.Visit(func(fl *flag.Flag) {
+		if fl.Name == "trust-references" {
+			updated.TrustReferences = trust
+		}
+	})
+	if input.Name != "" {
+		updated.Name = input.Name
+	}
+	if input.Type != "" && input.Type != conn.Type {
+		updated.Type = input.Type
+	} else {
+		for key, value := range conn.Credentials {
+			updated.Credentials[key] = value
+		}
+	}
+	// Switching modes drops the fields of the previous mode.
+	if mode, ok := input.Credentials["mode"]; ok && mode != updated.Credentials["mode"] {
+		updated.Credentials = map[string]any{}
+	}
+	for key, value := range input.Credentials {
+		updated.Credentials[key] = value
+	}
+	for _, key := range unset {
+		delete(updated.Credentials, key)
+	}
+
+	credentials, err := c.connections.ValidateCredentials(updated.Type, updated.Credentials)
+	if err != nil {
+		return c.fail(err)
+	}
+	updated.Credentials = credentials
+	if *test {
+		trusted := conn.TrustReferences
+		if updated.TrustReferences != nil {
+			trusted = *updated.TrustReferences
+		}
+		if err := c.connections.Test(entity.TestConnectionRequest{Type: updated.Type, Credentials: credentials, TrustReferences: trusted}); err != nil {
+			return c.fail(fmt.Errorf("connection test failed: %w", err))
+		}
+	}
+
+	if _, err := c.connections.Update(conn.ID, updated); err != nil {
+		return c.fail(err)
+	}
+	fmt.Fprintf(c.stdout, "Updated connection %d (%s)\n", conn.ID, updated.Name)
+	return ExitOK
+}
+
+func (c *CLI) removeConnection(args []string) int {
+	fs := flag.NewFlagSet("connections remove", flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	yes := fs.Bool("yes", false, "Confirm removal")
+	fs.Usage = func() {
+		fmt.Fprintln(c.stderr, "Usage: datafrost connections remove <name|id> --yes")
+		fs.PrintDefaults()
+	}
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return flagExit(err)
+	}
+	if len(positional) != 1 {
+		return c.usage(fs, "expected exactly one connection name or id")
+	}
+
+	conn, err := c.connections.FindByName(positional[0])
+	if err != nil {
+		return c.fail(err)
+	}
+	if !*yes {
+		return c.usage(fs, "pass --yes to remove connection %d (%s) and its saved queries", conn.ID, conn.Name)
+	}
+
+	if err := c.connections.Delete(conn.ID); err != nil {
+		return c.fail(err)
+	}
+	fmt.Fprintf(c.stdout, "Removed connection %d (%s)\n", conn.ID, conn.Name)
+	return ExitOK
+}
+
+func (c *CLI) testConnection(args []string) int {
+	fs := flag.NewFlagSet("connections test", flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(c.stderr, "Usage: datafrost connections test <name|id>")
+	}
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return flagExit(err)
+	}
+	if len(positional) != 1 {
+		return c.usage(fs, "expected exactly one connection name or id")
+	}
+
+	conn, err := c.connections.FindByName(positional[0])
+	if err != nil {
+		return c.fail(err)
+	}
+	if err := c.connections.TestExisting(conn.ID); err != nil {
+		return c.fail(fmt.Errorf("connection test failed: %w", err))
+	}
+	fmt.Fprintf(c.stdout, "Connection %d (%s) OK\n", conn.ID, conn.Name)
+	return ExitOK
+}
+
+func (c *CLI) importConnections(args []string) int {
+	fs := flag.NewFlagSet("connections import", flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	from := fs.String("from", "", "Source: "+strings.Join(c.imports.Sources(), ", "))
+	file := fs.String("file", "", "Source file (defaults to the tool's standard location)")
+	dryRun := fs.Bool("dry-run", false, "Show what would be imported without saving")
+	onDuplicate := fs.String("on-duplicate", entity.OnDuplicateSkip, "When a name already exists: skip, rename or replace")
+	format := fs.String("format", "table", "Output format: table or json")
+	trust := fs.Bool("trust-references", false, "Allow imported ${file:} and ${cmd:} references to be resolved")
+	var only stringList
+	fs.Var(&only, "only", "Import only the entry with this name (repeatable)")
+	fs.Usage = func() {
+		fmt.Fprintln(c.stderr, "Usage: datafrost connections import --from <source> [--file path] [--dry-run] [--on-duplicate skip|rename|replace]")
+		fs.PrintDefaults()
+	}
+	if _, err := parseInterspersed(fs, args); err != nil {
+		return flagExit(err)
+	}
+	if *from == "" {
+		return c.usage(fs, "--from is required")
+	}
+	if *format != "table" && *format != "json" {
+		return c.usage(fs, "unsupported format %q", *format)
+	}
+
+	req := entity.ConnectionImportRequest{Source: *from, Path: *file, OnDuplicate: *onDuplicate, Names: only, TrustReferences: *trust}
+	run := c.imports.Import
+	if *dryRun {
+		run = c.imports.Preview
+	}
+	result, err := run(req)
+	if err != nil {
+		return c.fail(err)
+	}
+
+	if *format == "json" {
+		enc := json.NewEncoder(c.stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(result); err != nil {
+			return c.fail(err)
+		}
+	} else {
+		table := newTableWriter(c.stdout, "")
+		_ = table.WriteHeader([]entity.ResultColumn{{Name: "action"}, {Name: "name"}, {Name: "type"}, {Name: "origin"}, {Name: "note"}})
+		for _, item := range result.Items {
+			note := item.Error
+			if note == "" {
+				note = strings.Join(item.Warnings, "; ")
+			}
+			_ = table.WriteRow([]any{item.Action, item.Name, item.Type, item.Origin, note})
+		}
+		if err := table.Close(); err != nil {
+			return c.fail(err)
+		}
+		verb := "Imported"
+		if result.DryRun {
+			verb = "Would import"
+		}
+		origin := result.Path
+		if origin == "" {
+			origin = result.Source
+		}
+		fmt.Fprintf(c.stderr, "%s %d new, %d replaced; %d skipped, %d invalid (from %s)\n",
+			verb, result.Created, result.Replaced, result.Skipped, result.Invalid, origin)
+	}
+	if result.Invalid > 0 && result.Created+result.Replaced == 0 {
+		return ExitError
+	}
+	return ExitOK
+}
+
+func (c *CLI) readConnectionInput(input *connectionInput) error {
+	if err := json.NewDecoder(c.stdin).Decode(input); err != nil {
+		return fmt.Errorf("invalid JSON on stdin: %w", err)
+	}
+	if input.Credentials == nil {
+		input.Credentials = map[string]any{}
+	}
+	return nil
+}
+
+func applyCredentialFlags(credentials map[string]any, values []string) error {
+	for _, value := range values {
+		key, val, ok := strings.Cut(value, "=")
+		if !ok || key == "" {
+			return fmt.Errorf("invalid --cred %q, expected key=value", value)
+		}
+		if path, isFile := strings.CutPrefix(val, "@"); isFile {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return fmt.Errorf("failed to read %s for %s: %w", path, key, err)
+			}
+			val = string(data)
+		}
+		credentials[key] = val
+	}
+	return nil
+}
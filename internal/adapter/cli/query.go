@@ -0,0 +1,116 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/3-lines-studio/datafrost/internal/core/entity"
+	"github.com/3-lines-studio/datafrost/internal/usecase/port"
+)
+
+func (c *CLI) Query(args []string) int {
+	fs := flag.NewFlagSet("query", flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	connName := fs.String("conn", "", "Connection name or ID (required)")
+	savedName := fs.String("saved", "", "Run the saved query with this name instead of SQL")
+	format := fs.String("format", "table", "Output format: table, csv, tsv, json, ndjson")
+	output := fs.String("output", "", "Write results to this file instead of stdout")
+	nullValue := fs.String("null", "", "Text written for NULL in table, csv and tsv output")
+	timeout := fs.Duration("timeout", 0, "Cancel the query after this long (e.g. 30s)")
+	fs.Usage = func() {
+		fmt.Fprintln(c.stderr, "Usage: datafrost query --conn <name> [flags] \"SELECT ...\" | --saved <name> | -")
+		fmt.Fprintln(c.stderr, "Queries are read-only: only a single SELECT or WITH statement (or a read-only PRAGMA on SQLite) is run.")
+		fs.PrintDefaults()
+	}
+
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return flagExit(err)
+	}
+	if *connName == "" {
+		return c.usage(fs, "--conn is required")
+	}
+
+	sql := strings.TrimSpace(strings.Join(positional, " "))
+	if *savedName != "" && sql != "" {
+		return c.usage(fs, "pass either SQL or --saved, not both")
+	}
+	if sql == "-" {
+		data, err := io.ReadAll(c.stdin)
+		if err != nil {
+			return c.fail(fmt.Errorf("failed to read query from stdin: %w", err))
+		}
+		sql = strings.TrimSpace(string(data))
+	}
+
+	conn, err := c.connections.FindByName(*connName)
+	if err != nil {
+		return c.fail(err)
+	}
+	if *savedName != "" {
+		saved, err := c.savedQueries.FindByName(conn.ID, *savedName)
+		if err != nil {
+			return c.fail(err)
+		}
+		sql = saved.Query
+	}
+	if sql == "" {
+		return c.usage(fs, "no query given")
+	}
+
+	var out io.Writer = c.stdout
+	if *output != "" {
+		file, err := os.Create(*output)
+		if err != nil {
+			return c.fail(err)
+		}
+		defer func() { _ = file.Close() }()
+		out = file
+	}
+
+	var writer port.ExportWriter
+	if *format == "table" {
+		writer = newTableWriter(out, *nullValue)
+	} else {
+		writer, err = c.writers.CreateStream(out, entity.ExportTarget{
+			Format:     *format,
+			Options:    entity.ExportOptions{NullValue: *nullValue},
+			SourceType: conn.Type,
+		})
+		if err != nil {
+			return c.usage(fs, "%v", err)
+		}
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	if *timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *timeout)
+		defer cancel()
+	}
+
+	streamErr := c.queries.Stream(ctx, conn.ID, sql, writer)
+	if streamErr == nil {
+		streamErr = writer.Close()
+	}
+	if streamErr != nil {
+		if *output != "" {
+			_ = os.Remove(*output)
+		}
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			streamErr = fmt.Errorf("query timed out after %s", timeout.Round(time.Millisecond))
+		} else if ctx.Err() != nil {
+			streamErr = errors.New("query cancelled")
+		}
+		return c.fail(streamErr)
+	}
+	return ExitOK
+}
@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"bufio"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/3-lines-studio/datafrost/internal/core/entity"
+)
+
+const maxCellWidth = 60
+
+type tableWriter struct {
+	w         io.Writer
+	nullValue string
+	columns   []string
+	rows      [][]string
+}
+
+func newTableWriter(w io.Writer, nullValue string) *tableWriter {
+	if nullValue == "" {
+		nullValue = "NULL"
+	}
+	return &tableWriter{w: w, nullValue: nullValue}
+}
+
+func (t *tableWriter) WriteHeader(columns []entity.ResultColumn) error {
+	t.columns = make([]string, len(columns))
+	for i, col := range columns {
+		t.columns[i] = col.Name
+	}
+	return nil
+}
+
+func (t *tableWriter) WriteRow(values []any) error {
+	row := make([]string, len(values))
+	for i, val := range values {
+		row[i] = t.cell(val)
+	}
+	t.rows = append(t.rows, row)
+	return nil
+}
+
+func (t *tableWriter) Close() error {
+	widths := make([]int, len(t.columns))
+	for i, name := range t.columns {
+		widths[i] = utf8.RuneCountInString(name)
+	}
+	for _, row := range t.rows {
+		for i, cell := range row {
+			if i < len(widths) {
+				widths[i] = max(widths[i], utf8.RuneCountInString(cell))
+			}
+		}
+	}
+
+	w := bufio.NewWriter(t.w)
+	writeLine := func(cells []string) {
+		for i, cell := range cells {
+			if i > 0 {
+				_, _ = w.WriteString(" | ")
+			}
+			_, _ = w.WriteString(cell)
+			if i < len(cells)-1 {
+				_, _ = w.WriteString(strings.Repeat(" ", widths[i]-utf8.RuneCountInString(cell)))
+			}
+		}
+		_ = w.WriteByte('\n')
+	}
+
+	if len(t.columns) > 0 {
+		writeLine(t.columns)
+		rule := make([]string, len(widths))
+		for i, width := range widths {
+			rule[i] = strings.Repeat("-", width)
+		}
+		_, _ = w.WriteString(strings.Join(rule, "-+-") + "\n")
+		for _, row := range t.rows {
+			writeLine(row)
+		}
+	}
+
+	noun := "rows"
+	if len(t.rows) == 1 {
+		noun = "row"
+	}
+	fmt.Fprintf(w, "(%d %s)\n", len(t.rows), noun)
+	return w.Flush()
+}
+
+func (t *tableWriter) cell(val any) string {
+	var s string
+	switch v := val.(type) {
+	case nil:
+		return t.nullValue
+	case string:
+		s = v
+	case []byte:
+		if utf8.Valid(v) {
+			s = string(v)
+		} else {
+			s = base64.StdEncoding.EncodeToString(v)
+		}
+	case time.Time:
+		s = v.Format(time.RFC3339Nano)
+	case float64:
+		s = strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		s = fmt.Sprintf("%v", v)
+	}
+
+	s = strings.NewReplacer("\r\n", "\\n", "\n", "\\n", "\t", " ").Replace(s)
+	if utf8.RuneCountInString(s) > maxCellWidth {
+		runes := []rune(s)
+		s = string(runes[:maxCellWidth-1]) + "…"
+	}
+	return s
+}
@@ -0,0 +1,168 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/3-lines-studio/datafrost/internal/core/entity"
+)
+
+const passphraseEnv = "DATAFROST_PASSPHRASE"
+
+func (c *CLI) ExportWorkspace(args []string) int {
+	fs := flag.NewFlagSet("export-workspace", flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	output := fs.String("output", "", "Write the bundle to this file instead of stdout")
+	passphraseFile := fs.String("passphrase-file", "", "Encrypt secrets with the passphrase in this file (- reads stdin); defaults to $"+passphraseEnv)
+	var conns stringList
+	fs.Var(&conns, "conn", "Export only this connection (repeatable)")
+	fs.Usage = func() {
+		fmt.Fprintln(c.stderr, "Usage: datafrost export-workspace [--output file] [--conn name ...] [--passphrase-file path]")
+		fs.PrintDefaults()
+	}
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return flagExit(err)
+	}
+	if len(positional) > 0 {
+		return c.usage(fs, "unexpected argument %q", positional[0])
+	}
+
+	passphrase, err := c.readPassphrase(*passphraseFile)
+	if err != nil {
+		return c.usage(fs, "%v", err)
+	}
+	bundle, err := c.workspace.Export(entity.WorkspaceExportOptions{Connections: conns, Passphrase: passphrase})
+	if err != nil {
+		return c.fail(err)
+	}
+	data, err := json.MarshalIndent(bundle, "", "  ")
+	if err != nil {
+		return c.fail(err)
+	}
+	data = append(data, '\n')
+
+	if *output == "" {
+		if _, err := c.stdout.Write(data); err != nil {
+			return c.fail(err)
+		}
+	} else if err := os.WriteFile(*output, data, 0o600); err != nil {
+		return c.fail(err)
+	}
+
+	secrets := "secrets omitted"
+	if bundle.Secrets != nil {
+		secrets = "secrets encrypted"
+	}
+	fmt.Fprintf(c.stderr, "Exported %d connection(s), %s\n", len(bundle.Connections), secrets)
+	return ExitOK
+}
+
+func (c *CLI) ImportWorkspace(args []string) int {
+	fs := flag.NewFlagSet("import-workspace", flag.ContinueOnError)
+	fs.SetOutput(c.stderr)
+	dryRun := fs.Bool("dry-run", false, "Show what would be imported without saving")
+	onDuplicate := fs.String("on-duplicate", entity.OnDuplicateSkip, "When a connection name already exists: skip, rename or replace")
+	passphraseFile := fs.String("passphrase-file", "", "Decrypt secrets with the passphrase in this file (- reads stdin); defaults to $"+passphraseEnv)
+	noSecrets := fs.Bool("no-secrets", false, "Import without decrypting the bundle's secrets")
+	trust := fs.Bool("trust-references", false, "Allow ${file:} and ${cmd:} references from the bundle to be resolved")
+	format := fs.String("format", "table", "Output format: table or json")
+	fs.Usage = func() {
+		fmt.Fprintln(c.stderr, "Usage: datafrost import-workspace <file|-> [--dry-run] [--on-duplicate skip|rename|replace] [--passphrase-file path]")
+		fs.PrintDefaults()
+	}
+	positional, err := parseInterspersed(fs, args)
+	if err != nil {
+		return flagExit(err)
+	}
+	if len(positional) != 1 {
+		return c.usage(fs, "expected exactly one bundle file")
+	}
+	if *format != "table" && *format != "json" {
+		return c.usage(fs, "unsupported format %q", *format)
+	}
+	if positional[0] == "-" && *passphraseFile == "-" {
+		return c.usage(fs, "the bundle and the passphrase cannot both be read from stdin")
+	}
+
+	var data []byte
+	if positional[0] == "-" {
+		data, err = io.ReadAll(c.stdin)
+	} else {
+		data, err = os.ReadFile(positional[0])
+	}
+	if err != nil {
+		return c.fail(err)
+	}
+	var bundle entity.WorkspaceBundle
+	if err := json.Unmarshal(data, &bundle); err != nil {
+		return c.fail(fmt.Errorf("invalid workspace bundle: %w", err))
+	}
+
+	opts := entity.WorkspaceImportOptions{OnDuplicate: *onDuplicate, DryRun: *dryRun, SkipSecrets: *noSecrets, TrustReferences: *trust}
+	if !*noSecrets {
+		if opts.Passphrase, err = c.readPassphrase(*passphraseFile); err != nil {
+			return c.usage(fs, "%v", err)
+		}
+	}
+	result, err := c.workspace.Import(bundle, opts)
+	if err != nil {
+		return c.fail(err)
+	}
+
+	if *format == "json" {
+		enc := json.NewEncoder(c.stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(result); err != nil {
+			return c.fail(err)
+		}
+	} else {
+		table := newTableWriter(c.stdout, "")
+		_ = table.WriteHeader([]entity.ResultColumn{{Name: "action"}, {Name: "name"}, {Name: "type"}, {Name: "queries"}, {Name: "tabs"}, {Name: "note"}})
+		for _, item := range result.Items {
+			note := item.Error
+			if note == "" {
+				note = strings.Join(item.Warnings, "; ")
+			}
+			_ = table.WriteRow([]any{item.Action, item.Name, item.Type, item.SavedQueries, item.Tabs, note})
+		}
+		if err := table.Close(); err != nil {
+			return c.fail(err)
+		}
+		verb := "Imported"
+		if result.DryRun {
+			verb = "Would import"
+		}
+		fmt.Fprintf(c.stderr, "%s %d new, %d replaced, %d layout(s); %d skipped, %d invalid\n",
+			verb, result.Created, result.Replaced, result.Layouts, result.Skipped, result.Invalid)
+	}
+	if result.Invalid > 0 && result.Created+result.Replaced == 0 {
+		return ExitError
+	}
+	return ExitOK
+}
+
+func (c *CLI) readPassphrase(path string) (string, error) {
+	if path == "" {
+		return os.Getenv(passphraseEnv), nil
+	}
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(c.stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read passphrase: %w", err)
+	}
+	passphrase := strings.TrimRight(string(data), "\r\n")
+	if passphrase == "" {
+		return "", fmt.Errorf("passphrase file is empty")
+	}
+	return passphrase, nil
+}
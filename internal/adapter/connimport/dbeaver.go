@@ -0,0 +1,148 @@
+package connimport
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/3-lines-studio/datafrost/internal/core/entity"
+)
+
+// DBeaver encrypts credentials-config.json with this fixed, publicly
+// documented key; it obfuscates rather than protects the file.
+const dbeaverCredentialsKey = "babb4a9f774ab853c96c2d653dfe544a"
+
+type dbeaverDataSources struct {
+	Connections map[string]struct {
+		Provider      string `json:"provider"`
+		Driver        string `json:"driver"`
+		Name          string `json:"name"`
+		Folder        string `json:"folder"`
+		Configuration struct {
+			Host     string `json:"host"`
+			Port     string `json:"port"`
+			Database string `json:"database"`
+			URL      string `json:"url"`
+			User     string `json:"user"`
+			Password string `json:"password"`
+			Handlers map[string]struct {
+				Properties map[string]string `json:"properties"`
+			} `json:"handlers"`
+		} `json:"configuration"`
+	} `json:"connections"`
+}
+
+type dbeaverCredential struct {
+	User     string `json:"user"`
+	Password string `json:"password"`
+}
+
+func parseDBeaver(data []byte, origin string, credentials map[string]dbeaverCredential) ([]entity.ConnectionCandidate, error) {
+	var sources dbeaverDataSources
+	if err := json.Unmarshal(data, &sources); err != nil {
+		return nil, fmt.Errorf("%s: not a DBeaver data-sources.json file: %w", origin, err)
+	}
+
+	ids := make([]string, 0, len(sources.Connections))
+	for id := range sources.Connections {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var candidates []entity.ConnectionCandidate
+	for _, id := range ids {
+		conn := sources.Connections[id]
+		cfg := conn.Configuration
+		candidate := entity.ConnectionCandidate{
+			Name:   conn.Name,
+			Origin: origin + "#" + id,
+		}
+		if candidate.Name == "" {
+			candidate.Name = id
+		}
+
+		user, password := cfg.User, cfg.Password
+		if cred, ok := credentials[id]; ok {
+			user = orDefault(cred.User, user)
+			password = orDefault(cred.Password, password)
+		}
+
+		provider := strings.ToLower(conn.Provider)
+		// Older DBeaver versions register SQLite under the generic JDBC provider.
+		if provider == "generic" && strings.Contains(strings.ToLower(conn.Driver), "sqlite") {
+			provider = "sqlite"
+		}
+		switch provider {
+		case "postgresql", "postgres":
+			sslMode := ""
+			for _, handler := range cfg.Handlers {
+				if mode := handler.Properties["sslMode"]; mode != "" {
+					sslMode = mode
+				}
+			}
+			candidate.Type = "postgres"
+			candidate.Credentials = postgresFields(cfg.Host, cfg.Port, cfg.Database, user, password, sslMode)
+			if user == "" {
+				candidate.Warnings = append(candidate.Warnings, "no username found; DBeaver keeps it in credentials-config.json next to data-sources.json")
+			}
+		case "sqlite":
+			path := cfg.Database
+			if path == "" {
+				path = strings.TrimPrefix(cfg.URL, "jdbc:sqlite:")
+			}
+			candidate.Type = "sqlite"
+			candidate.Credentials = map[string]any{"path": path}
+		default:
+			candidate.Type = conn.Provider
+			candidate.Warnings = append(candidate.Warnings, fmt.Sprintf("DBeaver provider %q is not supported", conn.Provider))
+		}
+		candidates = append(candidates, candidate)
+	}
+	return candidates, nil
+}
+
+func readDBeaverCredentials(path string) map[string]dbeaverCredential {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	plain, err := decryptDBeaverCredentials(data)
+	if err != nil {
+		return nil
+	}
+
+	var raw map[string]map[string]dbeaverCredential
+	if err := json.Unmarshal(plain, &raw); err != nil {
+		return nil
+	}
+	credentials := make(map[string]dbeaverCredential, len(raw))
+	for id, entry := range raw {
+		credentials[id] = entry["#connection"]
+	}
+	return credentials
+}
+
+func decryptDBeaverCredentials(data []byte) ([]byte, error) {
+	key, _ := hex.DecodeString(dbeaverCredentialsKey)
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < 2*aes.BlockSize || len(data)%aes.BlockSize != 0 {
+		return nil, fmt.Errorf("invalid credentials file size")
+	}
+	iv, ciphertext := data[:aes.BlockSize], data[aes.BlockSize:]
+	plain := make([]byte, len(ciphertext))
+	cipher.NewCBCDecrypter(block, iv).CryptBlocks(plain, ciphertext)
+
+	padding := int(plain[len(plain)-1])
+	if padding == 0 || padding > aes.BlockSize {
+		return nil, fmt.Errorf("invalid padding")
+	}
+	return plain[:len(plain)-padding], nil
+}
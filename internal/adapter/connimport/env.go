@@ -0,0 +1,89 @@
+package connimport
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/3-lines-studio/datafrost/internal/core/entity"
+)
+
+func parseEnvironment(environ []string, getenv func(string) string) []entity.ConnectionCandidate {
+	var candidates []entity.ConnectionCandidate
+	for _, kv := range environ {
+		name, value, ok := strings.Cut(kv, "=")
+		if !ok || value == "" || !isURLVariable(name) {
+			continue
+		}
+		candidate, ok := candidateFromURL(value)
+		if !ok {
+			continue
+		}
+		candidate.Name = name
+		candidate.Origin = "$" + name
+		candidates = append(candidates, candidate)
+	}
+	sort.Slice(candidates, func(i, j int) bool { return candidates[i].Name < candidates[j].Name })
+
+	// libpq's own variables describe a single connection.
+	if host := getenv("PGHOST"); host != "" {
+		database := getenv("PGDATABASE")
+		username := getenv("PGUSER")
+		candidates = append(candidates, entity.ConnectionCandidate{
+			Name:        postgresName(username, host, getenv("PGPORT"), database),
+			Type:        "postgres",
+			Origin:      "$PGHOST",
+			Credentials: postgresFields(host, getenv("PGPORT"), database, username, getenv("PGPASSWORD"), getenv("PGSSLMODE")),
+		})
+	}
+	return candidates
+}
+
+func isURLVariable(name string) bool {
+	upper := strings.ToUpper(name)
+	return upper == "DATABASE_URL" || strings.HasSuffix(upper, "_DATABASE_URL") ||
+		strings.HasSuffix(upper, "_DB_URL") || upper == "DB_URL"
+}
+
+func candidateFromURL(raw string) (entity.ConnectionCandidate, bool) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return entity.ConnectionCandidate{}, false
+	}
+
+	switch strings.ToLower(u.Scheme) {
+	case "postgres", "postgresql":
+		return entity.ConnectionCandidate{
+			Type:        "postgres",
+			Credentials: map[string]any{"mode": "url", "url": raw},
+		}, true
+	case "libsql":
+		query := u.Query()
+		token := query.Get("authToken")
+		query.Del("authToken")
+		u.RawQuery = query.Encode()
+		candidate := entity.ConnectionCandidate{
+			Type:        "turso",
+			Credentials: map[string]any{"url": u.String(), "token": token},
+		}
+		if token == "" {
+			candidate.Warnings = append(candidate.Warnings, "no authToken in the URL; add the token after importing")
+		}
+		return candidate, true
+	case "sqlite", "sqlite3", "file":
+		path := u.Path
+		if u.Opaque != "" {
+			path = u.Opaque
+		} else if u.Host != "" {
+			path = u.Host + u.Path
+		}
+		if path == "" {
+			return entity.ConnectionCandidate{}, false
+		}
+		return entity.ConnectionCandidate{
+			Type:        "sqlite",
+			Credentials: map[string]any{"path": path},
+		}, true
+	}
+	return entity.ConnectionCandidate{}, false
+}
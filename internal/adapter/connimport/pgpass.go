@@ -0,0 +1,87 @@
+package connimport
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/3-lines-studio/datafrost/internal/core/entity"
+)
+
+func parsePgpass(data []byte, origin string) ([]entity.ConnectionCandidate, error) {
+	var candidates []entity.ConnectionCandidate
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := splitPgpassLine(line)
+		if len(fields) != 5 {
+			return nil, fmt.Errorf("%s:%d: expected hostname:port:database:username:password", origin, lineNo)
+		}
+		host, port, database, username, password := fields[0], fields[1], fields[2], fields[3], fields[4]
+
+		candidate := entity.ConnectionCandidate{
+			Type:   "postgres",
+			Origin: fmt.Sprintf("%s:%d", origin, lineNo),
+		}
+		// Wildcards match many servers in libpq; a saved connection needs a
+		// concrete one, so those parts are left for the user to fill in.
+		if host == "*" {
+			host = ""
+			candidate.Warnings = append(candidate.Warnings, "hostname is a wildcard; using localhost")
+		}
+		if port == "*" {
+			port = ""
+		}
+		if database == "*" {
+			database = ""
+			candidate.Warnings = append(candidate.Warnings, "database is a wildcard; set it before connecting")
+		}
+		if username == "*" {
+			username = ""
+			candidate.Warnings = append(candidate.Warnings, "username is a wildcard; set it before connecting")
+		}
+
+		candidate.Name = postgresName(username, orDefault(host, "localhost"), port, database)
+		candidate.Credentials = postgresFields(host, port, database, username, password, "")
+		candidates = append(candidates, candidate)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return candidates, nil
+}
+
+func splitPgpassLine(line string) []string {
+	var fields []string
+	var current strings.Builder
+	escaped := false
+	for _, r := range line {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == ':' && len(fields) < 4:
+			fields = append(fields, current.String())
+			current.Reset()
+		default:
+			current.WriteRune(r)
+		}
+	}
+	return append(fields, current.String())
+}
+
+func orDefault(value, fallback string) string {
+	if value == "" {
+		return fallback
+	}
+	return value
+}
@@ -0,0 +1,73 @@
+package connimport
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/3-lines-studio/datafrost/internal/core/entity"
+)
+
+func parsePgService(data []byte, origin string) ([]entity.ConnectionCandidate, error) {
+	type service struct {
+		name   string
+		line   int
+		values map[string]string
+	}
+
+	var services []*service
+	var current *service
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+		if strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]") {
+			current = &service{name: strings.TrimSpace(line[1 : len(line)-1]), line: lineNo, values: map[string]string{}}
+			services = append(services, current)
+			continue
+		}
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			return nil, fmt.Errorf("%s:%d: expected key=value", origin, lineNo)
+		}
+		if current == nil {
+			return nil, fmt.Errorf("%s:%d: setting outside a [service] section", origin, lineNo)
+		}
+		current.values[strings.ToLower(strings.TrimSpace(key))] = strings.TrimSpace(value)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	candidates := make([]entity.ConnectionCandidate, 0, len(services))
+	for _, svc := range services {
+		v := svc.values
+		host := v["host"]
+		if host == "" {
+			host = v["hostaddr"]
+		}
+		database := v["dbname"]
+		if database == "" {
+			database = v["user"]
+		}
+		candidate := entity.ConnectionCandidate{
+			Name:        svc.name,
+			Type:        "postgres",
+			Origin:      fmt.Sprintf("%s:%d", origin, svc.line),
+			Credentials: postgresFields(host, v["port"], database, v["user"], v["password"], v["sslmode"]),
+		}
+		if strings.Contains(host, ",") {
+			candidate.Warnings = append(candidate.Warnings, "multiple hosts listed; test the connection after importing")
+		}
+		if v["password"] == "" {
+			candidate.Warnings = append(candidate.Warnings, "no password in the service file; add it after importing if the server requires one")
+		}
+		candidates = append(candidates, candidate)
+	}
+	return candidates, nil
+}
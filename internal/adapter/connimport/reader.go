@@ -0,0 +1,158 @@
+package connimport
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/3-lines-studio/datafrost/internal/core/entity"
+)
+
+type Reader struct {
+	environ func() []string
+	getenv  func(string) string
+	homeDir func() (string, error)
+}
+
+func NewReader() *Reader {
+	return &Reader{environ: os.Environ, getenv: os.Getenv, homeDir: os.UserHomeDir}
+}
+
+func (r *Reader) Sources() []string {
+	return []string{
+		entity.ConnectionSourcePgpass,
+		entity.ConnectionSourcePgService,
+		entity.ConnectionSourceEnv,
+		entity.ConnectionSourceDBeaver,
+		entity.ConnectionSourceTablePlus,
+	}
+}
+
+func (r *Reader) Read(source, path string, content []byte) ([]entity.ConnectionCandidate, string, error) {
+	if source == entity.ConnectionSourceEnv {
+		return parseEnvironment(r.environ(), r.getenv), "", nil
+	}
+
+	var parse func(data []byte, origin string) ([]entity.ConnectionCandidate, error)
+	switch source {
+	case entity.ConnectionSourcePgpass:
+		parse = parsePgpass
+	case entity.ConnectionSourcePgService:
+		parse = parsePgService
+	case entity.ConnectionSourceDBeaver:
+		parse = func(data []byte, origin string) ([]entity.ConnectionCandidate, error) {
+			// Usernames and passwords live next to data-sources.json, so they
+			// are only available when reading from disk.
+			var credentials map[string]dbeaverCredential
+			if content == nil {
+				credentials = readDBeaverCredentials(filepath.Join(filepath.Dir(origin), "credentials-config.json"))
+			}
+			return parseDBeaver(data, origin, credentials)
+		}
+	case entity.ConnectionSourceTablePlus:
+		parse = parseTablePlus
+	default:
+		return nil, "", fmt.Errorf("unknown connection source: %s", source)
+	}
+
+	if content != nil {
+		candidates, err := parse(content, source)
+		return candidates, "", err
+	}
+
+	if path == "" {
+		defaultPath, err := r.defaultPath(source)
+		if err != nil {
+			return nil, "", err
+		}
+		path = defaultPath
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, path, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	candidates, err := parse(data, path)
+	return candidates, path, err
+}
+
+func (r *Reader) defaultPath(source string) (string, error) {
+	switch source {
+	case entity.ConnectionSourcePgpass:
+		if path := r.getenv("PGPASSFILE"); path != "" {
+			return path, nil
+		}
+		if runtime.GOOS == "windows" {
+			return filepath.Join(r.getenv("APPDATA"), "postgresql", "pgpass.conf"), nil
+		}
+		return r.inHome(".pgpass")
+	case entity.ConnectionSourcePgService:
+		if path := r.getenv("PGSERVICEFILE"); path != "" {
+			return path, nil
+		}
+		if runtime.GOOS == "windows" {
+			return filepath.Join(r.getenv("APPDATA"), "postgresql", ".pg_service.conf"), nil
+		}
+		return r.inHome(".pg_service.conf")
+	case entity.ConnectionSourceDBeaver:
+		switch runtime.GOOS {
+		case "darwin":
+			return r.inHome("Library", "DBeaverData", "workspace6", "General", ".dbeaver", "data-sources.json")
+		case "windows":
+			return filepath.Join(r.getenv("APPDATA"), "DBeaverData", "workspace6", "General", ".dbeaver", "data-sources.json"), nil
+		}
+		return r.inHome(".local", "share", "DBeaverData", "workspace6", "General", ".dbeaver", "data-sources.json")
+	case entity.ConnectionSourceTablePlus:
+		if runtime.GOOS == "darwin" {
+			return r.inHome("Library", "Application Support", "com.tinyapp.TablePlus", "Data", "Connections.plist")
+		}
+		return "", fmt.Errorf("tableplus: pass the path of an exported connections file")
+	}
+	return "", fmt.Errorf("unknown connection source: %s", source)
+}
+
+func (r *Reader) inHome(elem ...string) (string, error) {
+	home, err := r.homeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate home directory: %w", err)
+	}
+	return filepath.Join(append([]string{home}, elem...)...), nil
+}
+
+func postgresFields(host, port, database, username, password, sslMode string) map[string]any {
+	if host == "" {
+		host = "localhost"
+	}
+	if port == "" {
+		port = "5432"
+	}
+	if sslMode == "" {
+		sslMode = "prefer"
+	}
+	credentials := map[string]any{
+		"mode":     "fields",
+		"host":     host,
+		"port":     port,
+		"database": database,
+		"username": username,
+		"ssl_mode": sslMode,
+	}
+	if password != "" {
+		credentials["password"] = password
+	}
+	return credentials
+}
+
+func postgresName(username, host, port, database string) string {
+	name := host
+	if port != "" && port != "5432" {
+		name += ":" + port
+	}
+	if database != "" {
+		name += "/" + database
+	}
+	if username != "" {
+		name = username + "@" + name
+	}
+	return name
+}
@@ -0,0 +1,134 @@
+package connimport
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/3-lines-studio/datafrost/internal/core/entity"
+)
+
+func parseTablePlus(data []byte, origin string) ([]entity.ConnectionCandidate, error) {
+	// Connections.plist and JSON exports share keys; passwords normally stay
+	// in the macOS keychain.
+	var entries []map[string]string
+	trimmed := bytes.TrimSpace(data)
+	if bytes.HasPrefix(trimmed, []byte("[")) {
+		var raw []map[string]any
+		if err := json.Unmarshal(trimmed, &raw); err != nil {
+			return nil, fmt.Errorf("%s: invalid TablePlus JSON export: %w", origin, err)
+		}
+		for _, item := range raw {
+			entry := make(map[string]string, len(item))
+			for key, value := range item {
+				entry[key] = fmt.Sprint(value)
+			}
+			entries = append(entries, entry)
+		}
+	} else {
+		parsed, err := parsePlistDicts(trimmed)
+		if err != nil {
+			return nil, fmt.Errorf("%s: not a TablePlus connections file: %w", origin, err)
+		}
+		entries = parsed
+	}
+
+	candidates := make([]entity.ConnectionCandidate, 0, len(entries))
+	for i, entry := range entries {
+		candidate := entity.ConnectionCandidate{
+			Name:   entry["ConnectionName"],
+			Origin: fmt.Sprintf("%s#%d", origin, i+1),
+		}
+		host := orDefault(entry["DatabaseHost"], entry["ServerAddress"])
+		port := orDefault(entry["DatabasePort"], entry["ServerPort"])
+
+		switch strings.ToLower(entry["Driver"]) {
+		case "postgresql", "postgres", "redshift", "cockroachdb":
+			candidate.Type = "postgres"
+			candidate.Credentials = postgresFields(host, port, entry["DatabaseName"], entry["DatabaseUser"], entry["DatabasePassword"], "")
+			if entry["DatabasePassword"] == "" {
+				candidate.Warnings = append(candidate.Warnings, "TablePlus keeps passwords in the keychain; add it after importing")
+			}
+		case "sqlite":
+			candidate.Type = "sqlite"
+			candidate.Credentials = map[string]any{"path": orDefault(entry["DatabasePath"], entry["DatabaseName"])}
+		case "libsql":
+			candidate.Type = "turso"
+			candidate.Credentials = map[string]any{"url": host, "token": entry["DatabasePassword"]}
+		default:
+			candidate.Type = entry["Driver"]
+			candidate.Warnings = append(candidate.Warnings, fmt.Sprintf("TablePlus driver %q is not supported", entry["Driver"]))
+		}
+		if candidate.Name == "" {
+			candidate.Name = postgresName(entry["DatabaseUser"], host, port, entry["DatabaseName"])
+		}
+		candidates = append(candidates, candidate)
+	}
+	return candidates, nil
+}
+
+func parsePlistDicts(data []byte) ([]map[string]string, error) {
+	// Only the scalar values of each top-level <dict> are kept; nested
+	// arrays and dicts are skipped.
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	var entries []map[string]string
+	var current map[string]string
+	var key string
+	depth, nested := 0, 0
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch {
+			case t.Name.Local == "dict":
+				depth++
+				if depth == 1 {
+					current = map[string]string{}
+				}
+			case depth >= 1 && t.Name.Local == "array":
+				nested++
+			case depth != 1 || nested > 0:
+			case t.Name.Local == "key":
+				var text string
+				if err := decoder.DecodeElement(&text, &t); err != nil {
+					return nil, err
+				}
+				key = text
+			case t.Name.Local == "string" || t.Name.Local == "integer" || t.Name.Local == "real":
+				var text string
+				if err := decoder.DecodeElement(&text, &t); err != nil {
+					return nil, err
+				}
+				current[key] = text
+			case t.Name.Local == "true" || t.Name.Local == "false":
+				current[key] = t.Name.Local
+			}
+		case xml.EndElement:
+			if t.Name.Local == "array" && nested > 0 {
+				nested--
+			}
+			if t.Name.Local == "dict" {
+				if depth == 1 {
+					entries = append(entries, current)
+				}
+				depth--
+			}
+		}
+	}
+
+	if entries == nil {
+		return nil, fmt.Errorf("no connections found")
+	}
+	return entries, nil
+}
@@ -6,8 +6,10 @@ import (
 	"strings"
 
 	"github.com/3-lines-studio/datafrost/internal/core/entity"
+	"github.com/3-lines-studio/datafrost/internal/usecase"
 
 	"cloud.google.com/go/bigquery"
+	"google.golang.org/api/iterator"
 	"google.golang.org/api/option"
 )
 
@@ -45,12 +47,14 @@ func newBigQueryAdapterRegistration() entity.AdapterRegistration {
 						Type:        "textarea",
 						Required:    true,
 						Placeholder: "Paste JSON credentials here or upload file...",
+						Secret:      true,
 					},
 				},
 				SupportsFile: true,
 				FileTypes:    []string{".json"},
 			},
 		},
+		Dialect: bigQueryDialect,
 		Factory: func() entity.DatabaseAdapter {
 			return &bigQueryAdapter{}
 		},
@@ -122,8 +126,9 @@ func (a *bigQueryAdapter) ListTables() ([]entity.TableInfo, error) {
 			break
 		}
 		tables = append(tables, entity.TableInfo{
-			Name: table.TableID,
-			Type: "table",
+			Name:   table.TableID,
+			Type:   "table",
+			Schema: a.dataset,
 		})
 	}
 
@@ -240,6 +245,82 @@ func (a *bigQueryAdapter) executeQueryWithCount(query string) (*entity.QueryResu
 	}, nil
 }
 
+func (a *bigQueryAdapter) StreamQuery(ctx context.Context, query string, sink entity.RowSink) error {
+	if a.client == nil {
+		return fmt.Errorf("not connected")
+	}
+
+	if err := checkSelectOnly(query); err != nil {
+		return err
+	}
+
+	return a.streamQuery(ctx, query, sink)
+}
+
+func (a *bigQueryAdapter) StreamTableData(ctx context.Context, tableName string, filters []entity.Filter, sink entity.RowSink) error {
+	if a.client == nil {
+		return fmt.Errorf("not connected")
+	}
+
+	whereClause, _ := buildBigQueryWhereClause(filters)
+
+	query := fmt.Sprintf("SELECT * FROM `%s.%s.%s`", a.projectID, a.dataset, tableName)
+	if whereClause != "" {
+		query += " WHERE " + whereClause
+	}
+
+	return a.streamQuery(ctx, query, sink)
+}
+
+func (a *bigQueryAdapter) streamQuery(ctx context.Context, query string, sink entity.RowSink) error {
+	it, err := a.client.Query(query).Read(ctx)
+	if err != nil {
+		return fmt.Errorf("query failed: %w", err)
+	}
+
+	headerWritten := false
+	for {
+		var row []bigquery.Value
+		err := it.Next(&row)
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("row iteration error: %w", err)
+		}
+
+		if !headerWritten {
+			if err := sink.WriteHeader(bigQueryResultColumns(it.Schema)); err != nil {
+				return err
+			}
+			headerWritten = true
+		}
+
+		convertedRow := make([]any, len(row))
+		for i, val := range row {
+			convertedRow[i] = convertBigQueryValue(val)
+		}
+		if err := sink.WriteRow(convertedRow); err != nil {
+			return err
+		}
+	}
+
+	if !headerWritten {
+		if err := sink.WriteHeader(bigQueryResultColumns(it.Schema)); err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+func bigQueryResultColumns(schema bigquery.Schema) []entity.ResultColumn {
+	columns := make([]entity.ResultColumn, len(schema))
+	for i, field := range schema {
+		columns[i] = entity.ResultColumn{Name: field.Name, DatabaseType: string(field.Type)}
+	}
+	return columns
+}
+
 func convertBigQueryValue(val bigquery.Value) any {
 	if val == nil {
 		return nil
@@ -398,17 +479,249 @@ func (a *bigQueryAdapter) GetTableSchema(tableName string) (*entity.TableSchema,
 		return nil, fmt.Errorf("failed to get table metadata: %w", err)
 	}
 
+	pkColumns := make(map[string]bool)
+	var foreignKeys []entity.ForeignKeyInfo
+	if constraints := metadata.TableConstraints; constraints != nil {
+		if constraints.PrimaryKey != nil {
+			for _, col := range constraints.PrimaryKey.Columns {
+				pkColumns[col] = true
+			}
+		}
+		for _, fk := range constraints.ForeignKeys {
+			info := entity.ForeignKeyInfo{Name: fk.Name}
+			if fk.ReferencedTable != nil {
+				info.ReferencedTable = fk.ReferencedTable.TableID
+			}
+			for _, ref := range fk.ColumnReferences {
+				info.Columns = append(info.Columns, ref.ReferencingColumn)
+				info.ReferencedColumns = append(info.ReferencedColumns, ref.ReferencedColumn)
+			}
+			foreignKeys = append(foreignKeys, info)
+		}
+	}
+
 	var columns []entity.ColumnInfo
 	for _, field := range metadata.Schema {
 		columns = append(columns, entity.ColumnInfo{
-			Name:     field.Name,
-			Type:     string(field.Type),
-			Nullable: !field.Required,
+			Name:         field.Name,
+			Type:         string(field.Type),
+			Nullable:     !field.Required,
+			IsPrimaryKey: pkColumns[field.Name],
+			Comment:      field.Description,
 		})
 	}
 
 	return &entity.TableSchema{
+		TableName:   tableName,
+		Columns:     columns,
+		ForeignKeys: foreignKeys,
+		Comment:     metadata.Description,
+	}, nil
+}
+
+func (a *bigQueryAdapter) GetColumnValues(tableName, column, search string, filters []entity.Filter, limit, offset int) ([]entity.ColumnValue, error) {
+	if a.client == nil {
+		return nil, fmt.Errorf("not connected")
+	}
+
+	whereClause, _ := buildBigQueryWhereClause(filters)
+
+	col := QuoteIdentifier("bigquery", column)
+	conditions := []string{col + " IS NOT NULL"}
+	if whereClause != "" {
+		conditions = append(conditions, whereClause)
+	}
+	if search != "" {
+		conditions = append(conditions, "LOWER(CAST("+col+" AS STRING)) LIKE LOWER(@search)")
+	}
+
+	q := a.client.Query(fmt.Sprintf(
+		"SELECT %s, COUNT(*) FROM `%s.%s.%s` WHERE %s GROUP BY %s ORDER BY 2 DESC, 1 LIMIT %d OFFSET %d",
+		col, a.projectID, a.dataset, tableName, strings.Join(conditions, " AND "), col, limit, offset,
+	))
+	if search != "" {
+		q.Parameters = []bigquery.QueryParameter{{Name: "search", Value: containsPattern(search)}}
+	}
+
+	ctx := context.Background()
+	it, err := q.Read(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get column values: %w", err)
+	}
+
+	values := []entity.ColumnValue{}
+	for {
+		var row []bigquery.Value
+		err := it.Next(&row)
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("column value iteration error: %w", err)
+		}
+		if len(row) < 2 {
+			continue
+		}
+		count, _ := row[1].(int64)
+		values = append(values, entity.ColumnValue{Value: convertBigQueryValue(row[0]), Count: count})
+	}
+	return values, nil
+}
+
+func (a *bigQueryAdapter) GetTableDDL(tableName string) (*entity.TableDDL, error) {
+	if a.client == nil {
+		return nil, fmt.Errorf("not connected")
+	}
+
+	ctx := context.Background()
+
+	q := a.client.Query(fmt.Sprintf(
+		"SELECT table_type, ddl FROM `%s.%s.INFORMATION_SCHEMA.TABLES` WHERE table_name = @table",
+		a.projectID, a.dataset,
+	))
+	q.Parameters = []bigquery.QueryParameter{{Name: "table", Value: tableName}}
+
+	it, err := q.Read(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ddl: %w", err)
+	}
+
+	var row struct {
+		TableType string `bigquery:"table_type"`
+		DDL       string `bigquery:"ddl"`
+	}
+	if err := it.Next(&row); err != nil {
+		if err == iterator.Done {
+			return nil, usecase.ErrTableNotFound
+		}
+		return nil, fmt.Errorf("failed to read ddl: %w", err)
+	}
+
+	return &entity.TableDDL{
 		TableName: tableName,
-		Columns:   columns,
+		Type:      strings.ToLower(row.TableType),
+		DDL:       strings.TrimSpace(row.DDL),
 	}, nil
 }
+
+func (a *bigQueryAdapter) Explain(query string, analyze bool) (*entity.QueryPlan, error) {
+	if a.client == nil {
+		return nil, fmt.Errorf("not connected")
+	}
+
+	if err := checkExplainable(query); err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	q := a.client.Query(query)
+	q.DryRun = !analyze
+
+	job, err := q.Run(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("explain failed: %w", err)
+	}
+
+	status := job.LastStatus()
+	if analyze {
+		status, err = job.Wait(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("explain failed: %w", err)
+		}
+		if err := status.Err(); err != nil {
+			return nil, fmt.Errorf("explain failed: %w", err)
+		}
+	}
+	if status == nil || status.Statistics == nil {
+		return nil, fmt.Errorf("explain returned no statistics")
+	}
+
+	stats, _ := status.Statistics.Details.(*bigquery.QueryStatistics)
+	if stats == nil {
+		return nil, fmt.Errorf("explain returned no query statistics")
+	}
+
+	plan := &entity.QueryPlan{
+		Dialect:  "bigquery",
+		Analyzed: analyze,
+		Summary: map[string]any{
+			"total_bytes_processed": stats.TotalBytesProcessed,
+			"statement_type":        stats.StatementType,
+		},
+	}
+	var referenced []string
+	for _, t := range stats.ReferencedTables {
+		referenced = append(referenced, fmt.Sprintf("%s.%s.%s", t.ProjectID, t.DatasetID, t.TableID))
+	}
+	if len(referenced) > 0 {
+		plan.Summary["referenced_tables"] = referenced
+	}
+	if analyze {
+		plan.Summary["total_bytes_billed"] = stats.TotalBytesBilled
+		plan.Summary["slot_millis"] = stats.SlotMillis
+	}
+
+	plan.Root = bigQueryPlanTree(stats.QueryPlan)
+	if plan.Root == nil {
+		bytes := float64(stats.TotalBytesProcessed)
+		plan.Root = &entity.PlanNode{
+			Operation: "Query",
+			Detail:    "dry run; run with analyze for stage details",
+			Extra:     map[string]any{"estimated_bytes_processed": bytes},
+			Children:  []*entity.PlanNode{},
+		}
+	}
+	return plan, nil
+}
+
+func bigQueryPlanTree(stages []*bigquery.ExplainQueryStage) *entity.PlanNode {
+	if len(stages) == 0 {
+		return nil
+	}
+
+	nodes := make(map[int64]*entity.PlanNode, len(stages))
+	consumed := make(map[int64]bool)
+	for _, stage := range stages {
+		var steps []string
+		for _, step := range stage.Steps {
+			steps = append(steps, step.Kind+": "+strings.Join(step.Substeps, ", "))
+		}
+		read := float64(stage.RecordsRead)
+		written := float64(stage.RecordsWritten)
+		computeMs := float64(stage.ComputeMax.Milliseconds())
+		nodes[stage.ID] = &entity.PlanNode{
+			Operation:    stage.Name,
+			Detail:       strings.Join(steps, "; "),
+			ActualRows:   &written,
+			ActualTimeMs: &computeMs,
+			Extra: map[string]any{
+				"records_read":         read,
+				"status":               stage.Status,
+				"parallel_inputs":      stage.ParallelInputs,
+				"shuffle_output_bytes": stage.ShuffleOutputBytes,
+			},
+			Children: []*entity.PlanNode{},
+		}
+		for _, input := range stage.InputStages {
+			consumed[input] = true
+		}
+	}
+
+	var roots []*entity.PlanNode
+	for _, stage := range stages {
+		node := nodes[stage.ID]
+		for _, input := range stage.InputStages {
+			if child, ok := nodes[input]; ok {
+				node.Children = append(node.Children, child)
+			}
+		}
+		if !consumed[stage.ID] {
+			roots = append(roots, node)
+		}
+	}
+
+	if len(roots) == 1 {
+		return roots[0]
+	}
+	return &entity.PlanNode{Operation: "Query", Children: roots}
+}
@@ -0,0 +1,130 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/3-lines-studio/datafrost/internal/core/entity"
+)
+
+const (
+	bulkInsertBatchRows = 500
+	// SQLite builds before 3.32 cap bind parameters at 999; PostgreSQL allows
+	// 65535 per statement.
+	sqliteMaxParams   = 999
+	postgresMaxParams = 65535
+)
+
+// Inserts in one transaction with multi-row INSERTs, so remote databases
+// such as Turso and PostgreSQL take one round trip per batch instead of
+// one per row.
+func bulkInsert(ctx context.Context, db *sql.DB, dialect string, req entity.BulkInsertRequest, next entity.RowSource, progress func(rows int64)) error {
+	if db == nil {
+		return fmt.Errorf("not connected")
+	}
+	if len(req.Columns) == 0 {
+		return fmt.Errorf("no columns to insert")
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	table := QuoteIdentifier(dialect, req.Table)
+	if req.DropExisting {
+		if _, err := tx.ExecContext(ctx, "DROP TABLE IF EXISTS "+table); err != nil {
+			return fmt.Errorf("failed to drop table: %w", err)
+		}
+	}
+
+	if req.Create != nil {
+		if _, err := tx.ExecContext(ctx, CreateTableStatement(dialect, dialect, req.Table, req.Create)); err != nil {
+			return fmt.Errorf("failed to create table: %w", err)
+		}
+	}
+
+	columns := make([]string, len(req.Columns))
+	for i, col := range req.Columns {
+		columns[i] = QuoteIdentifier(dialect, col)
+	}
+	prefix := fmt.Sprintf("INSERT INTO %s (%s) VALUES ", table, strings.Join(columns, ", "))
+
+	maxParams := sqliteMaxParams
+	if dialect == "postgres" {
+		maxParams = postgresMaxParams
+	}
+	batchRows := max(1, min(bulkInsertBatchRows, maxParams/len(columns)))
+
+	var count int64
+	batch := make([]any, 0, batchRows*len(columns))
+	flush := func() error {
+		rows := len(batch) / len(columns)
+		if rows == 0 {
+			return nil
+		}
+		if _, err := tx.ExecContext(ctx, prefix+valuesList(dialect, rows, len(columns)), batch...); err != nil {
+			return fmt.Errorf("failed to insert rows %d-%d: %w", count+1, count+int64(rows), err)
+		}
+		count += int64(rows)
+		batch = batch[:0]
+		progress(count)
+		return nil
+	}
+
+	for {
+		row, err := next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if len(row) != len(columns) {
+			return fmt.Errorf("row %d has %d values, expected %d", count+int64(len(batch)/len(columns))+1, len(row), len(columns))
+		}
+
+		batch = append(batch, row...)
+		if len(batch) == batchRows*len(columns) {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit import: %w", err)
+	}
+	return nil
+}
+
+func valuesList(dialect string, rows, columns int) string {
+	var b strings.Builder
+	param := 0
+	for r := range rows {
+		if r > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteByte('(')
+		for c := range columns {
+			if c > 0 {
+				b.WriteString(", ")
+			}
+			param++
+			if dialect == "postgres" {
+				fmt.Fprintf(&b, "$%d", param)
+			} else {
+				b.WriteByte('?')
+			}
+		}
+		b.WriteByte(')')
+	}
+	return b.String()
+}
@@ -4,42 +4,59 @@ import (
 	"sync"
 
 	"github.com/3-lines-studio/datafrost/internal/core/entity"
+	"github.com/3-lines-studio/datafrost/internal/usecase/port"
 )
 
 type AdapterCache struct {
-	mu      sync.Mutex
-	entries map[int64]entity.DatabaseAdapter
-	factory *Factory
+	mu       sync.Mutex
+	entries  map[int64]entity.DatabaseAdapter
+	factory  *Factory
+	resolver port.CredentialResolver
 }
 
-func NewAdapterCache() *AdapterCache {
+func NewAdapterCache(resolver port.CredentialResolver) *AdapterCache {
 	return &AdapterCache{
-		entries: make(map[int64]entity.DatabaseAdapter),
-		factory: NewFactory(),
+		entries:  make(map[int64]entity.DatabaseAdapter),
+		factory:  NewFactory(resolver),
+		resolver: resolver,
 	}
 }
 
-func (c *AdapterCache) Get(id int64, connType string, credentials map[string]any) (entity.DatabaseAdapter, error) {
+func (c *AdapterCache) Get(conn *entity.Connection) (entity.DatabaseAdapter, error) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
-	if adapter, ok := c.entries[id]; ok {
+	if adapter, ok := c.entries[conn.ID]; ok {
 		return adapter, nil
 	}
 
-	adapter, err := c.factory.GetAdapter(connType)
+	adapter, err := c.factory.GetAdapter(conn.Type)
 	if err != nil {
 		return nil, err
 	}
 
-	if err := adapter.Connect(credentials); err != nil {
+	// References are resolved into a copy for Connect only, so the stored
+	// credentials keep the ${...} form.
+	resolved, err := c.resolver.Resolve(conn.Credentials, conn.TrustReferences, c.factory.secretFields(conn.Type))
+	if err != nil {
+		return nil, err
+	}
+	if err := adapter.Connect(resolved); err != nil {
 		return nil, err
 	}
 
-	c.entries[id] = adapter
+	c.entries[conn.ID] = adapter
 	return adapter, nil
 }
 
+func (c *AdapterCache) IsOpen(id int64) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	_, ok := c.entries[id]
+	return ok
+}
+
 func (c *AdapterCache) Invalidate(id int64) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
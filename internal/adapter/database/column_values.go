@@ -0,0 +1,57 @@
+package database
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/3-lines-studio/datafrost/internal/core/entity"
+)
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func containsPattern(search string) string {
+	return "%" + likeEscaper.Replace(search) + "%"
+}
+
+func queryColumnValues(conn *sql.DB, query string, args []any) ([]entity.ColumnValue, error) {
+	rows, err := conn.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get column values: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	values := []entity.ColumnValue{}
+	for rows.Next() {
+		var v entity.ColumnValue
+		if err := rows.Scan(&v.Value, &v.Count); err != nil {
+			return nil, fmt.Errorf("failed to scan column value: %w", err)
+		}
+		if b, ok := v.Value.([]byte); ok {
+			v.Value = string(b)
+		}
+		values = append(values, v)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("column value iteration error: %w", err)
+	}
+	return values, nil
+}
+
+func sqliteColumnValuesQuery(tableName, column, search, whereClause string, args []any, limit, offset int) (string, []any) {
+	col := QuoteIdentifier("sqlite", column)
+	conditions := []string{col + " IS NOT NULL"}
+	if whereClause != "" {
+		conditions = append(conditions, whereClause)
+	}
+	if search != "" {
+		conditions = append(conditions, "CAST("+col+" AS TEXT) LIKE ? ESCAPE '\\'")
+		args = append(args, containsPattern(search))
+	}
+
+	query := fmt.Sprintf(
+		"SELECT %s, COUNT(*) FROM \"%s\" WHERE %s GROUP BY %s ORDER BY 2 DESC, 1 LIMIT %d OFFSET %d",
+		col, tableName, strings.Join(conditions, " AND "), col, limit, offset,
+	)
+	return query, args
+}
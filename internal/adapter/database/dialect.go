@@ -0,0 +1,94 @@
+package database
+
+import "github.com/3-lines-studio/datafrost/internal/core/entity"
+
+var commonSQLKeywords = []string{
+	"SELECT", "DISTINCT", "FROM", "WHERE", "AND", "OR", "NOT", "IN", "IS", "NULL",
+	"LIKE", "BETWEEN", "EXISTS", "AS", "ON", "USING", "JOIN", "INNER", "LEFT", "RIGHT",
+	"FULL", "OUTER", "CROSS", "GROUP", "BY", "HAVING", "ORDER", "ASC", "DESC", "LIMIT",
+	"OFFSET", "UNION", "ALL", "INTERSECT", "EXCEPT", "WITH", "RECURSIVE", "CASE", "WHEN",
+	"THEN", "ELSE", "END", "CAST", "TRUE", "FALSE", "OVER", "PARTITION", "WINDOW",
+}
+
+var commonSQLFunctions = []string{
+	"COUNT", "SUM", "AVG", "MIN", "MAX", "COALESCE", "NULLIF", "ABS", "ROUND",
+	"LOWER", "UPPER", "LENGTH", "TRIM", "LTRIM", "RTRIM", "REPLACE", "SUBSTR",
+	"ROW_NUMBER", "RANK", "DENSE_RANK", "LAG", "LEAD", "FIRST_VALUE", "LAST_VALUE",
+}
+
+var sqliteDialect = entity.SQLDialect{
+	Name: "sqlite",
+	Keywords: withCommon(commonSQLKeywords,
+		"PRAGMA", "GLOB", "REGEXP", "COLLATE", "NOCASE", "ESCAPE", "INDEXED", "ROWID",
+		"MATERIALIZED", "FILTER", "NULLS", "FIRST", "LAST",
+	),
+	Functions: withCommon(commonSQLFunctions,
+		"IFNULL", "IIF", "INSTR", "PRINTF", "FORMAT", "HEX", "QUOTE", "RANDOM", "TYPEOF",
+		"UNICODE", "GROUP_CONCAT", "TOTAL", "DATE", "TIME", "DATETIME", "JULIANDAY",
+		"STRFTIME", "UNIXEPOCH", "JSON", "JSON_EXTRACT", "JSON_ARRAY", "JSON_OBJECT",
+		"JSON_GROUP_ARRAY", "JSON_GROUP_OBJECT", "JSON_EACH", "JSON_TREE",
+	),
+	Types: []string{"INTEGER", "REAL", "TEXT", "BLOB", "NUMERIC", "BOOLEAN", "DATETIME"},
+}
+
+var postgresDialect = entity.SQLDialect{
+	Name: "postgres",
+	Keywords: withCommon(commonSQLKeywords,
+		"ILIKE", "SIMILAR", "LATERAL", "RETURNING", "FETCH", "FIRST", "NEXT", "ROWS",
+		"ONLY", "NULLS", "LAST", "FILTER", "WITHIN", "MATERIALIZED", "TABLESAMPLE",
+		"ANY", "SOME", "ARRAY", "INTERVAL", "SYMMETRIC",
+	),
+	Functions: withCommon(commonSQLFunctions,
+		"NOW", "CURRENT_DATE", "CURRENT_TIMESTAMP", "DATE_TRUNC", "DATE_PART", "EXTRACT",
+		"AGE", "TO_CHAR", "TO_DATE", "TO_TIMESTAMP", "TO_NUMBER", "CONCAT", "CONCAT_WS",
+		"STRING_AGG", "ARRAY_AGG", "ARRAY_LENGTH", "UNNEST", "GENERATE_SERIES", "SPLIT_PART",
+		"REGEXP_REPLACE", "REGEXP_MATCHES", "POSITION", "LEFT", "RIGHT", "GREATEST", "LEAST",
+		"JSONB_BUILD_OBJECT", "JSON_BUILD_OBJECT", "JSONB_AGG", "JSON_AGG", "JSONB_EXTRACT_PATH_TEXT",
+		"JSONB_ARRAY_ELEMENTS", "PERCENTILE_CONT", "PERCENTILE_DISC", "MODE", "NTILE",
+		"GEN_RANDOM_UUID", "MD5",
+	),
+	Types: []string{
+		"smallint", "integer", "bigint", "numeric", "real", "double precision", "serial",
+		"bigserial", "boolean", "text", "varchar", "char", "uuid", "date", "time",
+		"timestamp", "timestamptz", "interval", "json", "jsonb", "bytea", "inet",
+	},
+}
+
+var bigQueryDialect = entity.SQLDialect{
+	Name: "bigquery",
+	Keywords: withCommon(commonSQLKeywords,
+		"QUALIFY", "UNNEST", "STRUCT", "ARRAY", "SAFE_CAST", "EXCEPT", "REPLACE",
+		"TABLESAMPLE", "SYSTEM", "PIVOT", "UNPIVOT", "IGNORE", "RESPECT", "NULLS",
+		"INTERVAL", "FOR", "SYSTEM_TIME", "OF",
+	),
+	Functions: withCommon(commonSQLFunctions,
+		"COUNTIF", "APPROX_COUNT_DISTINCT", "APPROX_QUANTILES", "APPROX_TOP_COUNT", "ANY_VALUE",
+		"ARRAY_AGG", "ARRAY_LENGTH", "STRING_AGG", "CONCAT", "FORMAT", "SPLIT", "REGEXP_CONTAINS",
+		"REGEXP_EXTRACT", "REGEXP_REPLACE", "SAFE_DIVIDE", "IFNULL", "IF", "GENERATE_ARRAY",
+		"GENERATE_DATE_ARRAY", "CURRENT_DATE", "CURRENT_TIMESTAMP", "DATE", "DATETIME", "TIMESTAMP",
+		"DATE_TRUNC", "DATE_ADD", "DATE_SUB", "DATE_DIFF", "TIMESTAMP_TRUNC", "TIMESTAMP_DIFF",
+		"FORMAT_DATE", "FORMAT_TIMESTAMP", "PARSE_DATE", "PARSE_TIMESTAMP", "EXTRACT",
+		"JSON_EXTRACT", "JSON_EXTRACT_SCALAR", "JSON_VALUE", "JSON_QUERY", "TO_JSON_STRING",
+		"FARM_FINGERPRINT", "GENERATE_UUID",
+	),
+	Types: []string{
+		"INT64", "NUMERIC", "BIGNUMERIC", "FLOAT64", "BOOL", "STRING", "BYTES", "DATE",
+		"DATETIME", "TIME", "TIMESTAMP", "INTERVAL", "GEOGRAPHY", "JSON", "ARRAY", "STRUCT",
+	},
+}
+
+func withCommon(common []string, extra ...string) []string {
+	merged := make([]string, 0, len(common)+len(extra))
+	merged = append(merged, common...)
+	seen := make(map[string]bool, len(common))
+	for _, s := range common {
+		seen[s] = true
+	}
+	for _, s := range extra {
+		if !seen[s] {
+			merged = append(merged, s)
+			seen[s] = true
+		}
+	}
+	return merged
+}
@@ -2,17 +2,21 @@ package database
 
 import (
 	"fmt"
+	"slices"
 
 	"github.com/3-lines-studio/datafrost/internal/core/entity"
+	"github.com/3-lines-studio/datafrost/internal/usecase/port"
 )
 
 type Factory struct {
 	adapters map[string]entity.AdapterRegistration
+	resolver port.CredentialResolver
 }
 
-func NewFactory() *Factory {
+func NewFactory(resolver port.CredentialResolver) *Factory {
 	factory := &Factory{
 		adapters: make(map[string]entity.AdapterRegistration),
+		resolver: resolver,
 	}
 
 	factory.Register(newSQLiteAdapterRegistration())
@@ -43,6 +47,26 @@ func (f *Factory) GetAdapterInfo(adapterType string) (entity.AdapterInfo, error)
 	return reg.Info, nil
 }
 
+func (f *Factory) GetDialect(adapterType string) (entity.SQLDialect, error) {
+	reg, exists := f.adapters[adapterType]
+	if !exists {
+		return entity.SQLDialect{}, fmt.Errorf("unknown adapter type: %s", adapterType)
+	}
+	return reg.Dialect, nil
+}
+
+func (f *Factory) MapColumnType(fromType, toType, columnType string) (string, error) {
+	fromDialect, err := SQLDialectName(fromType)
+	if err != nil {
+		return "", err
+	}
+	toDialect, err := SQLDialectName(toType)
+	if err != nil {
+		return "", err
+	}
+	return ConvertColumnType(fromDialect, toDialect, columnType), nil
+}
+
 func (f *Factory) ListAdapters() []entity.AdapterInfo {
 	infos := make([]entity.AdapterInfo, 0, len(f.adapters))
 	for _, reg := range f.adapters {
@@ -51,16 +75,35 @@ func (f *Factory) ListAdapters() []entity.AdapterInfo {
 	return infos
 }
 
-func (f *Factory) TestConnection(adapterType string, credentials map[string]any) error {
+func (f *Factory) TestConnection(adapterType string, credentials map[string]any, trusted bool) error {
 	adapter, err := f.GetAdapter(adapterType)
 	if err != nil {
 		return err
 	}
 	defer func() { _ = adapter.Close() }()
 
-	if err := adapter.Connect(credentials); err != nil {
+	resolved, err := f.resolver.Resolve(credentials, trusted, f.secretFields(adapterType))
+	if err != nil {
+		return err
+	}
+	if err := adapter.Connect(resolved); err != nil {
 		return err
 	}
 
 	return adapter.Ping()
 }
+
+func (f *Factory) secretFields(adapterType string) map[string]bool {
+	info := f.adapters[adapterType].Info
+	fields := slices.Clone(info.UIConfig.Fields)
+	for _, mode := range info.UIConfig.Modes {
+		fields = append(fields, mode.Fields...)
+	}
+	secret := make(map[string]bool)
+	for _, field := range fields {
+		if field.Secret {
+			secret[field.Key] = true
+		}
+	}
+	return secret
+}
@@ -0,0 +1,118 @@
+package database
+
+import (
+	"sync"
+	"time"
+
+	"github.com/3-lines-studio/datafrost/internal/core/entity"
+)
+
+type metadataEntry struct {
+	tables   []entity.TableInfo
+	tablesAt time.Time
+	schemas  map[string]cachedSchema
+}
+
+type cachedSchema struct {
+	schema    *entity.TableSchema
+	fetchedAt time.Time
+}
+
+type MetadataCache struct {
+	mu      sync.Mutex
+	entries map[int64]*metadataEntry
+	ttls    map[int64]time.Duration
+}
+
+func NewMetadataCache() *MetadataCache {
+	return &MetadataCache{
+		entries: make(map[int64]*metadataEntry),
+		ttls:    make(map[int64]time.Duration),
+	}
+}
+
+func (c *MetadataCache) GetTables(id int64, maxAge time.Duration) ([]entity.TableInfo, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[id]
+	if !ok || entry.tables == nil || time.Since(entry.tablesAt) > maxAge {
+		return nil, false
+	}
+	return entry.tables, true
+}
+
+func (c *MetadataCache) SetTables(id int64, tables []entity.TableInfo) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if tables == nil {
+		tables = []entity.TableInfo{}
+	}
+	entry := c.entry(id)
+	entry.tables = tables
+	entry.tablesAt = time.Now()
+}
+
+func (c *MetadataCache) GetTableSchema(id int64, tableName string, maxAge time.Duration) (*entity.TableSchema, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[id]
+	if !ok {
+		return nil, false
+	}
+	cached, ok := entry.schemas[tableName]
+	if !ok || time.Since(cached.fetchedAt) > maxAge {
+		return nil, false
+	}
+	return cached.schema, true
+}
+
+func (c *MetadataCache) SetTableSchema(id int64, tableName string, schema *entity.TableSchema) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entry(id).schemas[tableName] = cachedSchema{schema: schema, fetchedAt: time.Now()}
+}
+
+func (c *MetadataCache) GetTTL(id int64) (time.Duration, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ttl, ok := c.ttls[id]
+	return ttl, ok
+}
+
+func (c *MetadataCache) SetTTL(id int64, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.ttls[id] = ttl
+}
+
+// Invalidate drops the cached metadata but keeps the TTL, which only changes
+// through SetTTL.
+func (c *MetadataCache) Invalidate(id int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, id)
+}
+
+func (c *MetadataCache) Remove(id int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, id)
+	delete(c.ttls, id)
+}
+
+func (c *MetadataCache) entry(id int64) *metadataEntry {
+	entry, ok := c.entries[id]
+	if !ok {
+		entry = &metadataEntry{schemas: make(map[string]cachedSchema)}
+		c.entries[id] = entry
+	}
+	return entry
+}
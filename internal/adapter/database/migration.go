@@ -0,0 +1,253 @@
+package database
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/3-lines-studio/datafrost/internal/core/entity"
+)
+
+type MigrationGenerator struct{}
+
+func NewMigrationGenerator() *MigrationGenerator {
+	return &MigrationGenerator{}
+}
+
+func (g *MigrationGenerator) MigrationScript(sourceType, targetType string, diff *entity.SchemaDiff, missing []*entity.TableSchema) (string, error) {
+	from, err := SQLDialectName(sourceType)
+	if err != nil {
+		return "", err
+	}
+	to, err := SQLDialectName(targetType)
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "-- Migration from %s (connection %d) to %s (connection %d)\n", sourceType, diff.SourceConnectionID, targetType, diff.TargetConnectionID)
+	b.WriteString("-- Destructive statements are commented out; review before running.\n")
+
+	// SQLite cannot add a foreign key to an existing table, so there they are
+	// declared inline; SQLite does not check that the referenced table exists
+	// yet.
+	for _, schema := range missing {
+		fmt.Fprintf(&b, "\n-- Missing table %s\n", schema.TableName)
+		var inline []string
+		if to == "sqlite" {
+			for _, fk := range schema.ForeignKeys {
+				inline = append(inline, foreignKeyClause(to, fk))
+			}
+		}
+		b.WriteString(createTableStatement(from, to, schema.TableName, schema.Columns, inline))
+	}
+	// Indexes and foreign keys follow every CREATE TABLE so a foreign key can
+	// reference a missing table that sorts after its own.
+	for _, schema := range missing {
+		var extra strings.Builder
+		for _, idx := range schema.Indexes {
+			if strings.HasPrefix(idx.Name, "sqlite_autoindex_") || isPrimaryKeyIndex(schema, idx) {
+				continue
+			}
+			writeCreateIndex(&extra, to, schema.TableName, idx)
+		}
+		if to != "sqlite" {
+			for _, fk := range schema.ForeignKeys {
+				writeAddForeignKey(&extra, to, schema.TableName, fk)
+			}
+		}
+		if extra.Len() > 0 {
+			fmt.Fprintf(&b, "\n-- Indexes and foreign keys for %s\n", schema.TableName)
+			b.WriteString(extra.String())
+		}
+	}
+
+	for _, td := range diff.Tables {
+		fmt.Fprintf(&b, "\n-- Table %s\n", td.Table)
+		table := QuoteIdentifier(to, td.Table)
+
+		for _, col := range td.AddedColumns {
+			colType := ConvertColumnType(from, to, col.Type)
+			def := fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, QuoteIdentifier(to, col.Name), colType)
+			defaultClause := columnDefault(from, to, col.DefaultValue)
+			if !col.Nullable {
+				if defaultClause != "" {
+					def += " NOT NULL" + defaultClause
+				} else {
+					b.WriteString("-- NOT NULL omitted: backfill the column, then add the constraint\n")
+				}
+			} else {
+				def += defaultClause
+			}
+			b.WriteString(def + ";\n")
+		}
+
+		for _, col := range td.RemovedColumns {
+			fmt.Fprintf(&b, "-- ALTER TABLE %s DROP COLUMN %s;\n", table, QuoteIdentifier(to, col.Name))
+		}
+
+		for _, cd := range td.ChangedColumns {
+			writeAlterColumn(&b, from, to, table, cd)
+		}
+
+		if td.SourcePrimaryKey != nil || td.TargetPrimaryKey != nil {
+			fmt.Fprintf(&b, "-- Primary key differs: source (%s), target (%s)\n",
+				strings.Join(td.SourcePrimaryKey, ", "), strings.Join(td.TargetPrimaryKey, ", "))
+		}
+
+		for _, idx := range td.RemovedIndexes {
+			switch to {
+			case "postgres", "sqlite":
+				fmt.Fprintf(&b, "-- DROP INDEX %s;\n", QuoteIdentifier(to, idx.Name))
+			default:
+				fmt.Fprintf(&b, "-- Index %s exists only in target\n", idx.Name)
+			}
+		}
+		for _, idx := range td.AddedIndexes {
+			writeCreateIndex(&b, to, td.Table, idx)
+		}
+		// Dropping an index loses no data, and the CREATE that follows would
+		// fail while the old definition still holds the name.
+		for _, id := range td.ChangedIndexes {
+			if to == "bigquery" {
+				fmt.Fprintf(&b, "-- Index %s differs between source and target\n", id.Name)
+				continue
+			}
+			fmt.Fprintf(&b, "DROP INDEX %s;\n", QuoteIdentifier(to, id.Name))
+			writeCreateIndex(&b, to, td.Table, id.Source)
+		}
+
+		for _, fk := range td.RemovedForeignKeys {
+			if to == "postgres" && fk.Name != "" {
+				fmt.Fprintf(&b, "-- ALTER TABLE %s DROP CONSTRAINT %s;\n", table, QuoteIdentifier(to, fk.Name))
+			} else {
+				fmt.Fprintf(&b, "-- Foreign key %s exists only in target\n", foreignKeyDescription(fk))
+			}
+		}
+		for _, fk := range td.AddedForeignKeys {
+			writeAddForeignKey(&b, to, td.Table, fk)
+		}
+
+		for _, c := range td.RemovedConstraints {
+			if to == "postgres" {
+				fmt.Fprintf(&b, "-- ALTER TABLE %s DROP CONSTRAINT %s;\n", table, QuoteIdentifier(to, c.Name))
+			} else {
+				fmt.Fprintf(&b, "-- Constraint %s %s exists only in target\n", c.Type, c.Definition)
+			}
+		}
+		for _, c := range td.AddedConstraints {
+			if to == "postgres" {
+				fmt.Fprintf(&b, "ALTER TABLE %s ADD CONSTRAINT %s %s;\n", table, QuoteIdentifier(to, c.Name), c.Definition)
+			} else {
+				fmt.Fprintf(&b, "-- Constraint %s %s requires rebuilding the table\n", c.Type, c.Definition)
+			}
+		}
+	}
+
+	for _, name := range diff.ExtraTables {
+		fmt.Fprintf(&b, "\n-- Table %s exists only in target\n-- DROP TABLE %s;\n", name, QuoteIdentifier(to, name))
+	}
+
+	return b.String(), nil
+}
+
+func writeAlterColumn(b *strings.Builder, from, to, table string, cd entity.ColumnDiff) {
+	column := QuoteIdentifier(to, cd.Name)
+	switch to {
+	case "postgres":
+		if cd.TypeChanged {
+			newType := ConvertColumnType(from, to, cd.SourceType)
+			fmt.Fprintf(b, "ALTER TABLE %s ALTER COLUMN %s TYPE %s USING %s::%s;\n", table, column, newType, column, newType)
+		}
+		if cd.NullabilityChanged {
+			if cd.SourceNullable {
+				fmt.Fprintf(b, "ALTER TABLE %s ALTER COLUMN %s DROP NOT NULL;\n", table, column)
+			} else {
+				fmt.Fprintf(b, "ALTER TABLE %s ALTER COLUMN %s SET NOT NULL;\n", table, column)
+			}
+		}
+		if cd.DefaultChanged {
+			if cd.SourceDefault == "" {
+				fmt.Fprintf(b, "ALTER TABLE %s ALTER COLUMN %s DROP DEFAULT;\n", table, column)
+			} else if isSerialDefault(to, cd.SourceDefault) {
+				fmt.Fprintf(b, "ALTER TABLE %s ALTER COLUMN %s ADD GENERATED BY DEFAULT AS IDENTITY;\n", table, column)
+			} else {
+				fmt.Fprintf(b, "ALTER TABLE %s ALTER COLUMN %s SET DEFAULT %s;\n", table, column, cd.SourceDefault)
+			}
+		}
+	case "bigquery":
+		if cd.TypeChanged {
+			fmt.Fprintf(b, "ALTER TABLE %s ALTER COLUMN %s SET DATA TYPE %s;\n", table, column, ConvertColumnType(from, to, cd.SourceType))
+		}
+		if cd.NullabilityChanged {
+			if cd.SourceNullable {
+				fmt.Fprintf(b, "ALTER TABLE %s ALTER COLUMN %s DROP NOT NULL;\n", table, column)
+			} else {
+				fmt.Fprintf(b, "-- BigQuery cannot add NOT NULL to existing column %s\n", cd.Name)
+			}
+		}
+		if cd.DefaultChanged {
+			fmt.Fprintf(b, "-- Default for %s differs: source %q, target %q\n", cd.Name, cd.SourceDefault, cd.TargetDefault)
+		}
+	default:
+		fmt.Fprintf(b, "-- SQLite cannot alter column %s (type %s -> %s, nullable %t -> %t); rebuild the table to apply\n",
+			cd.Name, cd.TargetType, ConvertColumnType(from, to, cd.SourceType), cd.TargetNullable, cd.SourceNullable)
+	}
+}
+
+func writeCreateIndex(b *strings.Builder, to, table string, idx entity.IndexInfo) {
+	if to == "bigquery" {
+		fmt.Fprintf(b, "-- BigQuery has no secondary indexes; skipped %s\n", idx.Name)
+		return
+	}
+	columns := make([]string, len(idx.Columns))
+	for i, col := range idx.Columns {
+		columns[i] = QuoteIdentifier(to, col)
+	}
+	unique := ""
+	if idx.Unique {
+		unique = "UNIQUE "
+	}
+	fmt.Fprintf(b, "CREATE %sINDEX %s ON %s (%s);\n", unique, QuoteIdentifier(to, idx.Name), QuoteIdentifier(to, table), strings.Join(columns, ", "))
+}
+
+func writeAddForeignKey(b *strings.Builder, to, table string, fk entity.ForeignKeyInfo) {
+	if to != "postgres" {
+		fmt.Fprintf(b, "-- Foreign key %s cannot be added with ALTER TABLE in %s\n", foreignKeyDescription(fk), to)
+		return
+	}
+	fmt.Fprintf(b, "ALTER TABLE %s ADD %s;\n", QuoteIdentifier(to, table), foreignKeyClause(to, fk))
+}
+
+func foreignKeyClause(to string, fk entity.ForeignKeyInfo) string {
+	columns := make([]string, len(fk.Columns))
+	for i, col := range fk.Columns {
+		columns[i] = QuoteIdentifier(to, col)
+	}
+	refs := make([]string, len(fk.ReferencedColumns))
+	for i, col := range fk.ReferencedColumns {
+		refs[i] = QuoteIdentifier(to, col)
+	}
+	constraint := ""
+	if fk.Name != "" {
+		constraint = "CONSTRAINT " + QuoteIdentifier(to, fk.Name) + " "
+	}
+	return fmt.Sprintf("%sFOREIGN KEY (%s) REFERENCES %s (%s)",
+		constraint, strings.Join(columns, ", "), QuoteIdentifier(to, fk.ReferencedTable), strings.Join(refs, ", "))
+}
+
+func foreignKeyDescription(fk entity.ForeignKeyInfo) string {
+	return fmt.Sprintf("(%s) -> %s(%s)", strings.Join(fk.Columns, ", "), fk.ReferencedTable, strings.Join(fk.ReferencedColumns, ", "))
+}
+
+func isPrimaryKeyIndex(schema *entity.TableSchema, idx entity.IndexInfo) bool {
+	if !idx.Unique {
+		return false
+	}
+	var pk []string
+	for _, col := range schema.Columns {
+		if col.IsPrimaryKey {
+			pk = append(pk, col.Name)
+		}
+	}
+	return len(pk) > 0 && strings.Join(pk, ",") == strings.Join(idx.Columns, ",")
+}
@@ -0,0 +1,156 @@
+package database
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/3-lines-studio/datafrost/internal/core/entity"
+)
+
+type ProfileQueryBuilder struct{}
+
+func NewProfileQueryBuilder() *ProfileQueryBuilder {
+	return &ProfileQueryBuilder{}
+}
+
+func (b *ProfileQueryBuilder) Source(conn *entity.Connection, table, query string) (string, error) {
+	dialect, err := SQLDialectName(conn.Type)
+	if err != nil {
+		return "", err
+	}
+
+	if query != "" {
+		query = strings.TrimSuffix(strings.TrimSpace(query), ";")
+		return "(" + query + ") AS profile_source", nil
+	}
+
+	if dialect == "bigquery" {
+		projectID, _ := conn.Credentials["project_id"].(string)
+		dataset, _ := conn.Credentials["dataset"].(string)
+		return fmt.Sprintf("`%s.%s.%s`", projectID, dataset, table), nil
+	}
+	return QuoteIdentifier(dialect, table), nil
+}
+
+func (b *ProfileQueryBuilder) ColumnKind(adapterType, columnType string) string {
+	dialect, err := SQLDialectName(adapterType)
+	if err != nil {
+		return entity.ProfileKindText
+	}
+
+	base, _ := splitColumnType(columnType)
+	switch {
+	case strings.HasPrefix(base, "_"), strings.HasSuffix(base, "[]"),
+		strings.HasPrefix(base, "ARRAY"), strings.HasPrefix(base, "STRUCT"),
+		base == "RECORD", base == "GEOGRAPHY", base == "XML", base == "USER-DEFINED":
+		return entity.ProfileKindComplex
+	}
+
+	kind, _ := columnKind(dialect, columnType)
+	switch kind {
+	case kindInteger, kindBigInt, kindSmallInt, kindReal, kindDouble, kindDecimal:
+		return entity.ProfileKindNumeric
+	case kindDate, kindTimestamp, kindTimestampTZ, kindTime:
+		return entity.ProfileKindTemporal
+	case kindBoolean:
+		return entity.ProfileKindBoolean
+	case kindBlob:
+		return entity.ProfileKindBinary
+	case kindJSON:
+		return entity.ProfileKindComplex
+	}
+	return entity.ProfileKindText
+}
+
+func (b *ProfileQueryBuilder) StatsQuery(adapterType, source, column, columnType string, approximate bool) (string, bool, error) {
+	dialect, err := SQLDialectName(adapterType)
+	if err != nil {
+		return "", false, err
+	}
+
+	col := QuoteIdentifier(dialect, column)
+	approximate = approximate && dialect == "bigquery"
+	distinct := "COUNT(DISTINCT " + col + ")"
+	if approximate {
+		distinct = "APPROX_COUNT_DISTINCT(" + col + ")"
+	}
+	minMax := "MIN(" + col + "), MAX(" + col + ")"
+	average := "NULL"
+
+	switch b.ColumnKind(adapterType, columnType) {
+	case entity.ProfileKindNumeric:
+		average = "CAST(AVG(" + col + ") AS " + profileDoubleType(dialect) + ")"
+	case entity.ProfileKindBoolean, entity.ProfileKindBinary:
+		minMax = "NULL, NULL"
+	case entity.ProfileKindComplex:
+		distinct = "NULL"
+		minMax = "NULL, NULL"
+	default:
+		// Postgres has no MIN/MAX aggregate for uuid.
+		if kind, _ := columnKind(dialect, columnType); kind == kindUUID {
+			minMax = "NULL, NULL"
+		}
+	}
+
+	query := fmt.Sprintf("SELECT COUNT(*) - COUNT(%s), %s, %s, %s FROM %s", col, distinct, minMax, average, source)
+	return query, approximate, nil
+}
+
+func (b *ProfileQueryBuilder) TopValuesQuery(adapterType, source, column string, limit int) (string, error) {
+	dialect, err := SQLDialectName(adapterType)
+	if err != nil {
+		return "", err
+	}
+
+	col := QuoteIdentifier(dialect, column)
+	return fmt.Sprintf(
+		"SELECT %s, COUNT(*) FROM %s WHERE %s IS NOT NULL GROUP BY %s ORDER BY 2 DESC, 1 LIMIT %d",
+		col, source, col, col, limit,
+	), nil
+}
+
+func (b *ProfileQueryBuilder) HistogramQuery(adapterType, source, column string, min, max float64, buckets int) (string, error) {
+	dialect, err := SQLDialectName(adapterType)
+	if err != nil {
+		return "", err
+	}
+
+	col := QuoteIdentifier(dialect, column)
+	width := (max - min) / float64(buckets)
+	offset := fmt.Sprintf("(%s - (%s)) / %s", col, profileFloat(min), profileFloat(width))
+
+	var bucket string
+	switch dialect {
+	case "sqlite":
+		bucket = "CAST(" + offset + " AS INTEGER)"
+	case "postgres":
+		bucket = "CAST(FLOOR(" + offset + ") AS INTEGER)"
+	default:
+		bucket = "CAST(FLOOR(" + offset + ") AS INT64)"
+	}
+
+	return fmt.Sprintf(
+		"SELECT bucket, COUNT(*) FROM (SELECT CASE WHEN %s >= (%s) THEN %d ELSE %s END AS bucket FROM %s WHERE %s IS NOT NULL) AS histogram GROUP BY bucket ORDER BY bucket",
+		col, profileFloat(max), buckets-1, bucket, source, col,
+	), nil
+}
+
+func profileDoubleType(dialect string) string {
+	switch dialect {
+	case "sqlite":
+		return "REAL"
+	case "postgres":
+		return "DOUBLE PRECISION"
+	}
+	return "FLOAT64"
+}
+
+func profileFloat(f float64) string {
+	s := strconv.FormatFloat(f, 'g', -1, 64)
+	// Keep the literal a float so SQLite does not fall back to integer division.
+	if !strings.ContainsAny(s, ".eE") {
+		s += ".0"
+	}
+	return s
+}
@@ -0,0 +1,303 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/3-lines-studio/datafrost/internal/core/entity"
+)
+
+var (
+	errMultipleStatements = errors.New("only a single statement is allowed")
+	errWritingPragma      = errors.New("only PRAGMAs that read settings or schema information are allowed")
+)
+
+type sqlToken struct {
+	word  string
+	depth int
+}
+
+// Splits on top-level semicolons and collects the bare words of each
+// statement, skipping string literals, quoted identifiers, comments and
+// PostgreSQL dollar-quoted bodies so keywords inside them are not mistaken
+// for statements.
+func scanStatements(query string) [][]sqlToken {
+	var statements [][]sqlToken
+	var current []sqlToken
+	depth := 0
+	flush := func() {
+		if len(current) > 0 {
+			statements = append(statements, current)
+		}
+		current = nil
+		depth = 0
+	}
+
+	for i := 0; i < len(query); {
+		c := query[i]
+		switch {
+		case c == '-' && strings.HasPrefix(query[i:], "--"):
+			end := strings.IndexByte(query[i:], '\n')
+			if end < 0 {
+				i = len(query)
+			} else {
+				i += end + 1
+			}
+		case c == '/' && strings.HasPrefix(query[i:], "/*"):
+			end := strings.Index(query[i+2:], "*/")
+			if end < 0 {
+				i = len(query)
+			} else {
+				i += end + 4
+			}
+		case c == '\'' || c == '"' || c == '`':
+			i = skipQuoted(query, i, c)
+			current = append(current, sqlToken{depth: depth})
+		case c == '[':
+			end := strings.IndexByte(query[i:], ']')
+			if end < 0 {
+				i = len(query)
+			} else {
+				i += end + 1
+			}
+			current = append(current, sqlToken{depth: depth})
+		case c == '$':
+			if tag, ok := dollarTag(query[i:]); ok {
+				end := strings.Index(query[i+len(tag):], tag)
+				if end < 0 {
+					i = len(query)
+				} else {
+					i += len(tag) + end + len(tag)
+				}
+				current = append(current, sqlToken{depth: depth})
+				continue
+			}
+			i++
+		case c == '(':
+			depth++
+			i++
+		case c == ')':
+			depth--
+			i++
+		case c == ';':
+			flush()
+			i++
+		case isWordByte(c):
+			start := i
+			for i < len(query) && isWordByte(query[i]) {
+				i++
+			}
+			current = append(current, sqlToken{word: strings.ToUpper(query[start:i]), depth: depth})
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		default:
+			current = append(current, sqlToken{depth: depth})
+			i++
+		}
+	}
+	flush()
+	return statements
+}
+
+func skipQuoted(query string, i int, quote byte) int {
+	for j := i + 1; j < len(query); j++ {
+		if query[j] == quote {
+			// A doubled quote is an escaped quote, not the end.
+			if j+1 < len(query) && query[j+1] == quote {
+				j++
+				continue
+			}
+			return j + 1
+		}
+	}
+	return len(query)
+}
+
+func dollarTag(s string) (string, bool) {
+	end := strings.IndexByte(s[1:], '$')
+	if end < 0 {
+		return "", false
+	}
+	tag := s[:end+2]
+	name := tag[1 : len(tag)-1]
+	// $1 is a bind parameter, not the start of a tag.
+	if name != "" && name[0] >= '0' && name[0] <= '9' {
+		return "", false
+	}
+	for _, c := range []byte(name) {
+		if !isWordByte(c) {
+			return "", false
+		}
+	}
+	return tag, true
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+var statementVerbs = []string{"SELECT", "VALUES", "INSERT", "UPDATE", "DELETE", "REPLACE", "MERGE"}
+
+// Returns the verb that decides what a statement does. For WITH it is the
+// first top-level verb after the CTE definitions, so WITH ... DELETE is
+// reported as DELETE.
+func statementVerb(tokens []sqlToken) string {
+	if len(tokens) == 0 {
+		return ""
+	}
+	if tokens[0].word != "WITH" {
+		return tokens[0].word
+	}
+	for _, tok := range tokens[1:] {
+		if tok.depth == 0 && slices.Contains(statementVerbs, tok.word) {
+			return tok.word
+		}
+	}
+	return "WITH"
+}
+
+func singleStatement(query string) ([]sqlToken, error) {
+	statements := scanStatements(query)
+	if len(statements) == 0 {
+		return nil, fmt.Errorf("query is empty")
+	}
+	if len(statements) > 1 {
+		return nil, errMultipleStatements
+	}
+	return statements[0], nil
+}
+
+// PRAGMAs that only report state. Assignments are always rejected, and the
+// ones taking an argument here treat it as a table or index name.
+var readOnlyPragmas = map[string]bool{
+	"application_id": false, "auto_vacuum": false, "cache_size": false, "collation_list": false,
+	"compile_options": false, "data_version": false, "database_list": false, "encoding": false,
+	"foreign_key_check": true, "foreign_key_list": true, "foreign_keys": false, "freelist_count": false,
+	"function_list": false, "index_info": true, "index_list": true, "index_xinfo": true,
+	"integrity_check": true, "journal_mode": false, "module_list": false, "page_count": false,
+	"page_size": false, "pragma_list": false, "quick_check": true, "schema_version": false,
+	"table_info": true, "table_list": true, "table_xinfo": true, "user_version": false,
+}
+
+func checkReadOnlyPragma(query string, tokens []sqlToken) error {
+	if strings.Contains(stripSQLLiterals(query), "=") {
+		return errWritingPragma
+	}
+	names := make([]string, 0, 2)
+	for _, tok := range tokens[1:] {
+		if tok.depth > 0 {
+			break
+		}
+		names = append(names, tok.word)
+	}
+	if len(names) == 0 {
+		return errWritingPragma
+	}
+	// PRAGMA schema.name: the pragma is the last bare word before any
+	// argument list.
+	name := strings.ToLower(names[len(names)-1])
+	takesArgument, ok := readOnlyPragmas[name]
+	if !ok {
+		return errWritingPragma
+	}
+	if !takesArgument && slices.ContainsFunc(tokens, func(tok sqlToken) bool { return tok.depth > 0 }) {
+		return errWritingPragma
+	}
+	return nil
+}
+
+func stripSQLLiterals(query string) string {
+	var b strings.Builder
+	for i := 0; i < len(query); {
+		c := query[i]
+		if c == '\'' || c == '"' || c == '`' {
+			i = skipQuoted(query, i, c)
+			continue
+		}
+		b.WriteByte(c)
+		i++
+	}
+	return b.String()
+}
+
+// Validates what SQLite and Turso accept on the read-only paths: one SELECT
+// (possibly behind a WITH) or a PRAGMA from readOnlyPragmas.
+func checkSQLiteReadOnly(query string, allowPragma bool) error {
+	tokens, err := singleStatement(query)
+	if err != nil {
+		return err
+	}
+	switch statementVerb(tokens) {
+	case "SELECT", "VALUES":
+		return nil
+	case "PRAGMA":
+		if allowPragma {
+			return checkReadOnlyPragma(query, tokens)
+		}
+	}
+	if allowPragma {
+		return fmt.Errorf("only SELECT, WITH, and PRAGMA queries are allowed")
+	}
+	return fmt.Errorf("only SELECT and WITH queries are allowed")
+}
+
+func checkSelectOnly(query string) error {
+	tokens, err := singleStatement(query)
+	if err != nil {
+		return err
+	}
+	if verb := statementVerb(tokens); verb != "SELECT" && verb != "WITH" {
+		return fmt.Errorf("only SELECT and WITH queries are allowed")
+	}
+	return nil
+}
+
+// Explain prefixes the query with EXPLAIN, which would only cover the first
+// statement and let the rest run as-is.
+func checkExplainable(query string) error {
+	if err := checkSelectOnly(query); err != nil {
+		return fmt.Errorf("cannot explain query: %w", err)
+	}
+	return nil
+}
+
+type rowQuerier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// query_only makes SQLite refuse any write on this connection, covering what
+// statement checks cannot see, such as writes hidden in triggers or
+// virtual tables.
+func streamQueryOnly(ctx context.Context, db *sql.DB, query string, sink entity.RowSink) error {
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+
+	if _, err := conn.ExecContext(ctx, "PRAGMA query_only = ON"); err != nil {
+		return err
+	}
+	// Restored on a fresh context so a cancelled query does not leave a
+	// pooled connection stuck in read-only mode.
+	defer func() { _, _ = conn.ExecContext(context.Background(), "PRAGMA query_only = OFF") }()
+
+	return streamRows(ctx, conn, query, nil, sink)
+}
+
+// Runs in a READ ONLY transaction that is always rolled back, so statements
+// that slip past the checks (data-modifying CTEs, volatile functions) cannot
+// change anything.
+func streamReadOnlyTx(ctx context.Context, db *sql.DB, query string, sink entity.RowSink) error {
+	tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	return streamRows(ctx, tx, query, nil, sink)
+}
@@ -0,0 +1,404 @@
+package database
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/3-lines-studio/datafrost/internal/core/entity"
+)
+
+const (
+	kindInteger     = "integer"
+	kindBigInt      = "bigint"
+	kindSmallInt    = "smallint"
+	kindReal        = "real"
+	kindDouble      = "double"
+	kindDecimal     = "decimal"
+	kindBoolean     = "boolean"
+	kindVarchar     = "varchar"
+	kindText        = "text"
+	kindBlob        = "blob"
+	kindDate        = "date"
+	kindTimestamp   = "timestamp"
+	kindTimestampTZ = "timestamptz"
+	kindTime        = "time"
+	kindJSON        = "json"
+	kindUUID        = "uuid"
+)
+
+func SQLDialectName(adapterType string) (string, error) {
+	switch adapterType {
+	case "sqlite", "turso":
+		return "sqlite", nil
+	case "postgres", "postgresql":
+		return "postgres", nil
+	case "bigquery":
+		return "bigquery", nil
+	}
+	return "", fmt.Errorf("unsupported SQL dialect: %s", adapterType)
+}
+
+func QuoteIdentifier(dialect, name string) string {
+	if dialect == "bigquery" {
+		return "`" + strings.ReplaceAll(name, "`", "\\`") + "`"
+	}
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// Postgres puts modifiers mid-name (timestamp(3) with time zone), so the
+// words after the parentheses are kept as part of the base type.
+func splitColumnType(typ string) (string, string) {
+	typ = strings.TrimSpace(typ)
+	open := strings.Index(typ, "(")
+	if open < 0 {
+		return strings.ToUpper(typ), ""
+	}
+	closing := strings.LastIndex(typ, ")")
+	if closing < open {
+		return strings.ToUpper(strings.TrimSpace(typ[:open])), strings.TrimSpace(typ[open+1:])
+	}
+	base := strings.TrimSpace(strings.TrimSpace(typ[:open]) + " " + strings.TrimSpace(typ[closing+1:]))
+	return strings.ToUpper(base), strings.TrimSpace(typ[open+1 : closing])
+}
+
+func columnKind(dialect, typ string) (string, string) {
+	base, params := splitColumnType(typ)
+	switch base {
+	case "INT", "INTEGER", "INT4", "SERIAL", "MEDIUMINT":
+		if dialect == "bigquery" {
+			return kindBigInt, ""
+		}
+		return kindInteger, ""
+	case "BIGINT", "INT8", "BIGSERIAL", "INT64":
+		return kindBigInt, ""
+	case "SMALLINT", "INT2", "TINYINT", "SMALLSERIAL":
+		return kindSmallInt, ""
+	case "REAL", "FLOAT4":
+		if dialect == "sqlite" {
+			return kindDouble, ""
+		}
+		return kindReal, ""
+	case "DOUBLE", "DOUBLE PRECISION", "FLOAT", "FLOAT8", "FLOAT64":
+		return kindDouble, ""
+	case "NUMERIC", "DECIMAL", "BIGNUMERIC", "BIGDECIMAL":
+		return kindDecimal, params
+	case "BOOL", "BOOLEAN":
+		return kindBoolean, ""
+	case "CHAR", "CHARACTER", "VARCHAR", "CHARACTER VARYING", "NCHAR", "NVARCHAR":
+		if params == "" {
+			return kindText, ""
+		}
+		return kindVarchar, params
+	case "TEXT", "STRING", "CLOB", "CITEXT", "NAME":
+		return kindText, ""
+	case "BLOB", "BYTEA", "BYTES", "BINARY", "VARBINARY":
+		return kindBlob, ""
+	case "DATE":
+		return kindDate, ""
+	case "DATETIME", "TIMESTAMP WITHOUT TIME ZONE":
+		return kindTimestamp, ""
+	case "TIMESTAMP":
+		if dialect == "bigquery" {
+			return kindTimestampTZ, ""
+		}
+		return kindTimestamp, ""
+	case "TIMESTAMPTZ", "TIMESTAMP WITH TIME ZONE":
+		return kindTimestampTZ, ""
+	case "TIME", "TIME WITHOUT TIME ZONE", "TIMETZ", "TIME WITH TIME ZONE":
+		return kindTime, ""
+	case "JSON", "JSONB":
+		return kindJSON, ""
+	case "UUID":
+		return kindUUID, ""
+	}
+	return kindText, ""
+}
+
+func ConvertColumnType(fromDialect, toDialect, typ string) string {
+	if fromDialect == toDialect && typ != "" {
+		return typ
+	}
+
+	kind, params := columnKind(fromDialect, typ)
+	switch toDialect {
+	case "postgres":
+		switch kind {
+		case kindInteger:
+			return "INTEGER"
+		case kindBigInt:
+			return "BIGINT"
+		case kindSmallInt:
+			return "SMALLINT"
+		case kindReal:
+			return "REAL"
+		case kindDouble:
+			return "DOUBLE PRECISION"
+		case kindDecimal:
+			return withParams("NUMERIC", params)
+		case kindBoolean:
+			return "BOOLEAN"
+		case kindVarchar:
+			return withParams("VARCHAR", params)
+		case kindBlob:
+			return "BYTEA"
+		case kindDate:
+			return "DATE"
+		case kindTimestamp:
+			return "TIMESTAMP"
+		case kindTimestampTZ:
+			return "TIMESTAMPTZ"
+		case kindTime:
+			return "TIME"
+		case kindJSON:
+			return "JSONB"
+		case kindUUID:
+			return "UUID"
+		}
+		return "TEXT"
+	case "bigquery":
+		switch kind {
+		case kindInteger, kindBigInt, kindSmallInt:
+			return "INT64"
+		case kindReal, kindDouble:
+			return "FLOAT64"
+		case kindDecimal:
+			return "NUMERIC"
+		case kindBoolean:
+			return "BOOL"
+		case kindBlob:
+			return "BYTES"
+		case kindDate:
+			return "DATE"
+		case kindTimestamp:
+			return "DATETIME"
+		case kindTimestampTZ:
+			return "TIMESTAMP"
+		case kindTime:
+			return "TIME"
+		case kindJSON:
+			return "JSON"
+		}
+		return "STRING"
+	default:
+		switch kind {
+		case kindInteger, kindBigInt, kindSmallInt:
+			return "INTEGER"
+		case kindReal, kindDouble:
+			return "REAL"
+		case kindDecimal:
+			return withParams("NUMERIC", params)
+		case kindBoolean:
+			return "BOOLEAN"
+		case kindVarchar:
+			return withParams("VARCHAR", params)
+		case kindBlob:
+			return "BLOB"
+		case kindDate:
+			return "DATE"
+		case kindTimestamp, kindTimestampTZ:
+			return "DATETIME"
+		case kindTime:
+			return "TIME"
+		}
+		return "TEXT"
+	}
+}
+
+func withParams(name, params string) string {
+	if params == "" {
+		return name
+	}
+	return name + "(" + params + ")"
+}
+
+func CreateTableStatement(fromDialect, toDialect, tableName string, columns []entity.ColumnInfo) string {
+	return createTableStatement(fromDialect, toDialect, tableName, columns, nil)
+}
+
+func createTableStatement(fromDialect, toDialect, tableName string, columns []entity.ColumnInfo, constraints []string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "CREATE TABLE %s (\n", QuoteIdentifier(toDialect, tableName))
+
+	var primaryKey []string
+	for i, col := range columns {
+		if i > 0 {
+			b.WriteString(",\n")
+		}
+		fmt.Fprintf(&b, "  %s %s", QuoteIdentifier(toDialect, col.Name), ConvertColumnType(fromDialect, toDialect, col.Type))
+		if !col.Nullable {
+			b.WriteString(" NOT NULL")
+		}
+		b.WriteString(columnDefault(fromDialect, toDialect, col.DefaultValue))
+		if col.IsPrimaryKey {
+			primaryKey = append(primaryKey, QuoteIdentifier(toDialect, col.Name))
+		}
+	}
+
+	if len(primaryKey) > 0 {
+		fmt.Fprintf(&b, ",\n  PRIMARY KEY (%s)", strings.Join(primaryKey, ", "))
+		if toDialect == "bigquery" {
+			b.WriteString(" NOT ENFORCED")
+		}
+	}
+	for _, constraint := range constraints {
+		b.WriteString(",\n  " + constraint)
+	}
+
+	b.WriteString("\n);\n")
+	return b.String()
+}
+
+// Serial columns read back as nextval('<table>_<column>_seq'), a sequence
+// the target does not have, so they are recreated as identity columns.
+func columnDefault(fromDialect, toDialect, defaultValue string) string {
+	if defaultValue == "" || fromDialect != toDialect {
+		return ""
+	}
+	if isSerialDefault(toDialect, defaultValue) {
+		return " GENERATED BY DEFAULT AS IDENTITY"
+	}
+	return " DEFAULT " + defaultValue
+}
+
+func isSerialDefault(dialect, defaultValue string) bool {
+	return dialect == "postgres" && strings.HasPrefix(defaultValue, "nextval(")
+}
+
+func SQLLiteral(fromDialect, toDialect, typ string, val any) string {
+	if val == nil {
+		return "NULL"
+	}
+
+	kind, _ := columnKind(fromDialect, typ)
+
+	switch v := val.(type) {
+	case bool:
+		if toDialect == "sqlite" {
+			if v {
+				return "1"
+			}
+			return "0"
+		}
+		return strings.ToUpper(strconv.FormatBool(v))
+	case int64:
+		return boolOrNumber(toDialect, kind, strconv.FormatInt(v, 10))
+	case int:
+		return boolOrNumber(toDialect, kind, strconv.Itoa(v))
+	case int32:
+		return boolOrNumber(toDialect, kind, strconv.FormatInt(int64(v), 10))
+	case float32:
+		return floatLiteral(toDialect, float64(v))
+	case float64:
+		return floatLiteral(toDialect, v)
+	case time.Time:
+		return timeLiteral(toDialect, kind, v)
+	case []byte:
+		if kind != kindBlob && utf8.Valid(v) {
+			return textLiteral(toDialect, kind, string(v))
+		}
+		return blobLiteral(toDialect, v)
+	case string:
+		return textLiteral(toDialect, kind, v)
+	default:
+		return stringLiteral(toDialect, fmt.Sprintf("%v", v))
+	}
+}
+
+func boolOrNumber(toDialect, kind, n string) string {
+	if kind == kindBoolean && toDialect != "sqlite" {
+		if n == "0" {
+			return "FALSE"
+		}
+		return "TRUE"
+	}
+	return n
+}
+
+func floatLiteral(toDialect string, f float64) string {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		special := "NaN"
+		if math.IsInf(f, 1) {
+			special = "Infinity"
+		} else if math.IsInf(f, -1) {
+			special = "-Infinity"
+		}
+		switch toDialect {
+		case "postgres":
+			return "'" + special + "'::double precision"
+		case "bigquery":
+			return "CAST('" + strings.ToLower(special) + "' AS FLOAT64)"
+		}
+		return "NULL"
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+func timeLiteral(toDialect, kind string, t time.Time) string {
+	switch kind {
+	case kindDate:
+		return typedLiteral(toDialect, "DATE", t.Format("2006-01-02"))
+	case kindTime:
+		return typedLiteral(toDialect, "TIME", t.Format("15:04:05.999999"))
+	case kindTimestamp:
+		s := t.Format("2006-01-02 15:04:05.999999")
+		if toDialect == "bigquery" {
+			return typedLiteral(toDialect, "DATETIME", s)
+		}
+		return typedLiteral(toDialect, "TIMESTAMP", s)
+	}
+	return typedLiteral(toDialect, "TIMESTAMP", t.Format("2006-01-02 15:04:05.999999-07:00"))
+}
+
+func typedLiteral(toDialect, typeName, s string) string {
+	if toDialect == "sqlite" {
+		return stringLiteral(toDialect, s)
+	}
+	return typeName + " " + stringLiteral(toDialect, s)
+}
+
+func textLiteral(toDialect, kind, s string) string {
+	switch kind {
+	case kindInteger, kindBigInt, kindSmallInt, kindReal, kindDouble, kindDecimal:
+		if _, err := strconv.ParseFloat(s, 64); err == nil && !strings.ContainsAny(s, " \t\n") &&
+			!strings.EqualFold(s, "nan") && !strings.Contains(strings.ToLower(s), "inf") {
+			return s
+		}
+	case kindBoolean:
+		if b, err := strconv.ParseBool(s); err == nil {
+			return SQLLiteral(toDialect, toDialect, "BOOLEAN", b)
+		}
+	case kindJSON:
+		if toDialect == "bigquery" {
+			return "JSON " + stringLiteral(toDialect, s)
+		}
+	case kindDate, kindTime, kindTimestamp, kindTimestampTZ:
+		if toDialect == "bigquery" {
+			return "CAST(" + stringLiteral(toDialect, s) + " AS " + ConvertColumnType("postgres", "bigquery", kind) + ")"
+		}
+	}
+	return stringLiteral(toDialect, s)
+}
+
+func stringLiteral(toDialect, s string) string {
+	if toDialect == "bigquery" {
+		r := strings.NewReplacer(`\`, `\\`, `'`, `\'`, "\n", `\n`, "\r", `\r`)
+		return "'" + r.Replace(s) + "'"
+	}
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+func blobLiteral(toDialect string, b []byte) string {
+	h := hex.EncodeToString(b)
+	switch toDialect {
+	case "postgres":
+		return `'\x` + h + `'::bytea`
+	case "bigquery":
+		return "FROM_HEX('" + h + "')"
+	}
+	return "X'" + h + "'"
+}
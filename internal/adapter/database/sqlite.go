@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/3-lines-studio/datafrost/internal/core/entity"
+	"github.com/3-lines-studio/datafrost/internal/usecase"
 
 	_ "github.com/mattn/go-sqlite3"
 )
@@ -21,6 +22,7 @@ func newSQLiteAdapterRegistration() entity.AdapterRegistration {
 			Type:        "sqlite",
 			Name:        "SQLite File",
 			Description: "Local SQLite database file",
+			LocalFile:   true,
 			UIConfig: entity.UIConfig{
 				Fields: []entity.FieldConfig{
 					{
@@ -33,6 +35,7 @@ func newSQLiteAdapterRegistration() entity.AdapterRegistration {
 				},
 			},
 		},
+		Dialect: sqliteDialect,
 		Factory: func() entity.DatabaseAdapter {
 			return &sqliteAdapter{}
 		},
@@ -70,7 +73,7 @@ func (a *sqliteAdapter) Ping() error {
 
 func (a *sqliteAdapter) ListTables() ([]entity.TableInfo, error) {
 	rows, err := a.conn.Query(
-		"SELECT name, type FROM sqlite_master WHERE type IN ('table', 'view') AND name NOT LIKE 'sqlite_%' ORDER BY name",
+		"SELECT name, type, 'main' FROM sqlite_master WHERE type IN ('table', 'view') AND name NOT LIKE 'sqlite_%' ORDER BY name",
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list tables: %w", err)
@@ -80,7 +83,7 @@ func (a *sqliteAdapter) ListTables() ([]entity.TableInfo, error) {
 	var tables []entity.TableInfo
 	for rows.Next() {
 		var t entity.TableInfo
-		if err := rows.Scan(&t.Name, &t.Type); err != nil {
+		if err := rows.Scan(&t.Name, &t.Type, &t.Schema); err != nil {
 			return nil, fmt.Errorf("failed to scan table: %w", err)
 		}
 		tables = append(tables, t)
@@ -167,6 +170,29 @@ func (a *sqliteAdapter) executeQueryWithArgs(query string, args []any) (*entity.
 	}, nil
 }
 
+func (a *sqliteAdapter) StreamQuery(ctx context.Context, query string, sink entity.RowSink) error {
+	if err := checkSQLiteReadOnly(query, true); err != nil {
+		return err
+	}
+
+	return streamQueryOnly(ctx, a.conn, query, sink)
+}
+
+func (a *sqliteAdapter) StreamTableData(ctx context.Context, tableName string, filters []entity.Filter, sink entity.RowSink) error {
+	whereClause, args := buildSQLiteWhereClause(filters)
+
+	query := fmt.Sprintf("SELECT * FROM \"%s\"", tableName)
+	if whereClause != "" {
+		query += " WHERE " + whereClause
+	}
+
+	return streamRows(ctx, a.conn, query, args, sink)
+}
+
+func (a *sqliteAdapter) BulkInsert(ctx context.Context, req entity.BulkInsertRequest, next entity.RowSource, progress func(rows int64)) error {
+	return bulkInsert(ctx, a.conn, "sqlite", req, next, progress)
+}
+
 func (a *sqliteAdapter) getFilteredTableCount(tableName, whereClause string, args []any) (int, error) {
 	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM \"%s\"", tableName)
 	if whereClause != "" {
@@ -206,7 +232,7 @@ func (a *sqliteAdapter) GetTableSchema(tableName string) (*entity.TableSchema, e
 		}
 
 		col.Nullable = notNull == 0
-		col.IsPrimaryKey = pk == 1
+		col.IsPrimaryKey = pk > 0
 		if dfltValue.Valid {
 			col.DefaultValue = dfltValue.String
 		}
@@ -260,9 +286,193 @@ func (a *sqliteAdapter) GetTableSchema(tableName string) (*entity.TableSchema, e
 	}
 	schema.Indexes = indexes
 
+	foreignKeys, err := sqliteForeignKeys(a.conn, tableName)
+	if err != nil {
+		return nil, err
+	}
+	schema.ForeignKeys = foreignKeys
+
 	return schema, nil
 }
 
+func (a *sqliteAdapter) GetColumnValues(tableName, column, search string, filters []entity.Filter, limit, offset int) ([]entity.ColumnValue, error) {
+	whereClause, args := buildSQLiteWhereClause(filters)
+	query, args := sqliteColumnValuesQuery(tableName, column, search, whereClause, args, limit, offset)
+	return queryColumnValues(a.conn, query, args)
+}
+
+func (a *sqliteAdapter) GetTableDDL(tableName string) (*entity.TableDDL, error) {
+	return sqliteTableDDL(a.conn, tableName)
+}
+
+func (a *sqliteAdapter) Explain(query string, analyze bool) (*entity.QueryPlan, error) {
+	return sqliteExplain(a.conn, query, analyze)
+}
+
+func sqliteExplain(conn *sql.DB, query string, analyze bool) (*entity.QueryPlan, error) {
+	if err := checkExplainable(query); err != nil {
+		return nil, err
+	}
+	if analyze {
+		return nil, fmt.Errorf("EXPLAIN ANALYZE is not supported by SQLite")
+	}
+
+	rows, err := conn.Query("EXPLAIN QUERY PLAN " + query)
+	if err != nil {
+		return nil, fmt.Errorf("explain failed: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	root := &entity.PlanNode{Operation: "QUERY PLAN", Children: []*entity.PlanNode{}}
+	nodes := map[int]*entity.PlanNode{0: root}
+	for rows.Next() {
+		var id, parent, notUsed int
+		var detail string
+		if err := rows.Scan(&id, &parent, &notUsed, &detail); err != nil {
+			return nil, fmt.Errorf("failed to scan plan row: %w", err)
+		}
+
+		node := &entity.PlanNode{Detail: detail, Children: []*entity.PlanNode{}}
+		fields := strings.Fields(detail)
+		if len(fields) > 0 {
+			node.Operation = fields[0]
+			if (node.Operation == "SCAN" || node.Operation == "SEARCH") && len(fields) > 1 {
+				node.Relation = fields[1]
+			}
+		}
+
+		parentNode, ok := nodes[parent]
+		if !ok {
+			parentNode = root
+		}
+		parentNode.Children = append(parentNode.Children, node)
+		nodes[id] = node
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("plan iteration error: %w", err)
+	}
+
+	return &entity.QueryPlan{Dialect: "sqlite", Root: root}, nil
+}
+
+func sqliteTableDDL(conn *sql.DB, tableName string) (*entity.TableDDL, error) {
+	rows, err := conn.Query(`
+		SELECT type, name, sql FROM sqlite_master
+		WHERE tbl_name = ? AND sql IS NOT NULL
+		ORDER BY CASE type WHEN 'table' THEN 0 WHEN 'view' THEN 0 WHEN 'index' THEN 1 ELSE 2 END, name
+	`, tableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ddl: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	ddl := &entity.TableDDL{TableName: tableName}
+	var statements []string
+	for rows.Next() {
+		var objType, name, statement string
+		if err := rows.Scan(&objType, &name, &statement); err != nil {
+			return nil, fmt.Errorf("failed to scan ddl: %w", err)
+		}
+		if name == tableName {
+			ddl.Type = objType
+		}
+		statements = append(statements, strings.TrimSpace(statement)+";")
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("ddl iteration error: %w", err)
+	}
+	if ddl.Type == "" {
+		return nil, usecase.ErrTableNotFound
+	}
+
+	ddl.DDL = strings.Join(statements, "\n\n")
+	return ddl, nil
+}
+
+func sqliteForeignKeys(conn *sql.DB, tableName string) ([]entity.ForeignKeyInfo, error) {
+	escapedTableName := strings.ReplaceAll(tableName, "'", "''")
+
+	rows, err := conn.Query(fmt.Sprintf("PRAGMA foreign_key_list('%s')", escapedTableName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get foreign keys: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	var foreignKeys []entity.ForeignKeyInfo
+	byID := make(map[int]int)
+	for rows.Next() {
+		var id, seq int
+		var refTable, from string
+		var to sql.NullString
+		var onUpdate, onDelete, match string
+
+		if err := rows.Scan(&id, &seq, &refTable, &from, &to, &onUpdate, &onDelete, &match); err != nil {
+			return nil, fmt.Errorf("failed to scan foreign key: %w", err)
+		}
+
+		pos, ok := byID[id]
+		if !ok {
+			foreignKeys = append(foreignKeys, entity.ForeignKeyInfo{
+				Name:            fmt.Sprintf("fk_%s_%d", tableName, id),
+				ReferencedTable: refTable,
+			})
+			pos = len(foreignKeys) - 1
+			byID[id] = pos
+		}
+
+		fk := &foreignKeys[pos]
+		fk.Columns = append(fk.Columns, from)
+		if to.Valid && to.String != "" {
+			fk.ReferencedColumns = append(fk.ReferencedColumns, to.String)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("foreign key iteration error: %w", err)
+	}
+
+	// A foreign key declared without target columns references the primary key.
+	for i := range foreignKeys {
+		if len(foreignKeys[i].ReferencedColumns) == 0 {
+			pkColumns, err := sqlitePrimaryKeyColumns(conn, foreignKeys[i].ReferencedTable)
+			if err != nil {
+				return nil, err
+			}
+			foreignKeys[i].ReferencedColumns = pkColumns
+		}
+	}
+
+	return foreignKeys, nil
+}
+
+func sqlitePrimaryKeyColumns(conn *sql.DB, tableName string) ([]string, error) {
+	escapedTableName := strings.ReplaceAll(tableName, "'", "''")
+
+	rows, err := conn.Query(fmt.Sprintf("PRAGMA table_info('%s')", escapedTableName))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get columns: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	pkByPosition := make(map[int]string)
+	for rows.Next() {
+		var cid, notNull, pk int
+		var name, colType string
+		var dfltValue sql.NullString
+		if err := rows.Scan(&cid, &name, &colType, &notNull, &dfltValue, &pk); err != nil {
+			return nil, fmt.Errorf("failed to scan column: %w", err)
+		}
+		if pk > 0 {
+			pkByPosition[pk] = name
+		}
+	}
+
+	columns := make([]string, 0, len(pkByPosition))
+	for i := 1; i <= len(pkByPosition); i++ {
+		columns = append(columns, pkByPosition[i])
+	}
+	return columns, rows.Err()
+}
+
 func buildSQLiteWhereClause(filters []entity.Filter) (string, []any) {
 	if len(filters) == 0 {
 		return "", nil
@@ -0,0 +1,54 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/3-lines-studio/datafrost/internal/core/entity"
+)
+
+func streamRows(ctx context.Context, conn rowQuerier, query string, args []any, sink entity.RowSink) error {
+	rows, err := conn.QueryContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("query failed: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return fmt.Errorf("failed to get columns: %w", err)
+	}
+
+	columns := make([]entity.ResultColumn, len(columnTypes))
+	for i, ct := range columnTypes {
+		columns[i] = entity.ResultColumn{Name: ct.Name(), DatabaseType: ct.DatabaseTypeName()}
+		if precision, scale, ok := ct.DecimalSize(); ok {
+			columns[i].Precision = precision
+			columns[i].Scale = scale
+		}
+	}
+	if err := sink.WriteHeader(columns); err != nil {
+		return err
+	}
+
+	for rows.Next() {
+		values := make([]any, len(columns))
+		valuePtrs := make([]any, len(columns))
+		for i := range values {
+			valuePtrs[i] = &values[i]
+		}
+
+		if err := rows.Scan(valuePtrs...); err != nil {
+			return fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		if err := sink.WriteRow(values); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("row iteration error: %w", err)
+	}
+	return ctx.Err()
+}
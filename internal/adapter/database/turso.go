@@ -36,11 +36,13 @@ func newTursoAdapterRegistration() entity.AdapterRegistration {
 						Type:        "password",
 						Required:    true,
 						Placeholder: "your-auth-token",
+						Secret:      true,
 					},
 				},
 				SupportsFile: false,
 			},
 		},
+		Dialect: sqliteDialect,
 		Factory: func() entity.DatabaseAdapter {
 			return &tursoAdapter{}
 		},
@@ -90,7 +92,7 @@ func (a *tursoAdapter) Ping() error {
 
 func (a *tursoAdapter) ListTables() ([]entity.TableInfo, error) {
 	rows, err := a.conn.Query(
-		"SELECT name, type FROM sqlite_master WHERE type IN ('table', 'view') AND name NOT LIKE 'sqlite_%' ORDER BY name",
+		"SELECT name, type, 'main' FROM sqlite_master WHERE type IN ('table', 'view') AND name NOT LIKE 'sqlite_%' ORDER BY name",
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list tables: %w", err)
@@ -100,7 +102,7 @@ func (a *tursoAdapter) ListTables() ([]entity.TableInfo, error) {
 	var tables []entity.TableInfo
 	for rows.Next() {
 		var t entity.TableInfo
-		if err := rows.Scan(&t.Name, &t.Type); err != nil {
+		if err := rows.Scan(&t.Name, &t.Type, &t.Schema); err != nil {
 			return nil, fmt.Errorf("failed to scan table: %w", err)
 		}
 		tables = append(tables, t)
@@ -187,6 +189,29 @@ func (a *tursoAdapter) executeQueryWithArgs(query string, args []any) (*entity.Q
 	}, nil
 }
 
+func (a *tursoAdapter) StreamQuery(ctx context.Context, query string, sink entity.RowSink) error {
+	if err := checkSQLiteReadOnly(query, true); err != nil {
+		return err
+	}
+
+	return streamQueryOnly(ctx, a.conn, query, sink)
+}
+
+func (a *tursoAdapter) StreamTableData(ctx context.Context, tableName string, filters []entity.Filter, sink entity.RowSink) error {
+	whereClause, args := buildTursoWhereClause(filters)
+
+	query := fmt.Sprintf("SELECT * FROM \"%s\"", tableName)
+	if whereClause != "" {
+		query += " WHERE " + whereClause
+	}
+
+	return streamRows(ctx, a.conn, query, args, sink)
+}
+
+func (a *tursoAdapter) BulkInsert(ctx context.Context, req entity.BulkInsertRequest, next entity.RowSource, progress func(rows int64)) error {
+	return bulkInsert(ctx, a.conn, "sqlite", req, next, progress)
+}
+
 func (a *tursoAdapter) getFilteredTableCount(tableName, whereClause string, args []any) (int, error) {
 	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM \"%s\"", tableName)
 	if whereClause != "" {
@@ -226,7 +251,7 @@ func (a *tursoAdapter) GetTableSchema(tableName string) (*entity.TableSchema, er
 		}
 
 		col.Nullable = notNull == 0
-		col.IsPrimaryKey = pk == 1
+		col.IsPrimaryKey = pk > 0
 		if dfltValue.Valid {
 			col.DefaultValue = dfltValue.String
 		}
@@ -280,9 +305,29 @@ func (a *tursoAdapter) GetTableSchema(tableName string) (*entity.TableSchema, er
 	}
 	schema.Indexes = indexes
 
+	foreignKeys, err := sqliteForeignKeys(a.conn, tableName)
+	if err != nil {
+		return nil, err
+	}
+	schema.ForeignKeys = foreignKeys
+
 	return schema, nil
 }
 
+func (a *tursoAdapter) GetColumnValues(tableName, column, search string, filters []entity.Filter, limit, offset int) ([]entity.ColumnValue, error) {
+	whereClause, args := buildTursoWhereClause(filters)
+	query, args := sqliteColumnValuesQuery(tableName, column, search, whereClause, args, limit, offset)
+	return queryColumnValues(a.conn, query, args)
+}
+
+func (a *tursoAdapter) GetTableDDL(tableName string) (*entity.TableDDL, error) {
+	return sqliteTableDDL(a.conn, tableName)
+}
+
+func (a *tursoAdapter) Explain(query string, analyze bool) (*entity.QueryPlan, error) {
+	return sqliteExplain(a.conn, query, analyze)
+}
+
 func buildTursoWhereClause(filters []entity.Filter) (string, []any) {
 	if len(filters) == 0 {
 		return "", nil
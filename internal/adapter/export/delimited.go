@@ -0,0 +1,123 @@
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/3-lines-studio/datafrost/internal/core/entity"
+)
+
+type csvWriter struct {
+	w         *bufio.Writer
+	delimiter rune
+	options   entity.ExportOptions
+}
+
+func newCSVWriter(w *bufio.Writer, target entity.ExportTarget) (rowWriter, error) {
+	options := target.Options
+	delimiter := ','
+	if options.Delimiter != "" {
+		if options.Delimiter == `\t` {
+			options.Delimiter = "\t"
+		}
+		r, size := utf8.DecodeRuneInString(options.Delimiter)
+		if size != len(options.Delimiter) || r == '"' || r == '\r' || r == '\n' {
+			return nil, fmt.Errorf("invalid CSV delimiter: %q", options.Delimiter)
+		}
+		delimiter = r
+	}
+	return &csvWriter{w: w, delimiter: delimiter, options: options}, nil
+}
+
+func (c *csvWriter) WriteHeader(columns []entity.ResultColumn) error {
+	if c.options.OmitHeader {
+		return nil
+	}
+	fields := make([]string, len(columns))
+	for i, col := range columns {
+		fields[i] = c.quote(col.Name)
+	}
+	return c.writeLine(fields)
+}
+
+func (c *csvWriter) WriteRow(values []any) error {
+	fields := make([]string, len(values))
+	for i, val := range values {
+		if val == nil {
+			fields[i] = c.options.NullValue
+			continue
+		}
+		fields[i] = c.quote(formatText(val))
+	}
+	return c.writeLine(fields)
+}
+
+func (c *csvWriter) Finish() error {
+	return nil
+}
+
+func (c *csvWriter) quote(field string) string {
+	needsQuotes := c.options.QuoteAll ||
+		strings.ContainsRune(field, c.delimiter) ||
+		strings.ContainsAny(field, "\"\r\n") ||
+		strings.HasPrefix(field, " ") || strings.HasSuffix(field, " ")
+	if !needsQuotes {
+		return field
+	}
+	return `"` + strings.ReplaceAll(field, `"`, `""`) + `"`
+}
+
+func (c *csvWriter) writeLine(fields []string) error {
+	if _, err := c.w.WriteString(strings.Join(fields, string(c.delimiter))); err != nil {
+		return err
+	}
+	_, err := c.w.WriteString("\r\n")
+	return err
+}
+
+var tsvEscaper = strings.NewReplacer("\\", `\\`, "\t", `\t`, "\n", `\n`, "\r", `\r`)
+
+type tsvWriter struct {
+	w       *bufio.Writer
+	options entity.ExportOptions
+}
+
+func newTSVWriter(w *bufio.Writer, target entity.ExportTarget) (rowWriter, error) {
+	return &tsvWriter{w: w, options: target.Options}, nil
+}
+
+func (t *tsvWriter) WriteHeader(columns []entity.ResultColumn) error {
+	if t.options.OmitHeader {
+		return nil
+	}
+	fields := make([]string, len(columns))
+	for i, col := range columns {
+		fields[i] = tsvEscaper.Replace(col.Name)
+	}
+	return t.writeLine(fields)
+}
+
+func (t *tsvWriter) WriteRow(values []any) error {
+	fields := make([]string, len(values))
+	for i, val := range values {
+		if val == nil {
+			fields[i] = t.options.NullValue
+			continue
+		}
+		fields[i] = tsvEscaper.Replace(formatText(val))
+	}
+	return t.writeLine(fields)
+}
+
+func (t *tsvWriter) Finish() error {
+	return nil
+}
+
+func (t *tsvWriter) writeLine(fields []string) error {
+	if _, err := t.w.WriteString(strings.Join(fields, "\t")); err != nil {
+		return err
+	}
+	return t.w.WriteByte('\n')
+}
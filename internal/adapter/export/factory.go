@@ -0,0 +1,156 @@
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/3-lines-studio/datafrost/internal/core/entity"
+	"github.com/3-lines-studio/datafrost/internal/usecase/port"
+)
+
+type writerConstructor func(w *bufio.Writer, target entity.ExportTarget) (rowWriter, error)
+
+type rowWriter interface {
+	entity.RowSink
+	Finish() error
+}
+
+type format struct {
+	extension string
+	create    writerConstructor
+}
+
+type Factory struct {
+	formats map[string]format
+	order   []string
+}
+
+func NewFactory() *Factory {
+	f := &Factory{formats: make(map[string]format)}
+	f.register("csv", "csv", newCSVWriter)
+	f.register("tsv", "tsv", newTSVWriter)
+	f.register("json", "json", newJSONWriter)
+	f.register("ndjson", "ndjson", newNDJSONWriter)
+	f.register("parquet", "parquet", newParquetWriter)
+	f.register("xlsx", "xlsx", newXLSXWriter)
+	f.register("sql", "sql", newSQLWriter)
+	return f
+}
+
+func (f *Factory) register(name, extension string, create writerConstructor) {
+	f.formats[name] = format{extension: extension, create: create}
+	f.order = append(f.order, name)
+}
+
+func (f *Factory) Formats() []string {
+	return append([]string(nil), f.order...)
+}
+
+func (f *Factory) Extension(name string) (string, error) {
+	fm, ok := f.formats[name]
+	if !ok {
+		return "", fmt.Errorf("unsupported export format: %s", name)
+	}
+	return fm.extension, nil
+}
+
+func (f *Factory) Create(target entity.ExportTarget) (port.ExportFileWriter, error) {
+	fm, ok := f.formats[target.Format]
+	if !ok {
+		return nil, fmt.Errorf("unsupported export format: %s", target.Format)
+	}
+
+	path := target.Path
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create export directory: %w", err)
+	}
+
+	// Rows go to a temporary file next to the target that only replaces it
+	// once the export succeeds, so a failed overwrite keeps the old file.
+	file, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create export file: %w", err)
+	}
+	mode := os.FileMode(0644)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+	if err := file.Chmod(mode); err != nil {
+		_ = file.Close()
+		_ = os.Remove(file.Name())
+		return nil, fmt.Errorf("failed to create export file: %w", err)
+	}
+
+	buf := bufio.NewWriterSize(file, 256*1024)
+	rw, err := fm.create(buf, target)
+	if err != nil {
+		_ = file.Close()
+		_ = os.Remove(file.Name())
+		return nil, err
+	}
+
+	return &fileWriter{file: file, buf: buf, rowWriter: rw, path: path}, nil
+}
+
+func (f *Factory) CreateStream(w io.Writer, target entity.ExportTarget) (port.ExportWriter, error) {
+	fm, ok := f.formats[target.Format]
+	if !ok {
+		return nil, fmt.Errorf("unsupported export format: %s", target.Format)
+	}
+
+	buf := bufio.NewWriterSize(w, 64*1024)
+	rw, err := fm.create(buf, target)
+	if err != nil {
+		return nil, err
+	}
+	return &streamWriter{buf: buf, rowWriter: rw}, nil
+}
+
+type streamWriter struct {
+	rowWriter
+	buf *bufio.Writer
+}
+
+func (w *streamWriter) Close() error {
+	finishErr := w.rowWriter.Finish()
+	flushErr := w.buf.Flush()
+	if finishErr != nil {
+		return finishErr
+	}
+	return flushErr
+}
+
+type fileWriter struct {
+	rowWriter
+	file *os.File
+	buf  *bufio.Writer
+	path string
+}
+
+func (w *fileWriter) Close() error {
+	finishErr := w.rowWriter.Finish()
+	flushErr := w.buf.Flush()
+	closeErr := w.file.Close()
+	err := finishErr
+	if err == nil {
+		err = flushErr
+	}
+	if err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(w.file.Name(), w.path)
+	}
+	if err != nil {
+		_ = os.Remove(w.file.Name())
+	}
+	return err
+}
+
+func (w *fileWriter) Discard() error {
+	_ = w.file.Close()
+	return os.Remove(w.file.Name())
+}
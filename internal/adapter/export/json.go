@@ -0,0 +1,97 @@
+package export
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+
+	"github.com/3-lines-studio/datafrost/internal/core/entity"
+)
+
+type jsonWriter struct {
+	w        *bufio.Writer
+	keys     [][]byte
+	lines    bool
+	rowCount int64
+}
+
+func newJSONWriter(w *bufio.Writer, _ entity.ExportTarget) (rowWriter, error) {
+	return &jsonWriter{w: w}, nil
+}
+
+func newNDJSONWriter(w *bufio.Writer, _ entity.ExportTarget) (rowWriter, error) {
+	return &jsonWriter{w: w, lines: true}, nil
+}
+
+func (j *jsonWriter) WriteHeader(columns []entity.ResultColumn) error {
+	j.keys = make([][]byte, len(columns))
+	for i, col := range columns {
+		key, err := json.Marshal(col.Name)
+		if err != nil {
+			return err
+		}
+		j.keys[i] = key
+	}
+	if !j.lines {
+		_, err := j.w.WriteString("[")
+		return err
+	}
+	return nil
+}
+
+func (j *jsonWriter) WriteRow(values []any) error {
+	if len(values) != len(j.keys) {
+		return fmt.Errorf("row has %d values, expected %d", len(values), len(j.keys))
+	}
+
+	if !j.lines {
+		sep := ",\n  "
+		if j.rowCount == 0 {
+			sep = "\n  "
+		}
+		if _, err := j.w.WriteString(sep); err != nil {
+			return err
+		}
+	}
+
+	_ = j.w.WriteByte('{')
+	for i, val := range values {
+		if i > 0 {
+			_ = j.w.WriteByte(',')
+		}
+		data, err := jsonValue(val)
+		if err != nil {
+			return err
+		}
+		_, _ = j.w.Write(j.keys[i])
+		_ = j.w.WriteByte(':')
+		if _, err := j.w.Write(data); err != nil {
+			return err
+		}
+	}
+	if err := j.w.WriteByte('}'); err != nil {
+		return err
+	}
+
+	j.rowCount++
+	if j.lines {
+		return j.w.WriteByte('\n')
+	}
+	return nil
+}
+
+func (j *jsonWriter) Finish() error {
+	if j.lines {
+		return nil
+	}
+	if j.keys == nil {
+		_, err := j.w.WriteString("[]\n")
+		return err
+	}
+	if j.rowCount == 0 {
+		_, err := j.w.WriteString("]\n")
+		return err
+	}
+	_, err := j.w.WriteString("\n]\n")
+	return err
+}
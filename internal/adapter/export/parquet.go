@@ -0,0 +1,312 @@
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/3-lines-studio/datafrost/internal/core/entity"
+
+	"github.com/apache/arrow/go/v15/arrow"
+	"github.com/apache/arrow/go/v15/arrow/array"
+	"github.com/apache/arrow/go/v15/arrow/decimal128"
+	"github.com/apache/arrow/go/v15/arrow/memory"
+	"github.com/apache/arrow/go/v15/parquet"
+	"github.com/apache/arrow/go/v15/parquet/compress"
+	"github.com/apache/arrow/go/v15/parquet/pqarrow"
+)
+
+const parquetBatchSize = 64 * 1024
+
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999-07",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02",
+}
+
+type parquetWriter struct {
+	w        *bufio.Writer
+	schema   *arrow.Schema
+	builder  *array.RecordBuilder
+	appends  []func(val any) error
+	writer   *pqarrow.FileWriter
+	buffered int
+}
+
+func newParquetWriter(w *bufio.Writer, _ entity.ExportTarget) (rowWriter, error) {
+	return &parquetWriter{w: w}, nil
+}
+
+func (p *parquetWriter) WriteHeader(columns []entity.ResultColumn) error {
+	fields := make([]arrow.Field, len(columns))
+	seen := map[string]int{}
+	for i, col := range columns {
+		name := col.Name
+		if n := seen[name]; n > 0 {
+			name = fmt.Sprintf("%s_%d", name, n+1)
+		}
+		seen[col.Name]++
+		fields[i] = arrow.Field{Name: name, Type: parquetType(col), Nullable: true}
+	}
+	p.schema = arrow.NewSchema(fields, nil)
+
+	props := parquet.NewWriterProperties(parquet.WithCompression(compress.Codecs.Snappy))
+	writer, err := pqarrow.NewFileWriter(p.schema, p.w, props, pqarrow.DefaultWriterProps())
+	if err != nil {
+		return fmt.Errorf("failed to create parquet writer: %w", err)
+	}
+	p.writer = writer
+
+	p.builder = array.NewRecordBuilder(memory.DefaultAllocator, p.schema)
+	p.appends = make([]func(val any) error, len(fields))
+	for i, field := range fields {
+		p.appends[i] = parquetAppender(p.builder.Field(i), field)
+	}
+	return nil
+}
+
+func (p *parquetWriter) WriteRow(values []any) error {
+	for i, val := range values {
+		if val == nil {
+			p.builder.Field(i).AppendNull()
+			continue
+		}
+		if err := p.appends[i](val); err != nil {
+			return fmt.Errorf("column %q: %w", p.schema.Field(i).Name, err)
+		}
+	}
+
+	p.buffered++
+	if p.buffered >= parquetBatchSize {
+		return p.flush()
+	}
+	return nil
+}
+
+func (p *parquetWriter) Finish() error {
+	if p.writer == nil {
+		return nil
+	}
+	defer p.builder.Release()
+	if err := p.flush(); err != nil {
+		_ = p.writer.Close()
+		return err
+	}
+	return p.writer.Close()
+}
+
+func (p *parquetWriter) flush() error {
+	if p.buffered == 0 {
+		return nil
+	}
+	record := p.builder.NewRecord()
+	defer record.Release()
+	p.buffered = 0
+	return p.writer.WriteBuffered(record)
+}
+
+func parquetType(col entity.ResultColumn) arrow.DataType {
+	base, precision, scale := splitTypeName(col.DatabaseType)
+	if col.Precision > 0 {
+		precision, scale = col.Precision, col.Scale
+	}
+
+	switch base {
+	case "INT", "INTEGER", "BIGINT", "SMALLINT", "TINYINT", "MEDIUMINT",
+		"INT2", "INT4", "INT8", "INT64", "SERIAL", "BIGSERIAL", "SMALLSERIAL":
+		return arrow.PrimitiveTypes.Int64
+	case "REAL", "FLOAT", "FLOAT4", "FLOAT8", "FLOAT64", "DOUBLE", "DOUBLE PRECISION":
+		return arrow.PrimitiveTypes.Float64
+	case "NUMERIC", "DECIMAL", "BIGNUMERIC":
+		if precision > 0 && precision <= 38 && scale >= 0 && scale <= precision {
+			return &arrow.Decimal128Type{Precision: int32(precision), Scale: int32(scale)}
+		}
+		return arrow.BinaryTypes.String
+	case "BOOL", "BOOLEAN":
+		return arrow.FixedWidthTypes.Boolean
+	case "DATE":
+		return arrow.FixedWidthTypes.Date32
+	case "TIMESTAMP", "TIMESTAMPTZ", "DATETIME", "TIMESTAMP WITH TIME ZONE", "TIMESTAMP WITHOUT TIME ZONE":
+		return arrow.FixedWidthTypes.Timestamp_us
+	case "BLOB", "BYTEA", "BYTES", "BINARY", "VARBINARY":
+		return arrow.BinaryTypes.Binary
+	default:
+		return arrow.BinaryTypes.String
+	}
+}
+
+func splitTypeName(typeName string) (string, int64, int64) {
+	base := strings.ToUpper(strings.TrimSpace(typeName))
+	open := strings.Index(base, "(")
+	if open < 0 {
+		return base, 0, 0
+	}
+
+	params := strings.TrimSuffix(base[open+1:], ")")
+	base = strings.TrimSpace(base[:open])
+
+	parts := strings.Split(params, ",")
+	precision, _ := strconv.ParseInt(strings.TrimSpace(parts[0]), 10, 64)
+	var scale int64
+	if len(parts) > 1 {
+		scale, _ = strconv.ParseInt(strings.TrimSpace(parts[1]), 10, 64)
+	}
+	return base, precision, scale
+}
+
+func parquetAppender(b array.Builder, field arrow.Field) func(val any) error {
+	switch dt := field.Type.(type) {
+	case *arrow.Int64Type:
+		ib := b.(*array.Int64Builder)
+		return func(val any) error {
+			n, err := toInt64(val)
+			if err != nil {
+				return err
+			}
+			ib.Append(n)
+			return nil
+		}
+	case *arrow.Float64Type:
+		fb := b.(*array.Float64Builder)
+		return func(val any) error {
+			f, err := toFloat64(val)
+			if err != nil {
+				return err
+			}
+			fb.Append(f)
+			return nil
+		}
+	case *arrow.Decimal128Type:
+		db := b.(*array.Decimal128Builder)
+		return func(val any) error {
+			n, err := decimal128.FromString(formatText(val), dt.Precision, dt.Scale)
+			if err != nil {
+				return err
+			}
+			db.Append(n)
+			return nil
+		}
+	case *arrow.BooleanType:
+		bb := b.(*array.BooleanBuilder)
+		return func(val any) error {
+			v, err := toBool(val)
+			if err != nil {
+				return err
+			}
+			bb.Append(v)
+			return nil
+		}
+	case *arrow.Date32Type:
+		db := b.(*array.Date32Builder)
+		return func(val any) error {
+			t, err := toTime(val)
+			if err != nil {
+				return err
+			}
+			db.Append(arrow.Date32FromTime(t))
+			return nil
+		}
+	case *arrow.TimestampType:
+		tb := b.(*array.TimestampBuilder)
+		return func(val any) error {
+			t, err := toTime(val)
+			if err != nil {
+				return err
+			}
+			tb.Append(arrow.Timestamp(t.UTC().UnixMicro()))
+			return nil
+		}
+	case *arrow.BinaryType:
+		bb := b.(*array.BinaryBuilder)
+		return func(val any) error {
+			switch v := val.(type) {
+			case []byte:
+				bb.Append(v)
+			default:
+				bb.AppendString(formatText(v))
+			}
+			return nil
+		}
+	default:
+		sb := b.(*array.StringBuilder)
+		return func(val any) error {
+			sb.Append(formatText(val))
+			return nil
+		}
+	}
+}
+
+func toInt64(val any) (int64, error) {
+	switch v := val.(type) {
+	case int64:
+		return v, nil
+	case int:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case int16:
+		return int64(v), nil
+	case float64:
+		if v == float64(int64(v)) {
+			return int64(v), nil
+		}
+	case bool:
+		if v {
+			return 1, nil
+		}
+		return 0, nil
+	case string, []byte:
+		return strconv.ParseInt(strings.TrimSpace(formatText(v)), 10, 64)
+	}
+	return 0, fmt.Errorf("cannot convert %v (%T) to integer", val, val)
+}
+
+func toFloat64(val any) (float64, error) {
+	switch v := val.(type) {
+	case float64:
+		return v, nil
+	case float32:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case int:
+		return float64(v), nil
+	case int32:
+		return float64(v), nil
+	case string, []byte:
+		return strconv.ParseFloat(strings.TrimSpace(formatText(v)), 64)
+	}
+	return 0, fmt.Errorf("cannot convert %v (%T) to float", val, val)
+}
+
+func toBool(val any) (bool, error) {
+	switch v := val.(type) {
+	case bool:
+		return v, nil
+	case int64:
+		return v != 0, nil
+	case string, []byte:
+		return strconv.ParseBool(strings.TrimSpace(formatText(v)))
+	}
+	return false, fmt.Errorf("cannot convert %v (%T) to boolean", val, val)
+}
+
+func toTime(val any) (time.Time, error) {
+	switch v := val.(type) {
+	case time.Time:
+		return v, nil
+	case string, []byte:
+		s := strings.TrimSpace(formatText(v))
+		for _, layout := range timeLayouts {
+			if t, err := time.Parse(layout, s); err == nil {
+				return t, nil
+			}
+		}
+	}
+	return time.Time{}, fmt.Errorf("cannot convert %v (%T) to time", val, val)
+}
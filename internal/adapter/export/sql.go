@@ -0,0 +1,143 @@
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	"github.com/3-lines-studio/datafrost/internal/adapter/database"
+	"github.com/3-lines-studio/datafrost/internal/core/entity"
+)
+
+const defaultSQLBatchSize = 500
+
+type sqlWriter struct {
+	w           *bufio.Writer
+	target      entity.ExportTarget
+	fromDialect string
+	toDialect   string
+	tableName   string
+	batchSize   int
+	columnTypes []string
+	insertHead  string
+	batched     int
+}
+
+func newSQLWriter(w *bufio.Writer, target entity.ExportTarget) (rowWriter, error) {
+	fromDialect, err := database.SQLDialectName(target.SourceType)
+	if err != nil {
+		return nil, err
+	}
+
+	toDialect := fromDialect
+	if target.Options.Dialect != "" {
+		toDialect, err = database.SQLDialectName(target.Options.Dialect)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	tableName := target.Options.TableName
+	if tableName == "" {
+		tableName = target.Table
+	}
+	if tableName == "" {
+		tableName = "export"
+	}
+
+	batchSize := target.Options.BatchSize
+	if batchSize <= 0 {
+		batchSize = defaultSQLBatchSize
+	}
+
+	return &sqlWriter{
+		w:           w,
+		target:      target,
+		fromDialect: fromDialect,
+		toDialect:   toDialect,
+		tableName:   tableName,
+		batchSize:   batchSize,
+	}, nil
+}
+
+func (s *sqlWriter) WriteHeader(columns []entity.ResultColumn) error {
+	schemaColumns := map[string]entity.ColumnInfo{}
+	if s.target.Schema != nil {
+		for _, col := range s.target.Schema.Columns {
+			schemaColumns[col.Name] = col
+		}
+	}
+
+	ddlColumns := make([]entity.ColumnInfo, len(columns))
+	names := make([]string, len(columns))
+	s.columnTypes = make([]string, len(columns))
+	for i, col := range columns {
+		info, ok := schemaColumns[col.Name]
+		if !ok {
+			info = entity.ColumnInfo{Name: col.Name, Type: col.DatabaseType, Nullable: true}
+		}
+		if info.Type == "" {
+			info.Type = col.DatabaseType
+		}
+		ddlColumns[i] = info
+		s.columnTypes[i] = info.Type
+		names[i] = database.QuoteIdentifier(s.toDialect, col.Name)
+	}
+
+	quotedTable := database.QuoteIdentifier(s.toDialect, s.tableName)
+	s.insertHead = fmt.Sprintf("INSERT INTO %s (%s) VALUES\n  ", quotedTable, strings.Join(names, ", "))
+
+	if _, err := fmt.Fprintf(s.w, "-- Exported from %s to %s dialect\n\n", s.fromDialect, s.toDialect); err != nil {
+		return err
+	}
+	if s.target.Options.OmitDDL {
+		return nil
+	}
+	if s.target.Options.DropTable {
+		if _, err := fmt.Fprintf(s.w, "DROP TABLE IF EXISTS %s;\n", quotedTable); err != nil {
+			return err
+		}
+	}
+	if _, err := s.w.WriteString(database.CreateTableStatement(s.fromDialect, s.toDialect, s.tableName, ddlColumns)); err != nil {
+		return err
+	}
+	_, err := s.w.WriteString("\n")
+	return err
+}
+
+func (s *sqlWriter) WriteRow(values []any) error {
+	if s.batched == 0 {
+		if _, err := s.w.WriteString(s.insertHead); err != nil {
+			return err
+		}
+	} else if _, err := s.w.WriteString(",\n  "); err != nil {
+		return err
+	}
+
+	literals := make([]string, len(values))
+	for i, val := range values {
+		literals[i] = database.SQLLiteral(s.fromDialect, s.toDialect, s.columnTypes[i], val)
+	}
+	if _, err := s.w.WriteString("(" + strings.Join(literals, ", ") + ")"); err != nil {
+		return err
+	}
+
+	s.batched++
+	if s.batched >= s.batchSize {
+		return s.endBatch()
+	}
+	return nil
+}
+
+func (s *sqlWriter) Finish() error {
+	return s.endBatch()
+}
+
+func (s *sqlWriter) endBatch() error {
+	if s.batched == 0 {
+		return nil
+	}
+	s.batched = 0
+	_, err := s.w.WriteString(";\n")
+	return err
+}
@@ -0,0 +1,65 @@
+package export
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+	"unicode/utf8"
+)
+
+func formatText(val any) string {
+	switch v := val.(type) {
+	case string:
+		return v
+	case []byte:
+		if utf8.Valid(v) {
+			return string(v)
+		}
+		return base64.StdEncoding.EncodeToString(v)
+	case time.Time:
+		return v.Format(time.RFC3339Nano)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+func jsonValue(val any) ([]byte, error) {
+	switch v := val.(type) {
+	case nil:
+		return []byte("null"), nil
+	case []byte:
+		return json.Marshal(formatText(v))
+	case time.Time:
+		return json.Marshal(v.Format(time.RFC3339Nano))
+	// JSON has no NaN or Infinity, so they are written as strings.
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return json.Marshal(formatText(v))
+		}
+		return json.Marshal(v)
+	case float32:
+		if math.IsNaN(float64(v)) || math.IsInf(float64(v), 0) {
+			return json.Marshal(formatText(v))
+		}
+		return json.Marshal(v)
+	case string, bool, int, int32, int64:
+		return json.Marshal(v)
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return json.Marshal(formatText(v))
+		}
+		return data, nil
+	}
+}
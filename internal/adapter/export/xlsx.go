@@ -0,0 +1,155 @@
+package export
+
+import (
+	"bufio"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/3-lines-studio/datafrost/internal/core/entity"
+
+	"github.com/xuri/excelize/v2"
+)
+
+type xlsxWriter struct {
+	w          *bufio.Writer
+	options    entity.ExportOptions
+	file       *excelize.File
+	stream     *excelize.StreamWriter
+	header     []any
+	types      []string
+	sheetCount int
+	row        int
+	dateStyle  int
+	timeStyle  int
+}
+
+func newXLSXWriter(w *bufio.Writer, target entity.ExportTarget) (rowWriter, error) {
+	options := target.Options
+	file := excelize.NewFile()
+
+	dateStyle, err := file.NewStyle(&excelize.Style{NumFmt: 14})
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	timeStyle, err := file.NewStyle(&excelize.Style{NumFmt: 22})
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+
+	return &xlsxWriter{
+		w:         w,
+		options:   options,
+		file:      file,
+		dateStyle: dateStyle,
+		timeStyle: timeStyle,
+	}, nil
+}
+
+func (x *xlsxWriter) WriteHeader(columns []entity.ResultColumn) error {
+	x.header = make([]any, len(columns))
+	x.types = make([]string, len(columns))
+	for i, col := range columns {
+		x.header[i] = col.Name
+		x.types[i], _, _ = splitTypeName(col.DatabaseType)
+	}
+	return x.nextSheet()
+}
+
+func (x *xlsxWriter) WriteRow(values []any) error {
+	if x.row >= excelize.TotalRows {
+		if err := x.nextSheet(); err != nil {
+			return err
+		}
+	}
+
+	cells := make([]any, len(values))
+	for i, val := range values {
+		cells[i] = x.cell(i, val)
+	}
+
+	x.row++
+	cellName, err := excelize.CoordinatesToCellName(1, x.row)
+	if err != nil {
+		return err
+	}
+	return x.stream.SetRow(cellName, cells)
+}
+
+func (x *xlsxWriter) Finish() error {
+	defer func() { _ = x.file.Close() }()
+
+	if x.stream == nil {
+		if err := x.nextSheet(); err != nil {
+			return err
+		}
+	}
+	if err := x.stream.Flush(); err != nil {
+		return err
+	}
+	return x.file.Write(x.w)
+}
+
+func (x *xlsxWriter) nextSheet() error {
+	if x.stream != nil {
+		if err := x.stream.Flush(); err != nil {
+			return err
+		}
+	}
+
+	x.sheetCount++
+	name := "Sheet1"
+	if x.sheetCount > 1 {
+		name = fmt.Sprintf("Sheet%d", x.sheetCount)
+		if _, err := x.file.NewSheet(name); err != nil {
+			return err
+		}
+	}
+
+	stream, err := x.file.NewStreamWriter(name)
+	if err != nil {
+		return err
+	}
+	x.stream = stream
+	x.row = 0
+
+	if x.options.OmitHeader || x.header == nil {
+		return nil
+	}
+	x.row++
+	return x.stream.SetRow("A1", x.header)
+}
+
+func (x *xlsxWriter) cell(i int, val any) any {
+	switch v := val.(type) {
+	case nil:
+		if x.options.NullValue != "" {
+			return x.options.NullValue
+		}
+		return nil
+	case time.Time:
+		style := x.timeStyle
+		if x.types[i] == "DATE" {
+			style = x.dateStyle
+		}
+		return excelize.Cell{StyleID: style, Value: v}
+	case int64, int32, int, float64, float32, bool:
+		return v
+	case string, []byte:
+		if t, err := toTime(v); err == nil && (x.types[i] == "DATE" || x.types[i] == "DATETIME" || x.types[i] == "TIMESTAMP") {
+			return x.cell(i, t)
+		}
+		return truncateCell(formatText(v))
+	default:
+		return truncateCell(formatText(v))
+	}
+}
+
+func truncateCell(s string) string {
+	if utf8.RuneCountInString(s) <= excelize.TotalCellChars {
+		return s
+	}
+	return string([]rune(s)[:excelize.TotalCellChars])
+}
@@ -0,0 +1,103 @@
+package http
+
+import (
+	"crypto/subtle"
+	"net"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+const tokenCookie = "datafrost_token"
+
+func TokenAuth(token string) func(http.Handler) http.Handler {
+	valid := func(candidate string) bool {
+		return candidate != "" && subtle.ConstantTimeCompare([]byte(candidate), []byte(token)) == 1
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			// ?token= is swapped for a cookie so the page and its assets load
+			// without the token in every URL.
+			if query := r.URL.Query().Get("token"); query != "" {
+				if !valid(query) {
+					unauthorized(w, r)
+					return
+				}
+				http.SetCookie(w, &http.Cookie{
+					Name:     tokenCookie,
+					Value:    token,
+					Path:     "/",
+					HttpOnly: true,
+					SameSite: http.SameSiteStrictMode,
+					Secure:   r.TLS != nil,
+				})
+				target := *r.URL
+				values := target.Query()
+				values.Del("token")
+				target.RawQuery = values.Encode()
+				http.Redirect(w, r, target.RequestURI(), http.StatusSeeOther)
+				return
+			}
+
+			if header, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok && valid(header) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			if cookie, err := r.Cookie(tokenCookie); err == nil && valid(cookie.Value) {
+				next.ServeHTTP(w, r)
+				return
+			}
+			unauthorized(w, r)
+		})
+	}
+}
+
+func unauthorized(w http.ResponseWriter, r *http.Request) {
+	if strings.HasPrefix(r.URL.Path, "/api/") {
+		JSONError(w, http.StatusUnauthorized, "missing or invalid token")
+		return
+	}
+	http.Error(w, "Unauthorized: open the URL printed by datafrost serve, including ?token=", http.StatusUnauthorized)
+}
+
+// Browsers label cross-site requests with Origin and Sec-Fetch-Site, even
+// the form posts and no-cors fetches that skip CORS preflight. The UI is
+// served from the same origin, so anything else is refused before it can
+// reach a handler.
+func SameOrigin(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if site := r.Header.Get("Sec-Fetch-Site"); site != "" && site != "same-origin" && site != "none" {
+			JSONError(w, http.StatusForbidden, "cross-origin requests are not allowed")
+			return
+		}
+		if origin := r.Header.Get("Origin"); origin != "" {
+			if u, err := url.Parse(origin); err != nil || u.Host != r.Host {
+				JSONError(w, http.StatusForbidden, "cross-origin requests are not allowed")
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// Pins the Host header to the address the window loads, so a page that
+// rebinds its own domain to 127.0.0.1 still cannot pass as same-origin. An
+// entry without a port matches that name on any port, for ports forwarded
+// under another number.
+func AllowedHosts(hosts ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			name := r.Host
+			if host, _, err := net.SplitHostPort(r.Host); err == nil {
+				name = host
+			}
+			if !slices.Contains(hosts, r.Host) && !slices.Contains(hosts, name) {
+				http.Error(w, "Forbidden", http.StatusForbidden)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/3-lines-studio/datafrost/internal/core/entity"
+	"github.com/3-lines-studio/datafrost/internal/usecase"
+)
+
+type CompareHandler struct {
+	uc *usecase.CompareUsecase
+}
+
+func NewCompareHandler(uc *usecase.CompareUsecase) *CompareHandler {
+	return &CompareHandler{uc: uc}
+}
+
+func (h *CompareHandler) Compare(w http.ResponseWriter, r *http.Request) {
+	var req entity.CompareRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		JSONError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	result, err := h.uc.Compare(r.Context(), req)
+	if err != nil {
+		if err == usecase.ErrConnectionNotFound {
+			JSONError(w, http.StatusNotFound, "connection not found")
+			return
+		}
+		JSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	JSONResponse(w, http.StatusOK, result)
+}
@@ -0,0 +1,72 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/3-lines-studio/datafrost/internal/core/entity"
+	"github.com/3-lines-studio/datafrost/internal/usecase"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type CompletionHandler struct {
+	uc *usecase.CompletionUsecase
+}
+
+func NewCompletionHandler(uc *usecase.CompletionUsecase) *CompletionHandler {
+	return &CompletionHandler{uc: uc}
+}
+
+func (h *CompletionHandler) Get(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		JSONError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	req := entity.CompletionRequest{SQL: r.URL.Query().Get("sql"), Cursor: -1}
+	if cursorStr := r.URL.Query().Get("cursor"); cursorStr != "" {
+		cursor, err := strconv.Atoi(cursorStr)
+		if err != nil {
+			JSONError(w, http.StatusBadRequest, "invalid cursor")
+			return
+		}
+		req.Cursor = cursor
+	}
+
+	h.respond(w, id, req)
+}
+
+func (h *CompletionHandler) Post(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		JSONError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	req := entity.CompletionRequest{Cursor: -1}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		JSONError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	h.respond(w, id, req)
+}
+
+func (h *CompletionHandler) respond(w http.ResponseWriter, id int64, req entity.CompletionRequest) {
+	response, err := h.uc.Complete(id, req)
+	if err != nil {
+		if err == usecase.ErrConnectionNotFound {
+			JSONError(w, http.StatusNotFound, "connection not found")
+			return
+		}
+		JSONError(w, http.StatusBadRequest, "failed to connect: "+err.Error())
+		return
+	}
+
+	JSONResponse(w, http.StatusOK, response)
+}
@@ -11,27 +11,47 @@ import (
 	"github.com/go-chi/chi/v5"
 )
 
+type connectionList struct {
+	Connections []entity.Connection `json:"connections"`
+	LastID      int64               `json:"last_id"`
+}
+
+type testResult struct {
+	Success bool `json:"success"`
+}
+
 type ConnectionsHandler struct {
-	uc *usecase.ConnectionUsecase
+	uc           *usecase.ConnectionUsecase
+	trustEnabled bool
 }
 
 func NewConnectionsHandler(uc *usecase.ConnectionUsecase) *ConnectionsHandler {
-	return &ConnectionsHandler{uc: uc}
+	return &ConnectionsHandler{uc: uc, trustEnabled: true}
+}
+
+// Under datafrost serve the API is reachable by anyone holding the token,
+// who must not be able to make the host read files or run commands.
+func (h *ConnectionsHandler) DisableTrust() {
+	h.trustEnabled = false
 }
 
 func (h *ConnectionsHandler) List(w http.ResponseWriter, r *http.Request) {
-	connections, lastID, err := h.uc.List()
+	filter := entity.ConnectionFilter{
+		Tag:         r.URL.Query().Get("tag"),
+		Environment: r.URL.Query().Get("environment"),
+		Group:       r.URL.Query().Get("group"),
+	}
+	connections, lastID, err := h.uc.ListFiltered(filter)
 	if err != nil {
+		if err == usecase.ErrInvalidEnvironment {
+			JSONError(w, http.StatusBadRequest, err.Error())
+			return
+		}
 		JSONError(w, http.StatusInternalServerError, err.Error())
 		return
 	}
 
-	response := map[string]any{
-		"connections": connections,
-		"last_id":     lastID,
-	}
-
-	JSONResponse(w, http.StatusOK, response)
+	JSONResponse(w, http.StatusOK, connectionList{Connections: connections, LastID: lastID})
 }
 
 func (h *ConnectionsHandler) Create(w http.ResponseWriter, r *http.Request) {
@@ -43,7 +63,8 @@ func (h *ConnectionsHandler) Create(w http.ResponseWriter, r *http.Request) {
 
 	conn, err := h.uc.Create(req)
 	if err != nil {
-		if err == usecase.ErrNameRequired || err == usecase.ErrTypeRequired {
+		if err == usecase.ErrNameRequired || err == usecase.ErrTypeRequired ||
+			err == usecase.ErrInvalidEnvironment || err == usecase.ErrInvalidColor {
 			JSONError(w, http.StatusBadRequest, err.Error())
 			return
 		}
@@ -86,6 +107,29 @@ func (h *ConnectionsHandler) SetLastConnected(w http.ResponseWriter, r *http.Req
 	w.WriteHeader(http.StatusNoContent)
 }
 
+func (h *ConnectionsHandler) Reorder(w http.ResponseWriter, r *http.Request) {
+	var req entity.ReorderConnectionsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		JSONError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := h.uc.Reorder(req.IDs); err != nil {
+		if err == usecase.ErrConnectionNotFound {
+			JSONError(w, http.StatusNotFound, "connection not found")
+			return
+		}
+		if err == usecase.ErrInvalidRequest {
+			JSONError(w, http.StatusBadRequest, "ids must not repeat")
+			return
+		}
+		JSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
 func (h *ConnectionsHandler) Update(w http.ResponseWriter, r *http.Request) {
 	idStr := chi.URLParam(r, "id")
 	id, err := strconv.ParseInt(idStr, 10, 64)
@@ -102,7 +146,8 @@ func (h *ConnectionsHandler) Update(w http.ResponseWriter, r *http.Request) {
 
 	conn, err := h.uc.Update(id, req)
 	if err != nil {
-		if err == usecase.ErrNameRequired || err == usecase.ErrTypeRequired {
+		if err == usecase.ErrNameRequired || err == usecase.ErrTypeRequired ||
+			err == usecase.ErrInvalidEnvironment || err == usecase.ErrInvalidColor {
 			JSONError(w, http.StatusBadRequest, err.Error())
 			return
 		}
@@ -129,7 +174,7 @@ func (h *ConnectionsHandler) Test(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	JSONResponse(w, http.StatusOK, map[string]bool{"success": true})
+	JSONResponse(w, http.StatusOK, testResult{Success: true})
 }
 
 func (h *ConnectionsHandler) TestExisting(w http.ResponseWriter, r *http.Request) {
@@ -149,5 +194,37 @@ func (h *ConnectionsHandler) TestExisting(w http.ResponseWriter, r *http.Request
 		return
 	}
 
-	JSONResponse(w, http.StatusOK, map[string]bool{"success": true})
+	JSONResponse(w, http.StatusOK, testResult{Success: true})
+}
+
+func (h *ConnectionsHandler) SetTrust(w http.ResponseWriter, r *http.Request) {
+	if !h.trustEnabled {
+		JSONError(w, http.StatusForbidden, "trust can only be changed with datafrost connections edit --trust-references while serving")
+		return
+	}
+
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		JSONError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	var req entity.TrustConnectionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		JSONError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	conn, err := h.uc.SetTrust(id, req.Trusted)
+	if err != nil {
+		if err == usecase.ErrConnectionNotFound {
+			JSONError(w, http.StatusNotFound, "connection not found")
+			return
+		}
+		JSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	JSONResponse(w, http.StatusOK, conn)
 }
@@ -0,0 +1,50 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/3-lines-studio/datafrost/internal/core/entity"
+	"github.com/3-lines-studio/datafrost/internal/usecase"
+)
+
+type ConnectionImportHandler struct {
+	uc *usecase.ConnectionImportUsecase
+}
+
+func NewConnectionImportHandler(uc *usecase.ConnectionImportUsecase) *ConnectionImportHandler {
+	return &ConnectionImportHandler{uc: uc}
+}
+
+func (h *ConnectionImportHandler) Sources(w http.ResponseWriter, r *http.Request) {
+	JSONResponse(w, http.StatusOK, h.uc.Sources())
+}
+
+func (h *ConnectionImportHandler) Preview(w http.ResponseWriter, r *http.Request) {
+	h.run(w, r, h.uc.Preview)
+}
+
+func (h *ConnectionImportHandler) Import(w http.ResponseWriter, r *http.Request) {
+	h.run(w, r, h.uc.Import)
+}
+
+func (h *ConnectionImportHandler) run(w http.ResponseWriter, r *http.Request, run func(entity.ConnectionImportRequest) (*entity.ConnectionImportResult, error)) {
+	var req entity.ConnectionImportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		JSONError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	result, err := run(req)
+	if err != nil {
+		if err == usecase.ErrUnknownSource || err == usecase.ErrInvalidOnDuplicate || errors.Is(err, usecase.ErrSourceUnreadable) {
+			JSONError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		JSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	JSONResponse(w, http.StatusOK, result)
+}
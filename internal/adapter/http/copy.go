@@ -0,0 +1,55 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/3-lines-studio/datafrost/internal/core/entity"
+	"github.com/3-lines-studio/datafrost/internal/usecase"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type CopyHandler struct {
+	uc *usecase.CopyUsecase
+}
+
+func NewCopyHandler(uc *usecase.CopyUsecase) *CopyHandler {
+	return &CopyHandler{uc: uc}
+}
+
+func (h *CopyHandler) Start(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		JSONError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	var req entity.CopyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		JSONError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	job, err := h.uc.Start(id, req)
+	if err != nil {
+		if err == usecase.ErrConnectionNotFound {
+			JSONError(w, http.StatusNotFound, "connection not found")
+			return
+		}
+		if err == usecase.ErrCopyNotSupported {
+			JSONError(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		if err == usecase.ErrProdNotConfirmed {
+			JSONError(w, http.StatusPreconditionRequired, err.Error())
+			return
+		}
+		JSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	JSONResponse(w, http.StatusAccepted, job)
+}
@@ -0,0 +1,95 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/3-lines-studio/datafrost/internal/core/entity"
+	"github.com/3-lines-studio/datafrost/internal/usecase"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type ExportHandler struct {
+	uc *usecase.ExportUsecase
+}
+
+func NewExportHandler(uc *usecase.ExportUsecase) *ExportHandler {
+	return &ExportHandler{uc: uc}
+}
+
+func (h *ExportHandler) Start(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		JSONError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	var req entity.ExportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		JSONError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	job, err := h.uc.Start(id, req)
+	if err != nil {
+		if err == usecase.ErrConnectionNotFound {
+			JSONError(w, http.StatusNotFound, "connection not found")
+			return
+		}
+		if err == usecase.ErrQueryNotFound {
+			JSONError(w, http.StatusNotFound, "query not found")
+			return
+		}
+		if err == usecase.ErrExportPathNotAllowed {
+			JSONError(w, http.StatusForbidden, err.Error())
+			return
+		}
+		if err == usecase.ErrFileExists {
+			JSONError(w, http.StatusConflict, err.Error())
+			return
+		}
+		JSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	JSONResponse(w, http.StatusAccepted, job)
+}
+
+func (h *ExportHandler) Formats(w http.ResponseWriter, r *http.Request) {
+	JSONResponse(w, http.StatusOK, h.uc.Formats())
+}
+
+type JobsHandler struct {
+	uc *usecase.JobUsecase
+}
+
+func NewJobsHandler(uc *usecase.JobUsecase) *JobsHandler {
+	return &JobsHandler{uc: uc}
+}
+
+func (h *JobsHandler) List(w http.ResponseWriter, r *http.Request) {
+	JSONResponse(w, http.StatusOK, h.uc.List())
+}
+
+func (h *JobsHandler) Get(w http.ResponseWriter, r *http.Request) {
+	job, err := h.uc.Get(chi.URLParam(r, "jobId"))
+	if err != nil {
+		JSONError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	JSONResponse(w, http.StatusOK, job)
+}
+
+func (h *JobsHandler) Cancel(w http.ResponseWriter, r *http.Request) {
+	job, err := h.uc.Cancel(chi.URLParam(r, "jobId"))
+	if err != nil {
+		JSONError(w, http.StatusNotFound, err.Error())
+		return
+	}
+
+	JSONResponse(w, http.StatusOK, job)
+}
@@ -0,0 +1,68 @@
+package http
+
+import (
+	"net/http"
+	"sync"
+	"time"
+)
+
+type IdleTracker struct {
+	timeout time.Duration
+	busy    func() bool
+
+	mu       sync.Mutex
+	active   int
+	lastSeen time.Time
+}
+
+func NewIdleTracker(timeout time.Duration, busy func() bool) *IdleTracker {
+	return &IdleTracker{timeout: timeout, busy: busy, lastSeen: time.Now()}
+}
+
+func (t *IdleTracker) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.mu.Lock()
+		t.active++
+		t.mu.Unlock()
+		defer func() {
+			t.mu.Lock()
+			t.active--
+			t.lastSeen = time.Now()
+			t.mu.Unlock()
+		}()
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (t *IdleTracker) Idle() <-chan struct{} {
+	done := make(chan struct{})
+	go func() {
+		interval := min(t.timeout/4, time.Minute)
+		if interval <= 0 {
+			interval = time.Second
+		}
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for range ticker.C {
+			// Background jobs keep the server alive even with no requests.
+			if t.busy != nil && t.busy() {
+				t.touch()
+				continue
+			}
+			t.mu.Lock()
+			idle := t.active == 0 && time.Since(t.lastSeen) >= t.timeout
+			t.mu.Unlock()
+			if idle {
+				close(done)
+				return
+			}
+		}
+	}()
+	return done
+}
+
+func (t *IdleTracker) touch() {
+	t.mu.Lock()
+	t.lastSeen = time.Now()
+	t.mu.Unlock()
+}
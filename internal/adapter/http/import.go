@@ -0,0 +1,112 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/3-lines-studio/datafrost/internal/core/entity"
+	"github.com/3-lines-studio/datafrost/internal/usecase"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type ImportHandler struct {
+	uc *usecase.ImportUsecase
+}
+
+func NewImportHandler(uc *usecase.ImportUsecase) *ImportHandler {
+	return &ImportHandler{uc: uc}
+}
+
+func (h *ImportHandler) Upload(w http.ResponseWriter, r *http.Request) {
+	reader, err := r.MultipartReader()
+	if err != nil {
+		JSONError(w, http.StatusBadRequest, "expected multipart form upload")
+		return
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if err != nil {
+			JSONError(w, http.StatusBadRequest, "file field is required")
+			return
+		}
+		if part.FormName() != "file" {
+			_ = part.Close()
+			continue
+		}
+
+		upload, err := h.uc.SaveUpload(part.FileName(), part)
+		_ = part.Close()
+		if err != nil {
+			JSONError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		JSONResponse(w, http.StatusCreated, upload)
+		return
+	}
+}
+
+func (h *ImportHandler) Preview(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		JSONError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	var req entity.ImportPreviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		JSONError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	preview, err := h.uc.Preview(id, req)
+	if err != nil {
+		writeImportError(w, err)
+		return
+	}
+
+	JSONResponse(w, http.StatusOK, preview)
+}
+
+func (h *ImportHandler) Start(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		JSONError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	var req entity.ImportRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		JSONError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	job, err := h.uc.Start(id, req)
+	if err != nil {
+		writeImportError(w, err)
+		return
+	}
+
+	JSONResponse(w, http.StatusAccepted, job)
+}
+
+func writeImportError(w http.ResponseWriter, err error) {
+	if err == usecase.ErrConnectionNotFound {
+		JSONError(w, http.StatusNotFound, "connection not found")
+		return
+	}
+	if err == usecase.ErrImportNotSupported {
+		JSONError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	if err == usecase.ErrProdNotConfirmed {
+		JSONError(w, http.StatusPreconditionRequired, err.Error())
+		return
+	}
+	JSONError(w, http.StatusBadRequest, err.Error())
+}
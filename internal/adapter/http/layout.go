@@ -9,6 +9,10 @@ import (
 	"github.com/go-chi/chi/v5"
 )
 
+type layoutBody struct {
+	Layout string `json:"layout"`
+}
+
 type LayoutHandler struct {
 	uc *usecase.AppStateUsecase
 }
@@ -26,15 +30,13 @@ func (h *LayoutHandler) Get(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	JSONResponse(w, http.StatusOK, map[string]string{"layout": layout})
+	JSONResponse(w, http.StatusOK, layoutBody{Layout: layout})
 }
 
 func (h *LayoutHandler) Save(w http.ResponseWriter, r *http.Request) {
 	key := chi.URLParam(r, "key")
 
-	var req struct {
-		Layout string `json:"layout"`
-	}
+	var req layoutBody
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		JSONError(w, http.StatusBadRequest, "Invalid request body")
@@ -46,5 +48,5 @@ func (h *LayoutHandler) Save(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	JSONResponse(w, http.StatusOK, map[string]string{"layout": req.Layout})
+	JSONResponse(w, http.StatusOK, req)
 }
@@ -0,0 +1,272 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+)
+
+var pathParamPattern = regexp.MustCompile(`\{([^}]+)\}`)
+
+type apiParam struct {
+	Name        string
+	Type        string
+	Description string
+}
+
+type apiOperation struct {
+	Method    string
+	Path      string
+	ID        string
+	Tag       string
+	Summary   string
+	Query     []apiParam
+	Request   any
+	Multipart bool
+	Status    int
+	Response  any
+}
+
+type OpenAPI struct {
+	schemas    *schemaRegistry
+	operations map[string]apiOperation
+	responses  map[string]map[string]any
+	document   []byte
+}
+
+func NewOpenAPI(version string) *OpenAPI {
+	spec := &OpenAPI{
+		schemas:    newSchemaRegistry(),
+		operations: make(map[string]apiOperation),
+		responses:  make(map[string]map[string]any),
+	}
+
+	paths := map[string]map[string]any{}
+	for _, op := range apiOperations() {
+		key := operationKey(op.Method, op.Path)
+		spec.operations[key] = op
+		if op.Response != nil {
+			spec.responses[key] = spec.schemas.schemaFor(reflect.TypeOf(op.Response))
+		}
+		if paths[op.Path] == nil {
+			paths[op.Path] = map[string]any{}
+		}
+		paths[op.Path][strings.ToLower(op.Method)] = spec.operationDocument(op)
+	}
+	spec.schemas.schemaFor(reflect.TypeFor[apiError]())
+
+	document := map[string]any{
+		"openapi": "3.1.0",
+		"info": map[string]any{
+			"title":       "Datafrost API",
+			"version":     version,
+			"description": "Local REST API used by the Datafrost UI.",
+		},
+		"paths":      paths,
+		"components": map[string]any{"schemas": spec.schemas.schemas},
+	}
+	data, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		panic(fmt.Sprintf("openapi: %v", err))
+	}
+	spec.document = data
+	return spec
+}
+
+type apiError struct {
+	Error string `json:"error"`
+}
+
+func (s *OpenAPI) operationDocument(op apiOperation) map[string]any {
+	var parameters []any
+	for _, match := range pathParamPattern.FindAllStringSubmatch(op.Path, -1) {
+		schema := map[string]any{"type": "string"}
+		if match[1] == "id" || match[1] == "queryId" {
+			schema = map[string]any{"type": "integer"}
+		}
+		parameters = append(parameters, map[string]any{
+			"name": match[1], "in": "path", "required": true, "schema": schema,
+		})
+	}
+	for _, param := range op.Query {
+		entry := map[string]any{
+			"name": param.Name, "in": "query", "schema": map[string]any{"type": param.Type},
+		}
+		if param.Description != "" {
+			entry["description"] = param.Description
+		}
+		parameters = append(parameters, entry)
+	}
+
+	doc := map[string]any{
+		"operationId": op.ID,
+		"summary":     op.Summary,
+		"tags":        []string{op.Tag},
+	}
+	if len(parameters) > 0 {
+		doc["parameters"] = parameters
+	}
+
+	switch {
+	case op.Multipart:
+		doc["requestBody"] = map[string]any{
+			"required": true,
+			"content": map[string]any{"multipart/form-data": map[string]any{
+				"schema": map[string]any{
+					"type":       "object",
+					"properties": map[string]any{"file": map[string]any{"type": "string", "format": "binary"}},
+					"required":   []string{"file"},
+				},
+			}},
+		}
+	case op.Request != nil:
+		doc["requestBody"] = map[string]any{
+			"required": true,
+			"content": map[string]any{"application/json": map[string]any{
+				"schema": s.schemas.schemaFor(reflect.TypeOf(op.Request)),
+			}},
+		}
+	}
+
+	responses := map[string]any{
+		"default": map[string]any{
+			"description": "Error",
+			"content": map[string]any{"application/json": map[string]any{
+				"schema": map[string]any{"$ref": schemaRefPrefix + "ApiError"},
+			}},
+		},
+	}
+	if op.Response == nil {
+		responses[strconv.Itoa(op.Status)] = map[string]any{"description": http.StatusText(op.Status)}
+	} else {
+		responses[strconv.Itoa(op.Status)] = map[string]any{
+			"description": http.StatusText(op.Status),
+			"content": map[string]any{"application/json": map[string]any{
+				"schema": s.responses[operationKey(op.Method, op.Path)],
+			}},
+		}
+	}
+	doc["responses"] = responses
+	return doc
+}
+
+func (s *OpenAPI) Document() []byte {
+	return s.document
+}
+
+func (s *OpenAPI) Spec(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(s.document)
+}
+
+func (s *OpenAPI) CheckRoutes(routes chi.Routes) ([]string, error) {
+	registered := map[string]bool{}
+	err := chi.Walk(routes, func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		registered[operationKey(method, normalizeRoute(route))] = true
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var problems []string
+	for key := range registered {
+		if _, ok := s.operations[key]; !ok {
+			problems = append(problems, "undocumented route: "+key)
+		}
+	}
+	for key := range s.operations {
+		if !registered[key] {
+			problems = append(problems, "documented but not routed: "+key)
+		}
+	}
+	sort.Strings(problems)
+	return problems, nil
+}
+
+func (s *OpenAPI) ValidateResponses(next http.Handler) http.Handler {
+	// Logs responses that no longer match the document, so handler changes
+	// that drift from the spec surface during development.
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec := &recordingWriter{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+
+		rctx := chi.RouteContext(r.Context())
+		if rctx == nil || rctx.RoutePattern() == "" {
+			return
+		}
+		key := operationKey(r.Method, normalizeRoute(rctx.RoutePattern()))
+		if err := s.validateResponse(key, rec.status, rec.Header().Get("Content-Type"), rec.body.Bytes()); err != nil {
+			log.Printf("openapi: %s: %v", key, err)
+		}
+	})
+}
+
+func (s *OpenAPI) validateResponse(key string, status int, contentType string, body []byte) error {
+	op, ok := s.operations[key]
+	if !ok {
+		return fmt.Errorf("route is not documented")
+	}
+
+	var schema map[string]any
+	switch {
+	case status >= 400:
+		schema = map[string]any{"$ref": schemaRefPrefix + "ApiError"}
+	case status != op.Status:
+		return fmt.Errorf("status %d, documented %d", status, op.Status)
+	case op.Response == nil:
+		if len(bytes.TrimSpace(body)) > 0 {
+			return fmt.Errorf("documented without a body but returned one")
+		}
+		return nil
+	default:
+		schema = s.responses[key]
+	}
+
+	if !strings.HasPrefix(contentType, "application/json") {
+		return fmt.Errorf("content type %q, expected application/json", contentType)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return fmt.Errorf("invalid JSON body: %w", err)
+	}
+	return s.schemas.validate(schema, value, "response")
+}
+
+type recordingWriter struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (w *recordingWriter) WriteHeader(status int) {
+	w.status = status
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *recordingWriter) Write(p []byte) (int, error) {
+	w.body.Write(p)
+	return w.ResponseWriter.Write(p)
+}
+
+func operationKey(method, path string) string {
+	return strings.ToUpper(method) + " " + path
+}
+
+func normalizeRoute(route string) string {
+	if len(route) > 1 {
+		return strings.TrimSuffix(route, "/")
+	}
+	return route
+}
@@ -0,0 +1,141 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/3-lines-studio/datafrost/internal/core/entity"
+)
+
+func apiOperations() []apiOperation {
+	filters := apiParam{Name: "filters", Type: "string", Description: "JSON array of filters ({column, operator, value})"}
+	page := apiParam{Name: "page", Type: "integer", Description: "1-based page number"}
+	limit := apiParam{Name: "limit", Type: "integer", Description: "Page size"}
+
+	return []apiOperation{
+		{Method: http.MethodGet, Path: "/api/openapi.json", ID: "getOpenAPI", Tag: "meta",
+			Summary: "This OpenAPI document", Status: http.StatusOK, Response: map[string]any{}},
+		{Method: http.MethodGet, Path: "/api/adapters", ID: "listAdapters", Tag: "meta",
+			Summary: "List database adapters and their connection fields", Status: http.StatusOK, Response: []entity.AdapterInfo{}},
+
+		{Method: http.MethodGet, Path: "/api/connections", ID: "listConnections", Tag: "connections",
+			Summary: "List saved connections", Query: []apiParam{
+				{Name: "tag", Type: "string", Description: "Only connections with this tag (case-insensitive)"},
+				{Name: "environment", Type: "string", Description: "dev, staging or prod"},
+				{Name: "group", Type: "string", Description: "Only connections in this group"},
+			}, Status: http.StatusOK, Response: connectionList{}},
+		{Method: http.MethodPost, Path: "/api/connections", ID: "createConnection", Tag: "connections",
+			Summary: "Create a connection", Request: entity.CreateConnectionRequest{}, Status: http.StatusCreated, Response: entity.Connection{}},
+		{Method: http.MethodPost, Path: "/api/connections/test", ID: "testConnection", Tag: "connections",
+			Summary: "Test credentials without saving", Request: entity.TestConnectionRequest{}, Status: http.StatusOK, Response: testResult{}},
+		{Method: http.MethodGet, Path: "/api/connections/import/sources", ID: "listConnectionImportSources", Tag: "connections",
+			Summary: "Sources connections can be imported from", Status: http.StatusOK, Response: []string{}},
+		{Method: http.MethodPost, Path: "/api/connections/import/preview", ID: "previewConnectionImport", Tag: "connections",
+			Summary: "Parse a pgpass, pg_service, env, DBeaver or TablePlus source without saving", Request: entity.ConnectionImportRequest{}, Status: http.StatusOK, Response: entity.ConnectionImportResult{}},
+		{Method: http.MethodPost, Path: "/api/connections/import", ID: "importConnections", Tag: "connections",
+			Summary: "Create connections from a source", Request: entity.ConnectionImportRequest{}, Status: http.StatusOK, Response: entity.ConnectionImportResult{}},
+		{Method: http.MethodPut, Path: "/api/connections/order", ID: "reorderConnections", Tag: "connections",
+			Summary: "Set the manual sort order of connections", Request: entity.ReorderConnectionsRequest{}, Status: http.StatusNoContent},
+		{Method: http.MethodPut, Path: "/api/connections/{id}", ID: "updateConnection", Tag: "connections",
+			Summary: "Update a connection", Request: entity.UpdateConnectionRequest{}, Status: http.StatusOK, Response: entity.Connection{}},
+		{Method: http.MethodDelete, Path: "/api/connections/{id}", ID: "deleteConnection", Tag: "connections",
+			Summary: "Delete a connection with its saved queries and tabs", Status: http.StatusNoContent},
+		{Method: http.MethodPost, Path: "/api/connections/{id}/select", ID: "selectConnection", Tag: "connections",
+			Summary: "Remember the connection as last used", Status: http.StatusNoContent},
+		{Method: http.MethodPost, Path: "/api/connections/{id}/test", ID: "testExistingConnection", Tag: "connections",
+			Summary: "Test a saved connection", Status: http.StatusOK, Response: testResult{}},
+		{Method: http.MethodPost, Path: "/api/connections/{id}/trust", ID: "trustConnection", Tag: "connections",
+			Summary: "Allow or stop resolving ${file:} and ${cmd:} references (refused under datafrost serve)", Request: entity.TrustConnectionRequest{}, Status: http.StatusOK, Response: entity.Connection{}},
+
+		{Method: http.MethodPost, Path: "/api/connections/{id}/refresh", ID: "refreshMetadata", Tag: "tables",
+			Summary: "Drop cached metadata and re-list tables", Status: http.StatusOK, Response: []entity.TableInfo{}},
+		{Method: http.MethodGet, Path: "/api/connections/{id}/metadata-ttl", ID: "getMetadataTTL", Tag: "tables",
+			Summary: "Metadata cache TTL", Status: http.StatusOK, Response: metadataTTLBody{}},
+		{Method: http.MethodPost, Path: "/api/connections/{id}/metadata-ttl", ID: "setMetadataTTL", Tag: "tables",
+			Summary: "Set the metadata cache TTL", Request: metadataTTLBody{}, Status: http.StatusOK, Response: metadataTTLBody{}},
+		{Method: http.MethodGet, Path: "/api/connections/{id}/tables", ID: "listTables", Tag: "tables",
+			Summary: "List tables and views", Status: http.StatusOK, Response: []entity.TableInfo{}},
+		{Method: http.MethodGet, Path: "/api/connections/{id}/tables/{name}", ID: "getTableData", Tag: "tables",
+			Summary: "Paginated table data", Query: []apiParam{limit, page, filters}, Status: http.StatusOK, Response: entity.QueryResult{}},
+		{Method: http.MethodGet, Path: "/api/connections/{id}/tables/{name}/schema", ID: "getTableSchema", Tag: "tables",
+			Summary: "Columns, indexes, constraints and foreign keys", Status: http.StatusOK, Response: entity.TableSchema{}},
+		{Method: http.MethodGet, Path: "/api/connections/{id}/tables/{name}/ddl", ID: "getTableDDL", Tag: "tables",
+			Summary: "CREATE statements for a table or view and its indexes", Status: http.StatusOK, Response: entity.TableDDL{}},
+		{Method: http.MethodGet, Path: "/api/connections/{id}/tables/{name}/columns/{col}/values", ID: "getColumnValues", Tag: "tables",
+			Summary: "Distinct column values with counts",
+			Query:   []apiParam{{Name: "search", Type: "string", Description: "Substring to match"}, filters, page, {Name: "limit", Type: "integer", Description: "Page size, up to 200"}},
+			Status:  http.StatusOK, Response: entity.ColumnValues{}},
+		{Method: http.MethodGet, Path: "/api/connections/{id}/erd", ID: "getERD", Tag: "tables",
+			Summary: "ER diagram",
+			Query:   []apiParam{{Name: "schema", Type: "string"}, {Name: "tables", Type: "string", Description: "Glob pattern of table names"}},
+			Status:  http.StatusOK, Response: entity.ERDiagram{}},
+
+		{Method: http.MethodGet, Path: "/api/connections/{id}/search", ID: "searchSchema", Tag: "search",
+			Summary: "Search table and column names, types and comments",
+			Query:   []apiParam{{Name: "q", Type: "string"}, {Name: "limit", Type: "integer"}},
+			Status:  http.StatusOK, Response: entity.SearchResponse{}},
+		{Method: http.MethodPost, Path: "/api/connections/{id}/search/reindex", ID: "reindexSchema", Tag: "search",
+			Summary: "Rebuild the schema search index", Status: http.StatusOK, Response: entity.SchemaIndexStatus{}},
+		{Method: http.MethodGet, Path: "/api/search", ID: "searchAll", Tag: "search",
+			Summary: "Search across all connections",
+			Query:   []apiParam{{Name: "q", Type: "string"}, {Name: "limit", Type: "integer"}},
+			Status:  http.StatusOK, Response: entity.SearchResponse{}},
+
+		{Method: http.MethodPost, Path: "/api/connections/{id}/query", ID: "executeQuery", Tag: "query",
+			Summary: "Execute a read-only SQL query", Request: entity.QueryRequest{}, Status: http.StatusOK, Response: entity.QueryResult{}},
+		{Method: http.MethodPost, Path: "/api/connections/{id}/explain", ID: "explainQuery", Tag: "query",
+			Summary: "Query plan tree", Request: entity.ExplainRequest{}, Status: http.StatusOK, Response: entity.QueryPlan{}},
+		{Method: http.MethodGet, Path: "/api/connections/{id}/completions", ID: "getCompletions", Tag: "query",
+			Summary: "Editor autocomplete",
+			Query:   []apiParam{{Name: "sql", Type: "string"}, {Name: "cursor", Type: "integer", Description: "Cursor offset in sql"}},
+			Status:  http.StatusOK, Response: entity.CompletionResponse{}},
+		{Method: http.MethodPost, Path: "/api/connections/{id}/completions", ID: "postCompletions", Tag: "query",
+			Summary: "Editor autocomplete for long SQL", Request: entity.CompletionRequest{}, Status: http.StatusOK, Response: entity.CompletionResponse{}},
+		{Method: http.MethodPost, Path: "/api/connections/{id}/profile", ID: "profileColumns", Tag: "query",
+			Summary: "Per-column statistics for a table or query", Request: entity.ProfileRequest{}, Status: http.StatusOK, Response: entity.ProfileReport{}},
+		{Method: http.MethodPost, Path: "/api/compare", ID: "compareResults", Tag: "query",
+			Summary: "Diff two query results by key columns", Request: entity.CompareRequest{}, Status: http.StatusOK, Response: entity.CompareResult{}},
+		{Method: http.MethodPost, Path: "/api/schema-diff", ID: "diffSchemas", Tag: "query",
+			Summary: "Compare table schemas of two connections", Request: entity.SchemaDiffRequest{}, Status: http.StatusOK, Response: entity.SchemaDiff{}},
+
+		{Method: http.MethodPost, Path: "/api/connections/{id}/export", ID: "startExport", Tag: "jobs",
+			Summary: "Export a query, saved query or table to a file", Request: entity.ExportRequest{}, Status: http.StatusAccepted, Response: entity.Job{}},
+		{Method: http.MethodGet, Path: "/api/export/formats", ID: "listExportFormats", Tag: "jobs",
+			Summary: "Available export formats", Status: http.StatusOK, Response: []string{}},
+		{Method: http.MethodPost, Path: "/api/import/upload", ID: "uploadImportFile", Tag: "jobs",
+			Summary: "Upload a file for import", Multipart: true, Status: http.StatusCreated, Response: entity.ImportUpload{}},
+		{Method: http.MethodPost, Path: "/api/connections/{id}/import/preview", ID: "previewImport", Tag: "jobs",
+			Summary: "Preview an import file with inferred column types", Request: entity.ImportPreviewRequest{}, Status: http.StatusOK, Response: entity.ImportPreview{}},
+		{Method: http.MethodPost, Path: "/api/connections/{id}/import", ID: "startImport", Tag: "jobs",
+			Summary: "Bulk import into a table", Request: entity.ImportRequest{}, Status: http.StatusAccepted, Response: entity.Job{}},
+		{Method: http.MethodPost, Path: "/api/connections/{id}/copy", ID: "startCopy", Tag: "jobs",
+			Summary: "Copy a table or query result into another connection", Request: entity.CopyRequest{}, Status: http.StatusAccepted, Response: entity.Job{}},
+		{Method: http.MethodGet, Path: "/api/jobs", ID: "listJobs", Tag: "jobs",
+			Summary: "List background jobs", Status: http.StatusOK, Response: []entity.Job{}},
+		{Method: http.MethodGet, Path: "/api/jobs/{jobId}", ID: "getJob", Tag: "jobs",
+			Summary: "Job status and progress", Status: http.StatusOK, Response: entity.Job{}},
+		{Method: http.MethodPost, Path: "/api/jobs/{jobId}/cancel", ID: "cancelJob", Tag: "jobs",
+			Summary: "Cancel a running job", Status: http.StatusOK, Response: entity.Job{}},
+
+		{Method: http.MethodGet, Path: "/api/connections/{id}/queries", ID: "listSavedQueries", Tag: "saved queries",
+			Summary: "List saved queries", Status: http.StatusOK, Response: savedQueryList{}},
+		{Method: http.MethodPost, Path: "/api/connections/{id}/queries", ID: "createSavedQuery", Tag: "saved queries",
+			Summary: "Save a query", Request: savedQueryBody{}, Status: http.StatusCreated, Response: entity.SavedQuery{}},
+		{Method: http.MethodPut, Path: "/api/connections/{id}/queries/{queryId}", ID: "updateSavedQuery", Tag: "saved queries",
+			Summary: "Update a saved query", Request: savedQueryBody{}, Status: http.StatusOK, Response: entity.SavedQuery{}},
+		{Method: http.MethodDelete, Path: "/api/connections/{id}/queries/{queryId}", ID: "deleteSavedQuery", Tag: "saved queries",
+			Summary: "Delete a saved query", Status: http.StatusNoContent},
+
+		{Method: http.MethodGet, Path: "/api/connections/{id}/tabs", ID: "getTabs", Tag: "app state",
+			Summary: "Open tabs", Status: http.StatusOK, Response: tabsBody{}},
+		{Method: http.MethodPost, Path: "/api/connections/{id}/tabs", ID: "saveTabs", Tag: "app state",
+			Summary: "Save open tabs", Request: tabsBody{}, Status: http.StatusOK, Response: tabsBody{}},
+		{Method: http.MethodGet, Path: "/api/theme", ID: "getTheme", Tag: "app state",
+			Summary: "Theme preference", Status: http.StatusOK, Response: themeBody{}},
+		{Method: http.MethodPost, Path: "/api/theme", ID: "setTheme", Tag: "app state",
+			Summary: "Set the theme (light or dark)", Request: themeBody{}, Status: http.StatusOK, Response: themeBody{}},
+		{Method: http.MethodGet, Path: "/api/layouts/{key}", ID: "getLayout", Tag: "app state",
+			Summary: "Panel layout", Status: http.StatusOK, Response: layoutBody{}},
+		{Method: http.MethodPost, Path: "/api/layouts/{key}", ID: "saveLayout", Tag: "app state",
+			Summary: "Save a panel layout", Request: layoutBody{}, Status: http.StatusOK, Response: layoutBody{}},
+	}
+}
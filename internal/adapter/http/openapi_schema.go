@@ -0,0 +1,257 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+)
+
+var (
+	timeType       = reflect.TypeFor[time.Time]()
+	rawMessageType = reflect.TypeFor[json.RawMessage]()
+)
+
+const schemaRefPrefix = "#/components/schemas/"
+
+type schemaRegistry struct {
+	schemas map[string]map[string]any
+	types   map[string]reflect.Type
+}
+
+func newSchemaRegistry() *schemaRegistry {
+	return &schemaRegistry{
+		schemas: make(map[string]map[string]any),
+		types:   make(map[string]reflect.Type),
+	}
+}
+
+func (s *schemaRegistry) schemaFor(t reflect.Type) map[string]any {
+	// Mirrors what encoding/json produces for t, so the document stays in
+	// step with the structs the handlers encode.
+	switch t {
+	case timeType:
+		return map[string]any{"type": "string", "format": "date-time"}
+	case rawMessageType:
+		return map[string]any{}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return nullable(s.schemaFor(t.Elem()))
+	case reflect.Interface:
+		return map[string]any{}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]any{"type": "string", "contentEncoding": "base64"}
+		}
+		return nullable(map[string]any{"type": "array", "items": s.schemaFor(t.Elem())})
+	case reflect.Array:
+		return map[string]any{"type": "array", "items": s.schemaFor(t.Elem())}
+	case reflect.Map:
+		return nullable(map[string]any{"type": "object", "additionalProperties": s.schemaFor(t.Elem())})
+	case reflect.Struct:
+		name := schemaName(t)
+		if existing, ok := s.types[name]; ok && existing != t {
+			panic(fmt.Sprintf("openapi: schema name %s is used by both %s and %s", name, existing, t))
+		}
+		if _, ok := s.schemas[name]; !ok {
+			s.types[name] = t
+			// Register before descending so self-referencing types terminate.
+			s.schemas[name] = map[string]any{}
+			s.schemas[name] = s.objectSchema(t)
+		}
+		return map[string]any{"$ref": schemaRefPrefix + name}
+	}
+	return map[string]any{}
+}
+
+func (s *schemaRegistry) objectSchema(t reflect.Type) map[string]any {
+	properties := map[string]any{}
+	var required []string
+	s.collectFields(t, properties, &required)
+	sort.Strings(required)
+
+	schema := map[string]any{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema
+}
+
+func (s *schemaRegistry) collectFields(t reflect.Type, properties map[string]any, required *[]string) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				s.collectFields(embedded, properties, required)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		properties[name] = s.schemaFor(field.Type)
+		if !slices.Contains(strings.Split(opts, ","), "omitempty") {
+			*required = append(*required, name)
+		}
+	}
+}
+
+func schemaName(t reflect.Type) string {
+	name := t.Name()
+	if name == "" {
+		return "Object"
+	}
+	return strings.ToUpper(name[:1]) + name[1:]
+}
+
+func nullable(schema map[string]any) map[string]any {
+	if typ, ok := schema["type"].(string); ok {
+		out := make(map[string]any, len(schema))
+		for k, v := range schema {
+			out[k] = v
+		}
+		out["type"] = []string{typ, "null"}
+		return out
+	}
+	if _, ok := schema["type"]; ok || len(schema) == 0 {
+		return schema
+	}
+	return map[string]any{"anyOf": []any{schema, map[string]any{"type": "null"}}}
+}
+
+func (s *schemaRegistry) validate(schema map[string]any, value any, path string) error {
+	if ref, ok := schema["$ref"].(string); ok {
+		target, ok := s.schemas[strings.TrimPrefix(ref, schemaRefPrefix)]
+		if !ok {
+			return fmt.Errorf("%s: unknown schema %s", path, ref)
+		}
+		return s.validate(target, value, path)
+	}
+
+	if anyOf, ok := schema["anyOf"].([]any); ok {
+		var firstErr error
+		for _, option := range anyOf {
+			err := s.validate(option.(map[string]any), value, path)
+			if err == nil {
+				return nil
+			}
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+		return firstErr
+	}
+
+	var types []string
+	switch typ := schema["type"].(type) {
+	case string:
+		types = []string{typ}
+	case []string:
+		types = typ
+	default:
+		return nil
+	}
+
+	actual := jsonType(value)
+	if !slices.Contains(types, actual) && !(actual == "integer" && slices.Contains(types, "number")) {
+		return fmt.Errorf("%s: expected %s, got %s", path, strings.Join(types, " or "), actual)
+	}
+
+	switch v := value.(type) {
+	case []any:
+		items, _ := schema["items"].(map[string]any)
+		if items == nil {
+			return nil
+		}
+		for i, item := range v {
+			if err := s.validate(items, item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case map[string]any:
+		properties, _ := schema["properties"].(map[string]any)
+		required, _ := schema["required"].([]string)
+		for _, name := range required {
+			if _, ok := v[name]; !ok {
+				return fmt.Errorf("%s: missing required property %q", path, name)
+			}
+		}
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			if propSchema, ok := properties[key].(map[string]any); ok {
+				if err := s.validate(propSchema, v[key], path+"."+key); err != nil {
+					return err
+				}
+				continue
+			}
+			switch extra := schema["additionalProperties"].(type) {
+			case bool:
+				if !extra {
+					return fmt.Errorf("%s: unexpected property %q", path, key)
+				}
+			case map[string]any:
+				if err := s.validate(extra, v[key], path+"."+key); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func jsonType(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			return "integer"
+		}
+		return "number"
+	case []any:
+		return "array"
+	case map[string]any:
+		return "object"
+	}
+	return "unknown"
+}
@@ -0,0 +1,324 @@
+package http
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"github.com/3-lines-studio/datafrost/internal/adapter/connimport"
+	"github.com/3-lines-studio/datafrost/internal/adapter/database"
+	"github.com/3-lines-studio/datafrost/internal/adapter/export"
+	"github.com/3-lines-studio/datafrost/internal/adapter/importer"
+	"github.com/3-lines-studio/datafrost/internal/adapter/repository"
+	"github.com/3-lines-studio/datafrost/internal/adapter/secret"
+	"github.com/3-lines-studio/datafrost/internal/core/entity"
+	"github.com/3-lines-studio/datafrost/internal/usecase"
+)
+
+// Calls every documented operation through the real router against SQLite
+// fixtures and validates each response against the OpenAPI document, so a
+// handler that drifts from the spec fails the build.
+func TestResponsesMatchOpenAPI(t *testing.T) {
+	dir := t.TempDir()
+	// The config database lives under the user config directory and uploads
+	// under the temp directory; keep both inside the test.
+	t.Setenv("HOME", dir)
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("APPDATA", dir)
+	t.Setenv("TMPDIR", dir)
+
+	sourcePath := filepath.Join(dir, "source.db")
+	targetPath := filepath.Join(dir, "target.db")
+	seedSQLite(t, sourcePath,
+		"CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT NOT NULL, email TEXT)",
+		"CREATE INDEX users_name ON users (name)",
+		"CREATE TABLE orders (id INTEGER PRIMARY KEY, user_id INTEGER REFERENCES users (id), total REAL)",
+		"INSERT INTO users (name, email) VALUES ('ada', 'ada@example.com'), ('grace', NULL), ('ada', 'ada@example.org')",
+		"INSERT INTO orders (user_id, total) VALUES (1, 9.5), (2, 12)",
+	)
+	seedSQLite(t, targetPath, "CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT)")
+
+	c := newContractClient(t)
+
+	c.call("GET", "/api/openapi.json", "/api/openapi.json", nil)
+	c.call("GET", "/api/adapters", "/api/adapters", nil)
+
+	c.call("POST", "/api/connections/test", "/api/connections/test", entity.TestConnectionRequest{
+		Type: "sqlite", Credentials: map[string]any{"path": sourcePath},
+	})
+	var source, target entity.Connection
+	c.decode(c.call("POST", "/api/connections", "/api/connections", entity.CreateConnectionRequest{
+		Name: "source", Type: "sqlite", Credentials: map[string]any{"path": sourcePath},
+		Group: "fixtures", Tags: []string{"local"}, Environment: entity.EnvironmentDev,
+	}), &source)
+	c.decode(c.call("POST", "/api/connections", "/api/connections", entity.CreateConnectionRequest{
+		Name: "target", Type: "sqlite", Credentials: map[string]any{"path": targetPath},
+	}), &target)
+	c.call("GET", "/api/connections", "/api/connections?tag=local", nil)
+	c.call("PUT", "/api/connections/order", "/api/connections/order", entity.ReorderConnectionsRequest{IDs: []int64{target.ID, source.ID}})
+
+	conn := fmt.Sprintf("/api/connections/%d", source.ID)
+	group := "fixtures"
+	c.call("PUT", "/api/connections/{id}", conn, entity.UpdateConnectionRequest{
+		Name: "source", Type: "sqlite", Credentials: map[string]any{"path": sourcePath}, Group: &group,
+	})
+	c.call("POST", "/api/connections/{id}/select", conn+"/select", nil)
+	c.call("POST", "/api/connections/{id}/test", conn+"/test", nil)
+	c.call("POST", "/api/connections/{id}/trust", conn+"/trust", entity.TrustConnectionRequest{Trusted: true})
+
+	c.call("POST", "/api/connections/{id}/refresh", conn+"/refresh", nil)
+	c.call("GET", "/api/connections/{id}/metadata-ttl", conn+"/metadata-ttl", nil)
+	c.call("POST", "/api/connections/{id}/metadata-ttl", conn+"/metadata-ttl", metadataTTLBody{TTLSeconds: 600})
+	c.call("GET", "/api/connections/{id}/tables", conn+"/tables", nil)
+	c.call("GET", "/api/connections/{id}/tables/{name}", conn+"/tables/users?limit=2&page=1", nil)
+	c.call("GET", "/api/connections/{id}/tables/{name}/schema", conn+"/tables/orders/schema", nil)
+	c.call("GET", "/api/connections/{id}/tables/{name}/ddl", conn+"/tables/users/ddl", nil)
+	c.call("GET", "/api/connections/{id}/tables/{name}/columns/{col}/values", conn+"/tables/users/columns/name/values?search=a", nil)
+	c.call("GET", "/api/connections/{id}/erd", conn+"/erd", nil)
+
+	c.call("POST", "/api/connections/{id}/search/reindex", conn+"/search/reindex", nil)
+	c.call("GET", "/api/connections/{id}/search", conn+"/search?q=user", nil)
+	c.call("GET", "/api/search", "/api/search?q=email", nil)
+
+	c.call("POST", "/api/connections/{id}/query", conn+"/query", entity.QueryRequest{Query: "SELECT id, name, email FROM users"})
+	c.call("POST", "/api/connections/{id}/explain", conn+"/explain", entity.ExplainRequest{Query: "SELECT * FROM users WHERE name = 'ada'"})
+	c.call("GET", "/api/connections/{id}/completions", conn+"/completions?"+url.Values{"sql": {"SELECT  FROM users"}, "cursor": {"7"}}.Encode(), nil)
+	c.call("POST", "/api/connections/{id}/completions", conn+"/completions", entity.CompletionRequest{SQL: "SELECT * FROM ", Cursor: 14})
+	c.call("POST", "/api/connections/{id}/profile", conn+"/profile", entity.ProfileRequest{Table: "users"})
+	c.call("POST", "/api/compare", "/api/compare", entity.CompareRequest{
+		Left:       entity.CompareSide{ConnectionID: source.ID, Query: "SELECT id, name FROM users"},
+		Right:      entity.CompareSide{ConnectionID: target.ID, Query: "SELECT id, name FROM users"},
+		KeyColumns: []string{"id"},
+	})
+	c.call("POST", "/api/schema-diff", "/api/schema-diff", entity.SchemaDiffRequest{
+		SourceConnectionID: source.ID, TargetConnectionID: target.ID, GenerateScript: true,
+	})
+
+	c.call("GET", "/api/export/formats", "/api/export/formats", nil)
+	var job entity.Job
+	c.decode(c.call("POST", "/api/connections/{id}/export", conn+"/export", entity.ExportRequest{
+		Format: "csv", Path: filepath.Join(dir, "users.csv"), Table: "users",
+	}), &job)
+	c.waitForJob(job.ID)
+	c.call("POST", "/api/jobs/{jobId}/cancel", "/api/jobs/"+job.ID+"/cancel", nil)
+	c.call("GET", "/api/jobs", "/api/jobs", nil)
+
+	var upload entity.ImportUpload
+	c.decode(c.upload("users.csv", "name,email\nlin,lin@example.com\n"), &upload)
+	c.call("POST", "/api/connections/{id}/import/preview", conn+"/import/preview", entity.ImportPreviewRequest{Path: upload.Path})
+	c.decode(c.call("POST", "/api/connections/{id}/import", conn+"/import", entity.ImportRequest{
+		Path: upload.Path, Table: "imported", CreateTable: true,
+	}), &job)
+	c.waitForJob(job.ID)
+	c.decode(c.call("POST", "/api/connections/{id}/copy", conn+"/copy", entity.CopyRequest{
+		TargetConnectionID: target.ID, Table: "orders", TargetTable: "orders_copy",
+	}), &job)
+	c.waitForJob(job.ID)
+
+	c.call("GET", "/api/connections/{id}/queries", conn+"/queries", nil)
+	var saved entity.SavedQuery
+	c.decode(c.call("POST", "/api/connections/{id}/queries", conn+"/queries", savedQueryBody{Name: "all users", Query: "SELECT * FROM users"}), &saved)
+	query := fmt.Sprintf("%s/queries/%d", conn, saved.ID)
+	c.call("PUT", "/api/connections/{id}/queries/{queryId}", query, savedQueryBody{Name: "users", Query: "SELECT name FROM users"})
+	c.call("DELETE", "/api/connections/{id}/queries/{queryId}", query, nil)
+
+	c.call("GET", "/api/connections/{id}/tabs", conn+"/tabs", nil)
+	c.call("POST", "/api/connections/{id}/tabs", conn+"/tabs", tabsBody{Tabs: []entity.Tab{
+		{ID: "t1", Type: "table", Title: "users", ConnectionID: int(source.ID), TableName: "users"},
+	}})
+	c.call("GET", "/api/theme", "/api/theme", nil)
+	c.call("POST", "/api/theme", "/api/theme", themeBody{Theme: "dark"})
+	c.call("GET", "/api/layouts/{key}", "/api/layouts/editor", nil)
+	c.call("POST", "/api/layouts/{key}", "/api/layouts/editor", layoutBody{Layout: "[50,50]"})
+
+	pgpass := entity.ConnectionImportRequest{Source: entity.ConnectionSourcePgpass, Content: "db.example.com:5432:app:alice:secret\n"}
+	c.call("GET", "/api/connections/import/sources", "/api/connections/import/sources", nil)
+	c.call("POST", "/api/connections/import/preview", "/api/connections/import/preview", pgpass)
+	c.call("POST", "/api/connections/import", "/api/connections/import", pgpass)
+
+	c.call("DELETE", "/api/connections/{id}", fmt.Sprintf("/api/connections/%d", target.ID), nil)
+
+	for _, op := range apiOperations() {
+		key := operationKey(op.Method, op.Path)
+		if !c.covered[key] {
+			t.Errorf("%s is documented but not exercised by this test", key)
+		}
+	}
+}
+
+type contractClient struct {
+	t       *testing.T
+	spec    *OpenAPI
+	router  chi.Router
+	route   string
+	covered map[string]bool
+}
+
+func newContractClient(t *testing.T) *contractClient {
+	t.Helper()
+
+	configDB, err := repository.NewConfigDB()
+	if err != nil {
+		t.Fatalf("config database: %v", err)
+	}
+	t.Cleanup(func() { _ = configDB.Close() })
+	sqlDB := configDB.DB()
+
+	connectionRepo := repository.NewConnectionRepository(sqlDB)
+	savedQueryRepo := repository.NewSavedQueryRepository(sqlDB)
+	appStateRepo := repository.NewAppStateRepository(sqlDB)
+	schemaIndexRepo := repository.NewSchemaIndexRepository(sqlDB)
+
+	credentialResolver := secret.NewResolver()
+	factory := database.NewFactory(credentialResolver)
+	adapterCache := database.NewAdapterCache(credentialResolver)
+	t.Cleanup(adapterCache.Close)
+	metadataCache := database.NewMetadataCache()
+
+	connectionUsecase := usecase.NewConnectionUsecase(connectionRepo, factory, adapterCache, metadataCache, schemaIndexRepo, appStateRepo)
+	tableUsecase := usecase.NewTableUsecase(connectionRepo, adapterCache, metadataCache, appStateRepo)
+	savedQueryUsecase := usecase.NewSavedQueryUsecase(savedQueryRepo)
+	appStateUsecase := usecase.NewAppStateUsecase(appStateRepo)
+	jobUsecase := usecase.NewJobUsecase()
+
+	c := &contractClient{t: t, spec: NewOpenAPI("test"), router: chi.NewRouter(), covered: map[string]bool{}}
+	c.router.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r)
+			if rctx := chi.RouteContext(r.Context()); rctx != nil {
+				c.route = normalizeRoute(rctx.RoutePattern())
+			}
+		})
+	})
+	RegisterRoutes(c.router, Handlers{
+		OpenAPI:          c.spec,
+		Connections:      NewConnectionsHandler(connectionUsecase),
+		ConnectionImport: NewConnectionImportHandler(usecase.NewConnectionImportUsecase(connectionUsecase, connectionRepo, connimport.NewReader())),
+		Tables:           NewTablesHandler(tableUsecase),
+		Query:            NewQueryHandler(usecase.NewQueryUsecase(connectionRepo, adapterCache)),
+		SavedQueries:     NewSavedQueriesHandler(savedQueryUsecase),
+		Tabs:             NewTabsHandler(appStateUsecase),
+		Theme:            NewThemeHandler(appStateUsecase),
+		Layout:           NewLayoutHandler(appStateUsecase),
+		Adapters:         NewAdapterHandler(usecase.NewAdapterUsecase(factory)),
+		Search:           NewSearchHandler(usecase.NewSearchUsecase(schemaIndexRepo, connectionRepo, adapterCache, tableUsecase)),
+		Completion:       NewCompletionHandler(usecase.NewCompletionUsecase(connectionRepo, factory, tableUsecase)),
+		Export:           NewExportHandler(usecase.NewExportUsecase(connectionRepo, savedQueryRepo, adapterCache, export.NewFactory(), jobUsecase)),
+		Jobs:             NewJobsHandler(jobUsecase),
+		Import:           NewImportHandler(usecase.NewImportUsecase(connectionRepo, factory, adapterCache, metadataCache, importer.NewFactory(), jobUsecase)),
+		Copy:             NewCopyHandler(usecase.NewCopyUsecase(connectionRepo, factory, adapterCache, metadataCache, jobUsecase)),
+		Compare:          NewCompareHandler(usecase.NewCompareUsecase(connectionRepo, adapterCache)),
+		SchemaDiff:       NewSchemaDiffHandler(usecase.NewSchemaDiffUsecase(connectionRepo, factory, tableUsecase, database.NewMigrationGenerator())),
+		Profile:          NewProfileHandler(usecase.NewProfileUsecase(connectionRepo, adapterCache, tableUsecase, database.NewProfileQueryBuilder())),
+	})
+	return c
+}
+
+func (c *contractClient) call(method, route, target string, body any) []byte {
+	c.t.Helper()
+
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			c.t.Fatalf("%s %s: %v", method, target, err)
+		}
+		reader = bytes.NewReader(data)
+	}
+	return c.send(method, route, httptest.NewRequest(method, target, reader))
+}
+
+func (c *contractClient) upload(name, content string) []byte {
+	c.t.Helper()
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	part, err := form.CreateFormFile("file", name)
+	if err != nil {
+		c.t.Fatal(err)
+	}
+	_, _ = io.WriteString(part, content)
+	_ = form.Close()
+
+	req := httptest.NewRequest("POST", "/api/import/upload", &body)
+	req.Header.Set("Content-Type", form.FormDataContentType())
+	return c.send("POST", "/api/import/upload", req)
+}
+
+func (c *contractClient) send(method, route string, req *http.Request) []byte {
+	c.t.Helper()
+
+	c.route = ""
+	rec := httptest.NewRecorder()
+	c.router.ServeHTTP(rec, req)
+
+	key := operationKey(method, route)
+	if c.route != route {
+		c.t.Fatalf("%s: %s was routed to %q", key, req.URL, c.route)
+	}
+	op, ok := c.spec.operations[key]
+	if !ok {
+		c.t.Fatalf("%s is routed but not documented", key)
+	}
+	if rec.Code != op.Status {
+		c.t.Fatalf("%s: status %d, documented %d: %s", key, rec.Code, op.Status, rec.Body.String())
+	}
+	if err := c.spec.validateResponse(key, rec.Code, rec.Header().Get("Content-Type"), rec.Body.Bytes()); err != nil {
+		c.t.Errorf("%s: %v", key, err)
+	}
+	c.covered[key] = true
+	return rec.Body.Bytes()
+}
+
+func (c *contractClient) decode(body []byte, v any) {
+	c.t.Helper()
+	if err := json.Unmarshal(body, v); err != nil {
+		c.t.Fatalf("decode %s: %v", body, err)
+	}
+}
+
+func (c *contractClient) waitForJob(id string) {
+	c.t.Helper()
+
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		var job entity.Job
+		c.decode(c.call("GET", "/api/jobs/{jobId}", "/api/jobs/"+id, nil), &job)
+		if job.Status != entity.JobStatusRunning {
+			if job.Status != entity.JobStatusCompleted {
+				c.t.Fatalf("%s job %s: %s", job.Kind, job.Status, job.Error)
+			}
+			return
+		}
+		if time.Now().After(deadline) {
+			c.t.Fatalf("%s job did not finish", job.Kind)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func seedSQLite(t *testing.T, path string, statements ...string) {
+	t.Helper()
+
+	db, err := sql.Open("sqlite3", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = db.Close() }()
+	for _, stmt := range statements {
+		if _, err := db.Exec(stmt); err != nil {
+			t.Fatalf("%s: %v", stmt, err)
+		}
+	}
+}
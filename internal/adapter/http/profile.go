@@ -0,0 +1,51 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/3-lines-studio/datafrost/internal/core/entity"
+	"github.com/3-lines-studio/datafrost/internal/usecase"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type ProfileHandler struct {
+	uc *usecase.ProfileUsecase
+}
+
+func NewProfileHandler(uc *usecase.ProfileUsecase) *ProfileHandler {
+	return &ProfileHandler{uc: uc}
+}
+
+func (h *ProfileHandler) Profile(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		JSONError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	var req entity.ProfileRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		JSONError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	report, err := h.uc.Profile(r.Context(), id, req)
+	if err != nil {
+		if err == usecase.ErrConnectionNotFound {
+			JSONError(w, http.StatusNotFound, "connection not found")
+			return
+		}
+		if err == usecase.ErrProfileSourceInvalid || err == usecase.ErrColumnNotFound {
+			JSONError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		JSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	JSONResponse(w, http.StatusOK, report)
+}
@@ -0,0 +1,94 @@
+package http
+
+import (
+	"github.com/go-chi/chi/v5"
+)
+
+type Handlers struct {
+	OpenAPI          *OpenAPI
+	Connections      *ConnectionsHandler
+	ConnectionImport *ConnectionImportHandler
+	Tables           *TablesHandler
+	Query            *QueryHandler
+	SavedQueries     *SavedQueriesHandler
+	Tabs             *TabsHandler
+	Theme            *ThemeHandler
+	Layout           *LayoutHandler
+	Adapters         *AdapterHandler
+	Search           *SearchHandler
+	Completion       *CompletionHandler
+	Export           *ExportHandler
+	Jobs             *JobsHandler
+	Import           *ImportHandler
+	Copy             *CopyHandler
+	Compare          *CompareHandler
+	SchemaDiff       *SchemaDiffHandler
+	Profile          *ProfileHandler
+}
+
+func RegisterRoutes(router chi.Router, h Handlers) {
+	router.Route("/api", func(r chi.Router) {
+		r.Get("/openapi.json", h.OpenAPI.Spec)
+		r.Route("/connections", func(r chi.Router) {
+			r.Get("/", h.Connections.List)
+			r.Post("/", h.Connections.Create)
+			r.Route("/{id}", func(r chi.Router) {
+				r.Delete("/", h.Connections.Delete)
+				r.Put("/", h.Connections.Update)
+				r.Post("/select", h.Connections.SetLastConnected)
+				r.Post("/test", h.Connections.TestExisting)
+				r.Post("/trust", h.Connections.SetTrust)
+				r.Post("/refresh", h.Tables.Refresh)
+				r.Get("/metadata-ttl", h.Tables.GetMetadataTTL)
+				r.Post("/metadata-ttl", h.Tables.SetMetadataTTL)
+				r.Get("/tables", h.Tables.List)
+				r.Get("/tables/{name}", h.Tables.GetData)
+				r.Get("/tables/{name}/schema", h.Tables.GetSchema)
+				r.Get("/tables/{name}/ddl", h.Tables.GetDDL)
+				r.Get("/tables/{name}/columns/{col}/values", h.Tables.GetColumnValues)
+				r.Get("/erd", h.Tables.GetERD)
+				r.Get("/search", h.Search.Search)
+				r.Post("/search/reindex", h.Search.Reindex)
+				r.Post("/query", h.Query.Execute)
+				r.Post("/explain", h.Query.Explain)
+				r.Post("/export", h.Export.Start)
+				r.Post("/import/preview", h.Import.Preview)
+				r.Post("/import", h.Import.Start)
+				r.Post("/copy", h.Copy.Start)
+				r.Post("/profile", h.Profile.Profile)
+				r.Get("/completions", h.Completion.Get)
+				r.Post("/completions", h.Completion.Post)
+				r.Get("/tabs", h.Tabs.Get)
+				r.Post("/tabs", h.Tabs.Save)
+				r.Route("/queries", func(r chi.Router) {
+					r.Get("/", h.SavedQueries.List)
+					r.Post("/", h.SavedQueries.Create)
+					r.Route("/{queryId}", func(r chi.Router) {
+						r.Put("/", h.SavedQueries.Update)
+						r.Delete("/", h.SavedQueries.Delete)
+					})
+				})
+			})
+			r.Post("/test", h.Connections.Test)
+			r.Put("/order", h.Connections.Reorder)
+			r.Get("/import/sources", h.ConnectionImport.Sources)
+			r.Post("/import/preview", h.ConnectionImport.Preview)
+			r.Post("/import", h.ConnectionImport.Import)
+		})
+		r.Get("/search", h.Search.SearchAll)
+		r.Post("/compare", h.Compare.Compare)
+		r.Post("/schema-diff", h.SchemaDiff.Diff)
+		r.Get("/export/formats", h.Export.Formats)
+		r.Post("/import/upload", h.Import.Upload)
+		r.Route("/jobs", func(r chi.Router) {
+			r.Get("/", h.Jobs.List)
+			r.Get("/{jobId}", h.Jobs.Get)
+			r.Post("/{jobId}/cancel", h.Jobs.Cancel)
+		})
+		r.Get("/adapters", h.Adapters.List)
+		r.Get("/theme", h.Theme.Get)
+		r.Post("/theme", h.Theme.Update)
+		r.Get("/layouts/{key}", h.Layout.Get)
+		r.Post("/layouts/{key}", h.Layout.Save)
+	})
+}
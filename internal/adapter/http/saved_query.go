@@ -5,11 +5,21 @@ import (
 	"net/http"
 	"strconv"
 
+	"github.com/3-lines-studio/datafrost/internal/core/entity"
 	"github.com/3-lines-studio/datafrost/internal/usecase"
 
 	"github.com/go-chi/chi/v5"
 )
 
+type savedQueryBody struct {
+	Name  string `json:"name"`
+	Query string `json:"query"`
+}
+
+type savedQueryList struct {
+	Queries []entity.SavedQuery `json:"queries"`
+}
+
 type SavedQueriesHandler struct {
 	uc *usecase.SavedQueryUsecase
 }
@@ -32,7 +42,7 @@ func (h *SavedQueriesHandler) List(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	JSONResponse(w, http.StatusOK, map[string]any{"queries": queries})
+	JSONResponse(w, http.StatusOK, savedQueryList{Queries: queries})
 }
 
 func (h *SavedQueriesHandler) Create(w http.ResponseWriter, r *http.Request) {
@@ -43,10 +53,7 @@ func (h *SavedQueriesHandler) Create(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var req struct {
-		Name  string `json:"name"`
-		Query string `json:"query"`
-	}
+	var req savedQueryBody
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		JSONError(w, http.StatusBadRequest, "Invalid request body")
@@ -74,10 +81,7 @@ func (h *SavedQueriesHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var req struct {
-		Name  string `json:"name"`
-		Query string `json:"query"`
-	}
+	var req savedQueryBody
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		JSONError(w, http.StatusBadRequest, "Invalid request body")
@@ -0,0 +1,41 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/3-lines-studio/datafrost/internal/core/entity"
+	"github.com/3-lines-studio/datafrost/internal/usecase"
+)
+
+type SchemaDiffHandler struct {
+	uc *usecase.SchemaDiffUsecase
+}
+
+func NewSchemaDiffHandler(uc *usecase.SchemaDiffUsecase) *SchemaDiffHandler {
+	return &SchemaDiffHandler{uc: uc}
+}
+
+func (h *SchemaDiffHandler) Diff(w http.ResponseWriter, r *http.Request) {
+	var req entity.SchemaDiffRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		JSONError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	diff, err := h.uc.Diff(req)
+	if err != nil {
+		if err == usecase.ErrConnectionNotFound {
+			JSONError(w, http.StatusNotFound, "connection not found")
+			return
+		}
+		if err == usecase.ErrInvalidPattern {
+			JSONError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		JSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	JSONResponse(w, http.StatusOK, diff)
+}
@@ -0,0 +1,86 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/3-lines-studio/datafrost/internal/usecase"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type SearchHandler struct {
+	uc *usecase.SearchUsecase
+}
+
+func NewSearchHandler(uc *usecase.SearchUsecase) *SearchHandler {
+	return &SearchHandler{uc: uc}
+}
+
+func (h *SearchHandler) Search(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		JSONError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+	response, err := h.uc.Search(id, r.URL.Query().Get("q"), limit)
+	if err != nil {
+		if err == usecase.ErrConnectionNotFound {
+			JSONError(w, http.StatusNotFound, "connection not found")
+			return
+		}
+		if err == usecase.ErrQueryRequired {
+			JSONError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		JSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	JSONResponse(w, http.StatusOK, response)
+}
+
+func (h *SearchHandler) SearchAll(w http.ResponseWriter, r *http.Request) {
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+	response, err := h.uc.SearchAll(r.URL.Query().Get("q"), limit)
+	if err != nil {
+		if err == usecase.ErrQueryRequired {
+			JSONError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		JSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	JSONResponse(w, http.StatusOK, response)
+}
+
+func (h *SearchHandler) Reindex(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		JSONError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	status, err := h.uc.Reindex(id)
+	if err != nil {
+		if err == usecase.ErrConnectionNotFound {
+			JSONError(w, http.StatusNotFound, "connection not found")
+			return
+		}
+		if err == usecase.ErrIndexInProgress {
+			JSONError(w, http.StatusConflict, err.Error())
+			return
+		}
+		JSONError(w, http.StatusBadRequest, "failed to index: "+err.Error())
+		return
+	}
+
+	JSONResponse(w, http.StatusOK, status)
+}
@@ -11,6 +11,10 @@ import (
 	"github.com/go-chi/chi/v5"
 )
 
+type tabsBody struct {
+	Tabs []entity.Tab `json:"tabs"`
+}
+
 type TabsHandler struct {
 	uc *usecase.AppStateUsecase
 }
@@ -33,7 +37,7 @@ func (h *TabsHandler) Get(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	JSONResponse(w, http.StatusOK, map[string]any{"tabs": tabs})
+	JSONResponse(w, http.StatusOK, tabsBody{Tabs: tabs})
 }
 
 func (h *TabsHandler) Save(w http.ResponseWriter, r *http.Request) {
@@ -44,9 +48,7 @@ func (h *TabsHandler) Save(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	var req struct {
-		Tabs []entity.Tab `json:"tabs"`
-	}
+	var req tabsBody
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		JSONError(w, http.StatusBadRequest, "Invalid request body")
@@ -58,5 +60,5 @@ func (h *TabsHandler) Save(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	JSONResponse(w, http.StatusOK, map[string]any{"tabs": req.Tabs})
+	JSONResponse(w, http.StatusOK, req)
 }
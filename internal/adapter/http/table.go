@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/3-lines-studio/datafrost/internal/core/entity"
 	"github.com/3-lines-studio/datafrost/internal/usecase"
@@ -11,6 +12,10 @@ import (
 	"github.com/go-chi/chi/v5"
 )
 
+type metadataTTLBody struct {
+	TTLSeconds int64 `json:"ttl_seconds"`
+}
+
 type TablesHandler struct {
 	uc *usecase.TableUsecase
 }
@@ -124,6 +129,170 @@ func (h *TablesHandler) GetSchema(w http.ResponseWriter, r *http.Request) {
 	JSONResponse(w, http.StatusOK, schema)
 }
 
+func (h *TablesHandler) GetColumnValues(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		JSONError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	tableName := chi.URLParam(r, "name")
+	column := chi.URLParam(r, "col")
+	if tableName == "" || column == "" {
+		JSONError(w, http.StatusBadRequest, "table and column names are required")
+		return
+	}
+
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+	var filters []entity.Filter
+	filtersStr := r.URL.Query().Get("filters")
+	if filtersStr != "" {
+		if err := json.Unmarshal([]byte(filtersStr), &filters); err != nil {
+			JSONError(w, http.StatusBadRequest, "invalid filters parameter")
+			return
+		}
+	}
+
+	values, err := h.uc.GetColumnValues(id, tableName, column, r.URL.Query().Get("search"), filters, page, limit)
+	if err != nil {
+		if err == usecase.ErrConnectionNotFound {
+			JSONError(w, http.StatusNotFound, "connection not found")
+			return
+		}
+		if err == usecase.ErrColumnNotFound {
+			JSONError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		JSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	JSONResponse(w, http.StatusOK, values)
+}
+
+func (h *TablesHandler) GetDDL(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		JSONError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	tableName := chi.URLParam(r, "name")
+	if tableName == "" {
+		JSONError(w, http.StatusBadRequest, "table name is required")
+		return
+	}
+
+	ddl, err := h.uc.GetTableDDL(id, tableName)
+	if err != nil {
+		if err == usecase.ErrConnectionNotFound {
+			JSONError(w, http.StatusNotFound, "connection not found")
+			return
+		}
+		if err == usecase.ErrTableNotFound {
+			JSONError(w, http.StatusNotFound, err.Error())
+			return
+		}
+		JSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	JSONResponse(w, http.StatusOK, ddl)
+}
+
+func (h *TablesHandler) GetERD(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		JSONError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	filter := entity.ERDFilter{
+		Schema:       r.URL.Query().Get("schema"),
+		TablePattern: r.URL.Query().Get("tables"),
+	}
+
+	diagram, err := h.uc.GetERDiagram(id, filter)
+	if err != nil {
+		if err == usecase.ErrConnectionNotFound {
+			JSONError(w, http.StatusNotFound, "connection not found")
+			return
+		}
+		if err == usecase.ErrInvalidPattern {
+			JSONError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		JSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	JSONResponse(w, http.StatusOK, diagram)
+}
+
+func (h *TablesHandler) Refresh(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		JSONError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	tables, err := h.uc.RefreshMetadata(id)
+	if err != nil {
+		if err == usecase.ErrConnectionNotFound {
+			JSONError(w, http.StatusNotFound, "connection not found")
+			return
+		}
+		JSONError(w, http.StatusBadRequest, "failed to connect: "+err.Error())
+		return
+	}
+
+	JSONResponse(w, http.StatusOK, tables)
+}
+
+func (h *TablesHandler) GetMetadataTTL(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		JSONError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	ttl := h.uc.GetMetadataTTL(id)
+	JSONResponse(w, http.StatusOK, metadataTTLBody{TTLSeconds: int64(ttl / time.Second)})
+}
+
+func (h *TablesHandler) SetMetadataTTL(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		JSONError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	var req metadataTTLBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		JSONError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	if err := h.uc.SetMetadataTTL(id, time.Duration(req.TTLSeconds)*time.Second); err != nil {
+		if err == usecase.ErrInvalidRequest {
+			JSONError(w, http.StatusBadRequest, "ttl_seconds must not be negative")
+			return
+		}
+		JSONError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	JSONResponse(w, http.StatusOK, req)
+}
+
 type QueryHandler struct {
 	uc *usecase.QueryUsecase
 }
@@ -162,3 +331,34 @@ func (h *QueryHandler) Execute(w http.ResponseWriter, r *http.Request) {
 
 	JSONResponse(w, http.StatusOK, result)
 }
+
+func (h *QueryHandler) Explain(w http.ResponseWriter, r *http.Request) {
+	idStr := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idStr, 10, 64)
+	if err != nil {
+		JSONError(w, http.StatusBadRequest, "invalid id")
+		return
+	}
+
+	var req entity.ExplainRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		JSONError(w, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	plan, err := h.uc.Explain(id, req)
+	if err != nil {
+		if err == usecase.ErrConnectionNotFound {
+			JSONError(w, http.StatusNotFound, "connection not found")
+			return
+		}
+		if err == usecase.ErrAnalyzeNotConfirmed {
+			JSONError(w, http.StatusPreconditionRequired, err.Error())
+			return
+		}
+		JSONError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	JSONResponse(w, http.StatusOK, plan)
+}
@@ -7,6 +7,10 @@ import (
 	"github.com/3-lines-studio/datafrost/internal/usecase"
 )
 
+type themeBody struct {
+	Theme string `json:"theme"`
+}
+
 type ThemeHandler struct {
 	uc *usecase.AppStateUsecase
 }
@@ -22,13 +26,11 @@ func (h *ThemeHandler) Get(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	JSONResponse(w, http.StatusOK, map[string]string{"theme": theme})
+	JSONResponse(w, http.StatusOK, themeBody{Theme: theme})
 }
 
 func (h *ThemeHandler) Update(w http.ResponseWriter, r *http.Request) {
-	var req struct {
-		Theme string `json:"theme"`
-	}
+	var req themeBody
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		JSONError(w, http.StatusBadRequest, "Invalid request body")
@@ -45,5 +47,5 @@ func (h *ThemeHandler) Update(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	JSONResponse(w, http.StatusOK, map[string]string{"theme": req.Theme})
+	JSONResponse(w, http.StatusOK, req)
 }
@@ -0,0 +1,113 @@
+package importer
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"unicode/utf8"
+
+	"github.com/3-lines-studio/datafrost/internal/core/entity"
+)
+
+type csvReader struct {
+	file    *os.File
+	reader  *csv.Reader
+	format  string
+	columns []string
+	pending []string
+}
+
+func newCSVReader(file *os.File, buf *bufio.Reader, format string, options entity.ImportOptions) (*csvReader, error) {
+	reader := csv.NewReader(buf)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	reader.ReuseRecord = false
+
+	delimiter := options.Delimiter
+	if delimiter == `\t` || (delimiter == "" && format == "tsv") {
+		delimiter = "\t"
+	}
+	if delimiter != "" {
+		r, size := utf8.DecodeRuneInString(delimiter)
+		if size != len(delimiter) {
+			return nil, fmt.Errorf("invalid delimiter: %q", options.Delimiter)
+		}
+		reader.Comma = r
+	}
+
+	first, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("import file is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", format, err)
+	}
+
+	c := &csvReader{file: file, reader: reader, format: format}
+	if options.NoHeader {
+		c.columns = make([]string, len(first))
+		for i := range first {
+			c.columns[i] = fmt.Sprintf("column_%d", i+1)
+		}
+		c.pending = first
+	} else {
+		c.columns = uniqueColumnNames(first)
+	}
+	return c, nil
+}
+
+func (c *csvReader) Format() string {
+	return c.format
+}
+
+func (c *csvReader) Columns() []string {
+	return c.columns
+}
+
+func (c *csvReader) Next() ([]any, error) {
+	record := c.pending
+	c.pending = nil
+	if record == nil {
+		var err error
+		record, err = c.reader.Read()
+		if err == io.EOF {
+			return nil, io.EOF
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", c.format, err)
+		}
+	}
+
+	row := make([]any, len(c.columns))
+	for i := range row {
+		if i < len(record) {
+			row[i] = record[i]
+		} else {
+			row[i] = ""
+		}
+	}
+	return row, nil
+}
+
+func (c *csvReader) Close() error {
+	return c.file.Close()
+}
+
+func uniqueColumnNames(names []string) []string {
+	seen := map[string]int{}
+	columns := make([]string, len(names))
+	for i, name := range names {
+		if name == "" {
+			name = fmt.Sprintf("column_%d", i+1)
+		}
+		if n := seen[name]; n > 0 {
+			columns[i] = fmt.Sprintf("%s_%d", name, n+1)
+		} else {
+			columns[i] = name
+		}
+		seen[name]++
+	}
+	return columns
+}
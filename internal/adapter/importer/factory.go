@@ -0,0 +1,63 @@
+package importer
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/3-lines-studio/datafrost/internal/core/entity"
+	"github.com/3-lines-studio/datafrost/internal/usecase/port"
+)
+
+type Factory struct{}
+
+func NewFactory() *Factory {
+	return &Factory{}
+}
+
+func (f *Factory) Open(path string, options entity.ImportOptions) (port.ImportReader, error) {
+	format := options.Format
+	if format == "" {
+		format = detectFormat(path)
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open import file: %w", err)
+	}
+
+	buf := bufio.NewReaderSize(file, 256*1024)
+	if bom, err := buf.Peek(3); err == nil && string(bom) == "\xef\xbb\xbf" {
+		_, _ = buf.Discard(3)
+	}
+
+	var reader port.ImportReader
+	switch format {
+	case "csv", "tsv":
+		reader, err = newCSVReader(file, buf, format, options)
+	case "ndjson", "json":
+		reader, err = newJSONReader(file, buf, format)
+	default:
+		err = fmt.Errorf("unsupported import format: %s", format)
+	}
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	return reader, nil
+}
+
+func detectFormat(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".tsv", ".tab":
+		return "tsv"
+	case ".ndjson", ".jsonl":
+		return "ndjson"
+	case ".json":
+		return "json"
+	default:
+		return "csv"
+	}
+}
@@ -0,0 +1,160 @@
+package importer
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+)
+
+const jsonColumnSample = 1000
+
+type jsonReader struct {
+	file     *os.File
+	decoder  *json.Decoder
+	format   string
+	array    bool
+	columns  []string
+	buffered []map[string]any
+}
+
+type jsonObject struct {
+	keys   []string
+	values map[string]any
+}
+
+func newJSONReader(file *os.File, buf *bufio.Reader, format string) (*jsonReader, error) {
+	decoder := json.NewDecoder(buf)
+	decoder.UseNumber()
+
+	j := &jsonReader{file: file, decoder: decoder, format: format}
+
+	if format == "json" {
+		tok, err := decoder.Token()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read json: %w", err)
+		}
+		if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+			return nil, fmt.Errorf("json import expects an array of objects")
+		}
+		j.array = true
+	}
+
+	seen := map[string]bool{}
+	for len(j.buffered) < jsonColumnSample {
+		obj, err := j.readObject()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		j.buffered = append(j.buffered, obj.values)
+		for _, key := range obj.keys {
+			if !seen[key] {
+				seen[key] = true
+				j.columns = append(j.columns, key)
+			}
+		}
+	}
+
+	if len(j.columns) == 0 {
+		return nil, fmt.Errorf("import file has no objects")
+	}
+	return j, nil
+}
+
+func (j *jsonReader) Format() string {
+	return j.format
+}
+
+func (j *jsonReader) Columns() []string {
+	return j.columns
+}
+
+func (j *jsonReader) Next() ([]any, error) {
+	var obj map[string]any
+	if len(j.buffered) > 0 {
+		obj = j.buffered[0]
+		j.buffered = j.buffered[1:]
+	} else {
+		next, err := j.readObject()
+		if err != nil {
+			return nil, err
+		}
+		obj = next.values
+	}
+
+	row := make([]any, len(j.columns))
+	for i, col := range j.columns {
+		row[i] = jsonImportValue(obj[col])
+	}
+	return row, nil
+}
+
+func (j *jsonReader) Close() error {
+	return j.file.Close()
+}
+
+func (j *jsonReader) readObject() (jsonObject, error) {
+	obj := jsonObject{values: map[string]any{}}
+	if j.array && !j.decoder.More() {
+		return obj, io.EOF
+	}
+
+	tok, err := j.decoder.Token()
+	if err == io.EOF {
+		return obj, io.EOF
+	}
+	if err != nil {
+		return obj, fmt.Errorf("failed to read %s: %w", j.format, err)
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return obj, fmt.Errorf("failed to read %s: expected an object, got %v", j.format, tok)
+	}
+
+	for j.decoder.More() {
+		tok, err := j.decoder.Token()
+		if err != nil {
+			return obj, fmt.Errorf("failed to read %s: %w", j.format, err)
+		}
+		key, _ := tok.(string)
+
+		var val any
+		if err := j.decoder.Decode(&val); err != nil {
+			return obj, fmt.Errorf("failed to read %s: %w", j.format, err)
+		}
+		if _, ok := obj.values[key]; !ok {
+			obj.keys = append(obj.keys, key)
+		}
+		obj.values[key] = val
+	}
+
+	if _, err := j.decoder.Token(); err != nil {
+		return obj, fmt.Errorf("failed to read %s: %w", j.format, err)
+	}
+	return obj, nil
+}
+
+func jsonImportValue(val any) any {
+	switch v := val.(type) {
+	case nil, string, bool:
+		return v
+	case json.Number:
+		if n, err := strconv.ParseInt(v.String(), 10, 64); err == nil {
+			return n
+		}
+		if f, err := v.Float64(); err == nil {
+			return f
+		}
+		return v.String()
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprintf("%v", v)
+		}
+		return string(data)
+	}
+}
@@ -0,0 +1,273 @@
+package mcp
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/3-lines-studio/datafrost/internal/usecase"
+	"github.com/3-lines-studio/datafrost/internal/usecase/port"
+)
+
+const (
+	latestProtocolVersion = "2025-06-18"
+	defaultMaxRows        = 200
+	defaultQueryTimeout   = time.Minute
+)
+
+var supportedProtocolVersions = []string{"2024-11-05", "2025-03-26", latestProtocolVersion}
+
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+)
+
+type Server struct {
+	connections *usecase.ConnectionUsecase
+	tables      *usecase.TableUsecase
+	queries     *usecase.QueryUsecase
+	writers     port.ExportWriterFactory
+	version     string
+	stdin       io.Reader
+	stdout      io.Writer
+	stderr      io.Writer
+
+	maxRows int
+	timeout time.Duration
+	allowed map[int64]bool
+
+	writeMu  sync.Mutex
+	callsMu  sync.Mutex
+	inflight map[string]context.CancelFunc
+}
+
+func New(
+	connections *usecase.ConnectionUsecase,
+	tables *usecase.TableUsecase,
+	queries *usecase.QueryUsecase,
+	writers port.ExportWriterFactory,
+	version string,
+	stdin io.Reader,
+	stdout, stderr io.Writer,
+) *Server {
+	return &Server{
+		connections: connections,
+		tables:      tables,
+		queries:     queries,
+		writers:     writers,
+		version:     version,
+		stdin:       stdin,
+		stdout:      stdout,
+		stderr:      stderr,
+		inflight:    make(map[string]context.CancelFunc),
+	}
+}
+
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+type connectionList []string
+
+func (l *connectionList) String() string { return strings.Join(*l, ",") }
+
+func (l *connectionList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+func (s *Server) Run(args []string) int {
+	fs := flag.NewFlagSet("mcp", flag.ContinueOnError)
+	fs.SetOutput(s.stderr)
+	var only connectionList
+	fs.Var(&only, "conn", "Only expose this connection name or ID (repeatable; default all)")
+	maxRows := fs.Int("max-rows", defaultMaxRows, "Maximum rows returned by run_query")
+	timeout := fs.Duration("timeout", defaultQueryTimeout, "Cancel queries after this long")
+	fs.Usage = func() {
+		fmt.Fprintln(s.stderr, "Usage: datafrost mcp [--conn <name>]... [--max-rows N] [--timeout 1m]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	if fs.NArg() > 0 || *maxRows <= 0 || *timeout <= 0 {
+		fs.Usage()
+		return 2
+	}
+	s.maxRows = *maxRows
+	s.timeout = *timeout
+
+	if len(only) > 0 {
+		s.allowed = make(map[int64]bool, len(only))
+		for _, name := range only {
+			conn, err := s.connections.FindByName(name)
+			if err != nil {
+				fmt.Fprintf(s.stderr, "datafrost: %s: %v\n", name, err)
+				if errors.Is(err, usecase.ErrConnectionNotFound) {
+					return 3
+				}
+				return 1
+			}
+			s.allowed[conn.ID] = true
+		}
+	}
+
+	var wg sync.WaitGroup
+	reader := bufio.NewReader(s.stdin)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(strings.TrimSpace(string(line))) > 0 {
+			s.handleLine(line, &wg)
+		}
+		if err != nil {
+			if err != io.EOF {
+				fmt.Fprintf(s.stderr, "datafrost: failed to read stdin: %v\n", err)
+			}
+			break
+		}
+	}
+
+	// Calls still running when the client closes stdin are answered before
+	// exiting; each is bounded by the query timeout.
+	wg.Wait()
+	return 0
+}
+
+func (s *Server) handleLine(line []byte, wg *sync.WaitGroup) {
+	var req request
+	if err := json.Unmarshal(line, &req); err != nil {
+		code := codeParseError
+		if strings.HasPrefix(strings.TrimSpace(string(line)), "[") {
+			code = codeInvalidRequest
+		}
+		s.writeError(json.RawMessage("null"), code, "invalid JSON-RPC message")
+		return
+	}
+
+	if len(req.ID) == 0 {
+		s.handleNotification(req)
+		return
+	}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		s.writeError(req.ID, codeInvalidRequest, "invalid JSON-RPC request")
+		return
+	}
+
+	switch req.Method {
+	case "initialize":
+		s.initialize(req)
+	case "ping":
+		s.writeResult(req.ID, struct{}{})
+	case "tools/list":
+		s.writeResult(req.ID, map[string]any{"tools": toolDefinitions()})
+	case "tools/call":
+		ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+		key := string(req.ID)
+		s.callsMu.Lock()
+		s.inflight[key] = cancel
+		s.callsMu.Unlock()
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() {
+				s.callsMu.Lock()
+				delete(s.inflight, key)
+				s.callsMu.Unlock()
+				cancel()
+			}()
+			s.callTool(ctx, req)
+		}()
+	default:
+		s.writeError(req.ID, codeMethodNotFound, "method not found: "+req.Method)
+	}
+}
+
+func (s *Server) handleNotification(req request) {
+	if req.Method != "notifications/cancelled" {
+		return
+	}
+	var params struct {
+		RequestID json.RawMessage `json:"requestId"`
+	}
+	if err := json.Unmarshal(req.Params, &params); err != nil {
+		return
+	}
+	s.callsMu.Lock()
+	if cancel, ok := s.inflight[string(params.RequestID)]; ok {
+		cancel()
+	}
+	s.callsMu.Unlock()
+}
+
+func (s *Server) initialize(req request) {
+	var params struct {
+		ProtocolVersion string `json:"protocolVersion"`
+	}
+	_ = json.Unmarshal(req.Params, &params)
+
+	version := latestProtocolVersion
+	for _, supported := range supportedProtocolVersions {
+		if params.ProtocolVersion == supported {
+			version = supported
+		}
+	}
+
+	s.writeResult(req.ID, map[string]any{
+		"protocolVersion": version,
+		"capabilities": map[string]any{
+			"tools": map[string]any{"listChanged": false},
+		},
+		"serverInfo": map[string]any{
+			"name":    "datafrost",
+			"version": s.version,
+		},
+		"instructions": fmt.Sprintf("Read-only access to the databases saved in Datafrost. Call list_connections first; "+
+			"run_query accepts a single SELECT or WITH statement (plus read-only PRAGMAs on SQLite/Turso) and returns at most %d rows.", s.maxRows),
+	})
+}
+
+func (s *Server) writeResult(id json.RawMessage, result any) {
+	s.write(response{JSONRPC: "2.0", ID: id, Result: result})
+}
+
+func (s *Server) writeError(id json.RawMessage, code int, message string) {
+	s.write(response{JSONRPC: "2.0", ID: id, Error: &rpcError{Code: code, Message: message}})
+}
+
+func (s *Server) write(resp response) {
+	data, err := json.Marshal(resp)
+	if err != nil {
+		data, _ = json.Marshal(response{JSONRPC: "2.0", ID: resp.ID, Error: &rpcError{Code: -32603, Message: err.Error()}})
+	}
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	_, _ = s.stdout.Write(append(data, '\n'))
+}
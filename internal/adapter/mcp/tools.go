@@ -0,0 +1,278 @@
+package mcp
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/3-lines-studio/datafrost/internal/core/entity"
+	"github.com/3-lines-studio/datafrost/internal/usecase"
+	"github.com/3-lines-studio/datafrost/internal/usecase/port"
+)
+
+var errRowCap = errors.New("row cap reached")
+
+type tool struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	InputSchema map[string]any `json:"inputSchema"`
+	Annotations map[string]any `json:"annotations"`
+}
+
+func toolDefinitions() []tool {
+	readOnly := map[string]any{"readOnlyHint": true, "openWorldHint": false}
+	connection := map[string]any{"type": "string", "description": "Connection name or ID from list_connections"}
+	return []tool{
+		{
+			Name:        "list_connections",
+			Description: "List the database connections saved in Datafrost (name, ID, database type, and environment, group and tags when set).",
+			InputSchema: map[string]any{"type": "object", "properties": map[string]any{}},
+			Annotations: readOnly,
+		},
+		{
+			Name:        "list_tables",
+			Description: "List tables and views of a connection.",
+			InputSchema: map[string]any{
+				"type":       "object",
+				"properties": map[string]any{"connection": connection},
+				"required":   []string{"connection"},
+			},
+			Annotations: readOnly,
+		},
+		{
+			Name:        "get_table_schema",
+			Description: "Columns, indexes, constraints and foreign keys of a table.",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"connection": connection,
+					"table":      map[string]any{"type": "string", "description": "Table name as returned by list_tables"},
+				},
+				"required": []string{"connection", "table"},
+			},
+			Annotations: readOnly,
+		},
+		{
+			Name:        "run_query",
+			Description: "Run a single read-only SQL statement (SELECT or WITH; read-only PRAGMAs on SQLite/Turso). Results are capped; truncated is true when more rows were available.",
+			InputSchema: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"connection": connection,
+					"sql":        map[string]any{"type": "string", "description": "SQL query to run"},
+					"max_rows":   map[string]any{"type": "integer", "minimum": 1, "description": "Maximum rows to return; cannot exceed the server limit"},
+				},
+				"required": []string{"connection", "sql"},
+			},
+			Annotations: readOnly,
+		},
+	}
+}
+
+type toolArguments struct {
+	Connection string `json:"connection"`
+	Table      string `json:"table"`
+	SQL        string `json:"sql"`
+	MaxRows    int    `json:"max_rows"`
+}
+
+func (s *Server) callTool(ctx context.Context, req request) {
+	var params struct {
+		Name      string          `json:"name"`
+		Arguments json.RawMessage `json:"arguments"`
+	}
+	if err := json.Unmarshal(req.Params, &params); err != nil {
+		s.writeError(req.ID, codeInvalidParams, "invalid tools/call params")
+		return
+	}
+	var args toolArguments
+	if len(params.Arguments) > 0 && string(params.Arguments) != "null" {
+		if err := json.Unmarshal(params.Arguments, &args); err != nil {
+			s.writeError(req.ID, codeInvalidParams, "invalid arguments: "+err.Error())
+			return
+		}
+	}
+
+	var result any
+	var err error
+	switch params.Name {
+	case "list_connections":
+		result, err = s.listConnections()
+	case "list_tables":
+		result, err = s.listTables(args)
+	case "get_table_schema":
+		result, err = s.getTableSchema(args)
+	case "run_query":
+		result, err = s.runQuery(ctx, args)
+	default:
+		s.writeError(req.ID, codeInvalidParams, "unknown tool: "+params.Name)
+		return
+	}
+
+	// Tool failures are reported in the result so the model can read and
+	// correct them; protocol errors are reserved for malformed calls.
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			err = fmt.Errorf("query cancelled after %s", s.timeout)
+		}
+		s.writeResult(req.ID, map[string]any{
+			"content": []map[string]any{{"type": "text", "text": err.Error()}},
+			"isError": true,
+		})
+		return
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		s.writeError(req.ID, -32603, err.Error())
+		return
+	}
+	s.writeResult(req.ID, map[string]any{
+		"content": []map[string]any{{"type": "text", "text": string(data)}},
+	})
+}
+
+type connectionSummary struct {
+	ID          int64    `json:"id"`
+	Name        string   `json:"name"`
+	Type        string   `json:"type"`
+	Environment string   `json:"environment,omitempty"`
+	Group       string   `json:"group,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+}
+
+func (s *Server) listConnections() (any, error) {
+	connections, _, err := s.connections.List()
+	if err != nil {
+		return nil, err
+	}
+	out := make([]connectionSummary, 0, len(connections))
+	for _, conn := range connections {
+		if s.allowed != nil && !s.allowed[conn.ID] {
+			continue
+		}
+		out = append(out, connectionSummary{
+			ID:          conn.ID,
+			Name:        conn.Name,
+			Type:        conn.Type,
+			Environment: conn.Environment,
+			Group:       conn.Group,
+			Tags:        conn.Tags,
+		})
+	}
+	return map[string]any{"connections": out}, nil
+}
+
+func (s *Server) resolve(name string) (*entity.Connection, error) {
+	if strings.TrimSpace(name) == "" {
+		return nil, errors.New("connection is required")
+	}
+	conn, err := s.connections.FindByName(name)
+	if err != nil {
+		return nil, err
+	}
+	if s.allowed != nil && !s.allowed[conn.ID] {
+		return nil, usecase.ErrConnectionNotFound
+	}
+	return conn, nil
+}
+
+func (s *Server) listTables(args toolArguments) (any, error) {
+	conn, err := s.resolve(args.Connection)
+	if err != nil {
+		return nil, err
+	}
+	tables, err := s.tables.ListTables(conn.ID)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"connection": conn.Name, "tables": tables}, nil
+}
+
+func (s *Server) getTableSchema(args toolArguments) (any, error) {
+	conn, err := s.resolve(args.Connection)
+	if err != nil {
+		return nil, err
+	}
+	if args.Table == "" {
+		return nil, usecase.ErrTableRequired
+	}
+	return s.tables.GetTableSchema(conn.ID, args.Table)
+}
+
+type cappedSink struct {
+	port.ExportWriter
+	limit     int
+	rows      int
+	columns   []entity.ResultColumn
+	truncated bool
+}
+
+func (c *cappedSink) WriteHeader(columns []entity.ResultColumn) error {
+	c.columns = columns
+	return c.ExportWriter.WriteHeader(columns)
+}
+
+func (c *cappedSink) WriteRow(values []any) error {
+	if c.rows >= c.limit {
+		c.truncated = true
+		return errRowCap
+	}
+	c.rows++
+	return c.ExportWriter.WriteRow(values)
+}
+
+type queryColumn struct {
+	Name string `json:"name"`
+	Type string `json:"type,omitempty"`
+}
+
+type queryResult struct {
+	Columns   []queryColumn   `json:"columns"`
+	Rows      json.RawMessage `json:"rows"`
+	RowCount  int             `json:"row_count"`
+	Truncated bool            `json:"truncated"`
+}
+
+func (s *Server) runQuery(ctx context.Context, args toolArguments) (any, error) {
+	conn, err := s.resolve(args.Connection)
+	if err != nil {
+		return nil, err
+	}
+	limit := s.maxRows
+	if args.MaxRows > 0 && args.MaxRows < limit {
+		limit = args.MaxRows
+	}
+
+	var buf bytes.Buffer
+	writer, err := s.writers.CreateStream(&buf, entity.ExportTarget{Format: "json", SourceType: conn.Type})
+	if err != nil {
+		return nil, err
+	}
+	sink := &cappedSink{ExportWriter: writer, limit: limit}
+
+	// The row cap stops the stream early; cancelling lets the adapter abandon
+	// the rest of the result instead of draining it.
+	queryCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	if err := s.queries.Stream(queryCtx, conn.ID, args.SQL, sink); err != nil && !errors.Is(err, errRowCap) {
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	columns := make([]queryColumn, len(sink.columns))
+	for i, col := range sink.columns {
+		columns[i] = queryColumn{Name: col.Name, Type: col.DatabaseType}
+	}
+	return queryResult{
+		Columns:   columns,
+		Rows:      json.RawMessage(buf.Bytes()),
+		RowCount:  sink.rows,
+		Truncated: sink.truncated,
+	}, nil
+}
@@ -26,3 +26,26 @@ func (r *AppStateRepository) Set(key, value string) error {
 	)
 	return err
 }
+
+func (r *AppStateRepository) Delete(key string) error {
+	_, err := r.db.Exec("DELETE FROM app_state WHERE key = ?", key)
+	return err
+}
+
+func (r *AppStateRepository) List(prefix string) (map[string]string, error) {
+	rows, err := r.db.Query("SELECT key, value FROM app_state WHERE substr(key, 1, ?) = ?", len(prefix), prefix)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	values := make(map[string]string)
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, err
+		}
+		values[key] = value
+	}
+	return values, rows.Err()
+}
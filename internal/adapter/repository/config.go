@@ -10,7 +10,8 @@ import (
 )
 
 type ConfigDB struct {
-	db *sql.DB
+	db   *sql.DB
+	path string
 }
 
 func DBPath() string {
@@ -34,7 +35,9 @@ func NewConfigDB() (*ConfigDB, error) {
 	}
 
 	dbPath := filepath.Join(appDir, "config.db")
-	db, err := sql.Open("sqlite3", dbPath)
+	// Transactions take the write lock at BEGIN (BEGIN IMMEDIATE), so a
+	// migration's version check cannot race another process's migration.
+	db, err := sql.Open("sqlite3", dbPath+"?_txlock=immediate")
 	if err != nil {
 		return nil, fmt.Errorf("failed to open config database: %w", err)
 	}
@@ -43,8 +46,9 @@ func NewConfigDB() (*ConfigDB, error) {
 		return nil, fmt.Errorf("failed to ping config database: %w", err)
 	}
 
-	configDB := &ConfigDB{db: db}
+	configDB := &ConfigDB{db: db, path: dbPath}
 	if err := configDB.migrate(); err != nil {
+		_ = db.Close()
 		return nil, fmt.Errorf("failed to run migrations: %w", err)
 	}
 
@@ -55,39 +59,6 @@ func (c *ConfigDB) Close() error {
 	return c.db.Close()
 }
 
-func (c *ConfigDB) migrate() error {
-	migrations := []string{
-		`CREATE TABLE IF NOT EXISTS connections (
-			id INTEGER PRIMARY KEY AUTOINCREMENT,
-			name TEXT NOT NULL UNIQUE,
-			type TEXT NOT NULL,
-			credentials TEXT NOT NULL,
-			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
-		)`,
-		`CREATE TABLE IF NOT EXISTS app_state (
-			key TEXT PRIMARY KEY,
-			value TEXT NOT NULL
-		)`,
-		`CREATE TABLE IF NOT EXISTS saved_queries (
-			id INTEGER PRIMARY KEY AUTOINCREMENT,
-			connection_id INTEGER NOT NULL,
-			name TEXT NOT NULL,
-			query TEXT NOT NULL,
-			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
-			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
-			FOREIGN KEY (connection_id) REFERENCES connections(id) ON DELETE CASCADE
-		)`,
-	}
-
-	for _, migration := range migrations {
-		if _, err := c.db.Exec(migration); err != nil {
-			return err
-		}
-	}
-
-	return nil
-}
-
 func (c *ConfigDB) DB() *sql.DB {
 	return c.db
 }
@@ -8,6 +8,8 @@ import (
 	"github.com/3-lines-studio/datafrost/internal/core/entity"
 )
 
+const connectionColumns = "id, name, type, credentials, group_name, tags, environment, color, sort_order, trust_references, created_at"
+
 type ConnectionRepository struct {
 	db *sql.DB
 }
@@ -22,9 +24,14 @@ func (r *ConnectionRepository) Create(req entity.CreateConnectionRequest) (*enti
 		return nil, err
 	}
 
+	tagsJSON, err := serializeTags(req.Tags)
+	if err != nil {
+		return nil, err
+	}
+
 	result, err := r.db.Exec(
-		"INSERT INTO connections (name, type, credentials) VALUES (?, ?, ?)",
-		req.Name, req.Type, credentialsJSON,
+		"INSERT INTO connections (name, type, credentials, group_name, tags, environment, color, trust_references) VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+		req.Name, req.Type, credentialsJSON, req.Group, tagsJSON, req.Environment, req.Color, req.TrustReferences,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create connection: %w", err)
@@ -39,31 +46,22 @@ func (r *ConnectionRepository) Create(req entity.CreateConnectionRequest) (*enti
 }
 
 func (r *ConnectionRepository) GetByID(id int64) (*entity.Connection, error) {
-	var conn entity.Connection
-	var credentialsJSON string
-	err := r.db.QueryRow(
-		"SELECT id, name, type, credentials, created_at FROM connections WHERE id = ?",
+	conn, err := scanConnection(r.db.QueryRow(
+		"SELECT "+connectionColumns+" FROM connections WHERE id = ?",
 		id,
-	).Scan(&conn.ID, &conn.Name, &conn.Type, &credentialsJSON, &conn.CreatedAt)
-
+	))
 	if err == sql.ErrNoRows {
 		return nil, nil
 	}
 	if err != nil {
 		return nil, fmt.Errorf("failed to get connection: %w", err)
 	}
-
-	conn.Credentials, err = deserializeCredentials(credentialsJSON)
-	if err != nil {
-		return nil, err
-	}
-
-	return &conn, nil
+	return conn, nil
 }
 
 func (r *ConnectionRepository) List() ([]entity.Connection, error) {
 	rows, err := r.db.Query(
-		"SELECT id, name, type, credentials, created_at FROM connections ORDER BY created_at DESC",
+		"SELECT " + connectionColumns + " FROM connections ORDER BY sort_order, created_at DESC, id DESC",
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list connections: %w", err)
@@ -72,16 +70,11 @@ func (r *ConnectionRepository) List() ([]entity.Connection, error) {
 
 	var connections []entity.Connection
 	for rows.Next() {
-		var conn entity.Connection
-		var credentialsJSON string
-		if err := rows.Scan(&conn.ID, &conn.Name, &conn.Type, &credentialsJSON, &conn.CreatedAt); err != nil {
-			return nil, fmt.Errorf("failed to scan connection: %w", err)
-		}
-		conn.Credentials, err = deserializeCredentials(credentialsJSON)
+		conn, err := scanConnection(rows)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("failed to scan connection: %w", err)
 		}
-		connections = append(connections, conn)
+		connections = append(connections, *conn)
 	}
 
 	return connections, rows.Err()
@@ -101,9 +94,23 @@ func (r *ConnectionRepository) Update(id int64, req entity.UpdateConnectionReque
 		return nil, err
 	}
 
+	var tagsJSON *string
+	if req.Tags != nil {
+		data, err := serializeTags(*req.Tags)
+		if err != nil {
+			return nil, err
+		}
+		tagsJSON = &data
+	}
+
+	// NULL parameters keep the stored value, see UpdateConnectionRequest.
 	_, err = r.db.Exec(
-		"UPDATE connections SET name = ?, type = ?, credentials = ? WHERE id = ?",
-		req.Name, req.Type, credentialsJSON, id,
+		`UPDATE connections SET name = ?, type = ?, credentials = ?,
+			group_name = COALESCE(?, group_name), tags = COALESCE(?, tags),
+			environment = COALESCE(?, environment), color = COALESCE(?, color),
+			trust_references = COALESCE(?, trust_references)
+		WHERE id = ?`,
+		req.Name, req.Type, credentialsJSON, req.Group, tagsJSON, req.Environment, req.Color, req.TrustReferences, id,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update connection: %w", err)
@@ -112,6 +119,21 @@ func (r *ConnectionRepository) Update(id int64, req entity.UpdateConnectionReque
 	return r.GetByID(id)
 }
 
+func (r *ConnectionRepository) Reorder(ids []int64) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	for i, id := range ids {
+		if _, err := tx.Exec("UPDATE connections SET sort_order = ? WHERE id = ?", i+1, id); err != nil {
+			return fmt.Errorf("failed to reorder connections: %w", err)
+		}
+	}
+	return tx.Commit()
+}
+
 func (r *ConnectionRepository) SetLastConnected(id int64) error {
 	_, err := r.db.Exec(
 		"INSERT INTO app_state (key, value) VALUES ('last_connected_id', ?) ON CONFLICT(key) DO UPDATE SET value = ?",
@@ -138,6 +160,43 @@ func (r *ConnectionRepository) GetLastConnected() (int64, error) {
 	return id, err
 }
 
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanConnection(row rowScanner) (*entity.Connection, error) {
+	var conn entity.Connection
+	var credentialsJSON, tagsJSON string
+	err := row.Scan(&conn.ID, &conn.Name, &conn.Type, &credentialsJSON,
+		&conn.Group, &tagsJSON, &conn.Environment, &conn.Color, &conn.SortOrder, &conn.TrustReferences, &conn.CreatedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.Credentials, err = deserializeCredentials(credentialsJSON)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal([]byte(tagsJSON), &conn.Tags); err != nil {
+		return nil, fmt.Errorf("failed to deserialize tags: %w", err)
+	}
+	if conn.Tags == nil {
+		conn.Tags = []string{}
+	}
+	return &conn, nil
+}
+
+func serializeTags(tags []string) (string, error) {
+	if tags == nil {
+		tags = []string{}
+	}
+	data, err := json.Marshal(tags)
+	if err != nil {
+		return "", fmt.Errorf("failed to serialize tags: %w", err)
+	}
+	return string(data), nil
+}
+
 func serializeCredentials(credentials map[string]any) (string, error) {
 	data, err := json.Marshal(credentials)
 	if err != nil {
@@ -0,0 +1,201 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrSchemaTooNew = errors.New("config.db was created by a newer version of Datafrost")
+
+type migration struct {
+	version int
+	name    string
+	up      func(tx *sql.Tx) error
+}
+
+// Append only: released versions must never be edited or renumbered, since
+// installs record the highest version they applied.
+var migrations = []migration{
+	{1, "initial schema", execAll(
+		`CREATE TABLE IF NOT EXISTS connections (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			name TEXT NOT NULL UNIQUE,
+			type TEXT NOT NULL,
+			credentials TEXT NOT NULL,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP
+		)`,
+		`CREATE TABLE IF NOT EXISTS app_state (
+			key TEXT PRIMARY KEY,
+			value TEXT NOT NULL
+		)`,
+		`CREATE TABLE IF NOT EXISTS saved_queries (
+			id INTEGER PRIMARY KEY AUTOINCREMENT,
+			connection_id INTEGER NOT NULL,
+			name TEXT NOT NULL,
+			query TEXT NOT NULL,
+			created_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			updated_at DATETIME DEFAULT CURRENT_TIMESTAMP,
+			FOREIGN KEY (connection_id) REFERENCES connections(id) ON DELETE CASCADE
+		)`,
+		`CREATE TABLE IF NOT EXISTS schema_index (
+			connection_id INTEGER NOT NULL,
+			table_name TEXT NOT NULL,
+			table_type TEXT NOT NULL,
+			column_name TEXT NOT NULL DEFAULT '',
+			column_type TEXT NOT NULL DEFAULT '',
+			comment TEXT NOT NULL DEFAULT '',
+			FOREIGN KEY (connection_id) REFERENCES connections(id) ON DELETE CASCADE
+		)`,
+		`CREATE INDEX IF NOT EXISTS idx_schema_index_connection ON schema_index(connection_id)`,
+		`CREATE TABLE IF NOT EXISTS schema_index_status (
+			connection_id INTEGER PRIMARY KEY,
+			indexed_at DATETIME NOT NULL,
+			FOREIGN KEY (connection_id) REFERENCES connections(id) ON DELETE CASCADE
+		)`,
+	)},
+	{2, "connection organization", addColumns("connections",
+		column{"group_name", "TEXT NOT NULL DEFAULT ''"},
+		column{"tags", "TEXT NOT NULL DEFAULT '[]'"},
+		column{"environment", "TEXT NOT NULL DEFAULT ''"},
+		column{"color", "TEXT NOT NULL DEFAULT ''"},
+		column{"sort_order", "INTEGER NOT NULL DEFAULT 0"},
+	)},
+	{3, "trusted credential references", addColumns("connections",
+		column{"trust_references", "INTEGER NOT NULL DEFAULT 0"},
+	)},
+}
+
+func (c *ConfigDB) migrate() error {
+	if _, err := c.db.Exec(`CREATE TABLE IF NOT EXISTS schema_version (
+		version INTEGER PRIMARY KEY,
+		name TEXT NOT NULL,
+		applied_at DATETIME DEFAULT CURRENT_TIMESTAMP
+	)`); err != nil {
+		return err
+	}
+
+	current, err := schemaVersion(c.db)
+	if err != nil {
+		return err
+	}
+	latest := migrations[len(migrations)-1].version
+	if current > latest {
+		return fmt.Errorf("%w: it is at schema version %d but this build supports up to %d; upgrade Datafrost or restore a backup of %s",
+			ErrSchemaTooNew, current, latest, c.path)
+	}
+	if current == latest {
+		return nil
+	}
+
+	if err := c.backup(current); err != nil {
+		return fmt.Errorf("failed to back up config database: %w", err)
+	}
+
+	for _, m := range migrations {
+		if m.version <= current {
+			continue
+		}
+		if err := c.apply(m); err != nil {
+			return fmt.Errorf("migration %d (%s): %w", m.version, m.name, err)
+		}
+	}
+	return nil
+}
+
+func (c *ConfigDB) apply(m migration) error {
+	tx, err := c.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// Another process may have migrated since the version was read. The
+	// transaction is IMMEDIATE (see NewConfigDB), so this read already holds
+	// the write lock and no other migration can start until it commits.
+	current, err := schemaVersion(tx)
+	if err != nil {
+		return err
+	}
+	if current >= m.version {
+		return nil
+	}
+
+	if err := m.up(tx); err != nil {
+		return err
+	}
+	if _, err := tx.Exec("INSERT INTO schema_version (version, name) VALUES (?, ?)", m.version, m.name); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// Databases from before schema_version existed report version 0 but already
+// hold data, so they are backed up too. Only a brand-new file is skipped.
+func (c *ConfigDB) backup(version int) error {
+	var tables int
+	err := c.db.QueryRow(
+		"SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name NOT IN ('schema_version', 'sqlite_sequence')",
+	).Scan(&tables)
+	if err != nil {
+		return err
+	}
+	if tables == 0 {
+		return nil
+	}
+
+	path := fmt.Sprintf("%s.v%d-%s.bak", c.path, version, time.Now().Format("20060102-150405"))
+	_, err = c.db.Exec("VACUUM INTO ?", path)
+	return err
+}
+
+type queryRower interface {
+	QueryRow(query string, args ...any) *sql.Row
+}
+
+func schemaVersion(q queryRower) (int, error) {
+	var version int
+	err := q.QueryRow("SELECT COALESCE(MAX(version), 0) FROM schema_version").Scan(&version)
+	return version, err
+}
+
+func execAll(statements ...string) func(tx *sql.Tx) error {
+	return func(tx *sql.Tx) error {
+		for _, stmt := range statements {
+			if _, err := tx.Exec(stmt); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+type column struct {
+	name       string
+	definition string
+}
+
+// SQLite has no ADD COLUMN IF NOT EXISTS. Columns are looked up first because
+// builds from before versioning added some of them on every start.
+func addColumns(table string, columns ...column) func(tx *sql.Tx) error {
+	return func(tx *sql.Tx) error {
+		for _, col := range columns {
+			var exists int
+			err := tx.QueryRow(
+				"SELECT COUNT(*) FROM pragma_table_info(?) WHERE name = ?",
+				table, col.name,
+			).Scan(&exists)
+			if err != nil {
+				return err
+			}
+			if exists > 0 {
+				continue
+			}
+			if _, err := tx.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", table, col.name, col.definition)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
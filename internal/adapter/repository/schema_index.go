@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/3-lines-studio/datafrost/internal/core/entity"
+)
+
+type SchemaIndexRepository struct {
+	db *sql.DB
+}
+
+func NewSchemaIndexRepository(db *sql.DB) *SchemaIndexRepository {
+	return &SchemaIndexRepository{db: db}
+}
+
+func (r *SchemaIndexRepository) Replace(connectionID int64, entries []entity.SchemaIndexEntry) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.Exec("DELETE FROM schema_index WHERE connection_id = ?", connectionID); err != nil {
+		return fmt.Errorf("failed to clear schema index: %w", err)
+	}
+
+	stmt, err := tx.Prepare(
+		`INSERT INTO schema_index (connection_id, table_name, table_type, column_name, column_type, comment)
+		 VALUES (?, ?, ?, ?, ?, ?)`,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to prepare schema index insert: %w", err)
+	}
+	defer func() { _ = stmt.Close() }()
+
+	for _, e := range entries {
+		if _, err := stmt.Exec(connectionID, e.TableName, e.TableType, e.ColumnName, e.ColumnType, e.Comment); err != nil {
+			return fmt.Errorf("failed to insert schema index entry: %w", err)
+		}
+	}
+
+	_, err = tx.Exec(
+		`INSERT INTO schema_index_status (connection_id, indexed_at) VALUES (?, ?)
+		 ON CONFLICT(connection_id) DO UPDATE SET indexed_at = excluded.indexed_at`,
+		connectionID, time.Now(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update schema index status: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+func (r *SchemaIndexRepository) Search(connectionID int64, term string, limit int) ([]entity.SearchResult, error) {
+	pattern := "%" + escapeLike(term) + "%"
+
+	query := `SELECT s.connection_id, c.name, s.table_name, s.table_type, s.column_name, s.column_type, s.comment
+		FROM schema_index s
+		JOIN connections c ON c.id = s.connection_id
+		WHERE ((s.column_name = '' AND s.table_name LIKE ? ESCAPE '\') OR s.column_name LIKE ? ESCAPE '\'
+			OR s.column_type LIKE ? ESCAPE '\' OR s.comment LIKE ? ESCAPE '\')`
+	args := []any{pattern, pattern, pattern, pattern}
+	if connectionID != 0 {
+		query += " AND s.connection_id = ?"
+		args = append(args, connectionID)
+	}
+	query += ` ORDER BY
+			CASE WHEN s.column_name = ? COLLATE NOCASE OR (s.column_name = '' AND s.table_name = ? COLLATE NOCASE) THEN 0 ELSE 1 END,
+			c.name, s.table_name, s.column_name
+		LIMIT ?`
+	args = append(args, term, term, limit)
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search schema index: %w", err)
+	}
+	defer func() { _ = rows.Close() }()
+
+	results := []entity.SearchResult{}
+	lowerTerm := strings.ToLower(term)
+	for rows.Next() {
+		var res entity.SearchResult
+		if err := rows.Scan(&res.ConnectionID, &res.ConnectionName, &res.TableName, &res.TableType, &res.ColumnName, &res.ColumnType, &res.Comment); err != nil {
+			return nil, fmt.Errorf("failed to scan search result: %w", err)
+		}
+		res.MatchedOn = matchedField(res, lowerTerm)
+		results = append(results, res)
+	}
+
+	return results, rows.Err()
+}
+
+func (r *SchemaIndexRepository) IndexedAt(connectionID int64) (*time.Time, error) {
+	var indexedAt time.Time
+	err := r.db.QueryRow(
+		"SELECT indexed_at FROM schema_index_status WHERE connection_id = ?",
+		connectionID,
+	).Scan(&indexedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get schema index status: %w", err)
+	}
+	return &indexedAt, nil
+}
+
+func (r *SchemaIndexRepository) Delete(connectionID int64) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.Exec("DELETE FROM schema_index WHERE connection_id = ?", connectionID); err != nil {
+		return fmt.Errorf("failed to clear schema index: %w", err)
+	}
+	if _, err := tx.Exec("DELETE FROM schema_index_status WHERE connection_id = ?", connectionID); err != nil {
+		return fmt.Errorf("failed to clear schema index status: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+func matchedField(res entity.SearchResult, lowerTerm string) string {
+	switch {
+	case res.ColumnName != "" && strings.Contains(strings.ToLower(res.ColumnName), lowerTerm):
+		return "column"
+	case res.ColumnName == "" && strings.Contains(strings.ToLower(res.TableName), lowerTerm):
+		return "table"
+	case strings.Contains(strings.ToLower(res.ColumnType), lowerTerm):
+		return "type"
+	case strings.Contains(strings.ToLower(res.Comment), lowerTerm):
+		return "comment"
+	default:
+		return "table"
+	}
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
@@ -0,0 +1,167 @@
+package secret
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Matches ${env:NAME}, ${file:PATH} and ${cmd:COMMAND}; a leading $$ escapes
+// the reference so it is kept literally.
+var referencePattern = regexp.MustCompile(`\$(\$?)\{(env|file|cmd):([^}]*)\}`)
+
+const commandTimeout = 30 * time.Second
+
+var (
+	ErrUntrustedReference    = errors.New("file and cmd references are only resolved for connections marked as trusted")
+	ErrUntrustedEnvReference = errors.New("untrusted connections only resolve ${env:} as the whole value of a secret field such as a password")
+)
+
+type ReferenceError struct {
+	Failures []ReferenceFailure
+}
+
+type ReferenceFailure struct {
+	Field     string
+	Reference string
+	Err       error
+}
+
+func (e *ReferenceError) Error() string {
+	parts := make([]string, len(e.Failures))
+	for i, f := range e.Failures {
+		parts[i] = fmt.Sprintf("%s (%s): %v", f.Field, f.Reference, f.Err)
+	}
+	return "unresolved credential references: " + strings.Join(parts, "; ")
+}
+
+type Resolver struct {
+	lookupEnv func(string) (string, bool)
+	readFile  func(string) ([]byte, error)
+	run       func(ctx context.Context, command string) ([]byte, error)
+}
+
+func NewResolver() *Resolver {
+	return &Resolver{lookupEnv: os.LookupEnv, readFile: os.ReadFile, run: runCommand}
+}
+
+// Untrusted credentials (unsaved test payloads, imports the user has not
+// confirmed) may only read environment variables: anything that can reach
+// the local API must not be able to read files or run commands. Even then a
+// variable may only fill a whole secret field, since one spliced into a host
+// or URL would be sent to whatever server that names.
+func (r *Resolver) Resolve(credentials map[string]any, trusted bool, secretFields map[string]bool) (map[string]any, error) {
+	keys := make([]string, 0, len(credentials))
+	for key := range credentials {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	resolved := make(map[string]any, len(credentials))
+	var failures []ReferenceFailure
+	for _, key := range keys {
+		value, ok := credentials[key].(string)
+		if !ok || !strings.Contains(value, "${") {
+			resolved[key] = credentials[key]
+			continue
+		}
+		resolved[key] = referencePattern.ReplaceAllStringFunc(value, func(match string) string {
+			groups := referencePattern.FindStringSubmatch(match)
+			if groups[1] != "" {
+				return match[1:]
+			}
+			if groups[2] != "env" && !trusted {
+				failures = append(failures, ReferenceFailure{Field: key, Reference: match, Err: ErrUntrustedReference})
+				return ""
+			}
+			if groups[2] == "env" && !trusted && (!secretFields[key] || match != value) {
+				failures = append(failures, ReferenceFailure{Field: key, Reference: match, Err: ErrUntrustedEnvReference})
+				return ""
+			}
+			out, err := r.resolveOne(groups[2], groups[3])
+			if err != nil {
+				failures = append(failures, ReferenceFailure{Field: key, Reference: match, Err: err})
+			}
+			return out
+		})
+	}
+	if len(failures) > 0 {
+		return nil, &ReferenceError{Failures: failures}
+	}
+	return resolved, nil
+}
+
+func (r *Resolver) resolveOne(kind, target string) (string, error) {
+	target = strings.TrimSpace(target)
+	if target == "" {
+		return "", errors.New("empty reference")
+	}
+	switch kind {
+	case "env":
+		value, ok := r.lookupEnv(target)
+		if !ok {
+			return "", fmt.Errorf("environment variable %s is not set", target)
+		}
+		return value, nil
+	case "file":
+		data, err := r.readFile(expandHome(target))
+		if err != nil {
+			return "", err
+		}
+		return trimNewline(string(data)), nil
+	case "cmd":
+		ctx, cancel := context.WithTimeout(context.Background(), commandTimeout)
+		defer cancel()
+		out, err := r.run(ctx, target)
+		if err != nil {
+			return "", err
+		}
+		return trimNewline(string(out)), nil
+	}
+	return "", fmt.Errorf("unknown reference type %q", kind)
+}
+
+func runCommand(ctx context.Context, command string) ([]byte, error) {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command)
+	} else {
+		cmd = exec.CommandContext(ctx, "sh", "-c", command)
+	}
+	out, err := cmd.Output()
+	if ctx.Err() == context.DeadlineExceeded {
+		return nil, fmt.Errorf("command timed out after %s", commandTimeout)
+	}
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			if stderr := strings.TrimSpace(string(exitErr.Stderr)); stderr != "" {
+				first, _, _ := strings.Cut(stderr, "\n")
+				return nil, fmt.Errorf("%v: %s", err, first)
+			}
+		}
+		return nil, err
+	}
+	return out, nil
+}
+
+func expandHome(path string) string {
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, rest)
+		}
+	}
+	return path
+}
+
+func trimNewline(value string) string {
+	return strings.TrimSuffix(strings.TrimSuffix(value, "\n"), "\r")
+}
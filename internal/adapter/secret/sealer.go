@@ -0,0 +1,93 @@
+package secret
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+
+	"github.com/3-lines-studio/datafrost/internal/core/entity"
+)
+
+const (
+	kdfName    = "pbkdf2-sha256"
+	cipherName = "aes-256-gcm"
+	keyLength  = 32
+	saltLength = 16
+	// OWASP's current recommendation for PBKDF2-HMAC-SHA256.
+	defaultIterations = 600_000
+	// Bounds how much work a crafted bundle can make Open do.
+	maxIterations = 10_000_000
+)
+
+var errDecrypt = errors.New("wrong passphrase or corrupted secrets")
+
+type PassphraseSealer struct {
+	iterations int
+}
+
+func NewPassphraseSealer() *PassphraseSealer {
+	return &PassphraseSealer{iterations: defaultIterations}
+}
+
+func (s *PassphraseSealer) Seal(plaintext []byte, passphrase string) (*entity.SealedSecrets, error) {
+	if passphrase == "" {
+		return nil, errors.New("passphrase is required")
+	}
+	salt := make([]byte, saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	aead, err := newAEAD(passphrase, salt, s.iterations)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return &entity.SealedSecrets{
+		KDF:        kdfName,
+		Iterations: s.iterations,
+		Salt:       salt,
+		Cipher:     cipherName,
+		Nonce:      nonce,
+		Ciphertext: aead.Seal(nil, nonce, plaintext, nil),
+	}, nil
+}
+
+func (s *PassphraseSealer) Open(sealed entity.SealedSecrets, passphrase string) ([]byte, error) {
+	if sealed.KDF != kdfName || sealed.Cipher != cipherName {
+		return nil, fmt.Errorf("unsupported secret encryption %s/%s", sealed.KDF, sealed.Cipher)
+	}
+	if sealed.Iterations < 1 || sealed.Iterations > maxIterations || len(sealed.Salt) == 0 {
+		return nil, errors.New("invalid key derivation parameters")
+	}
+	aead, err := newAEAD(passphrase, sealed.Salt, sealed.Iterations)
+	if err != nil {
+		return nil, err
+	}
+	if len(sealed.Nonce) != aead.NonceSize() {
+		return nil, errDecrypt
+	}
+	plaintext, err := aead.Open(nil, sealed.Nonce, sealed.Ciphertext, nil)
+	if err != nil {
+		return nil, errDecrypt
+	}
+	return plaintext, nil
+}
+
+func newAEAD(passphrase string, salt []byte, iterations int) (cipher.AEAD, error) {
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, iterations, keyLength)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
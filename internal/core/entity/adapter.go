@@ -4,6 +4,7 @@ type AdapterInfo struct {
 	Type        string   `json:"type"`
 	Name        string   `json:"name"`
 	Description string   `json:"description"`
+	LocalFile   bool     `json:"local_file"`
 	UIConfig    UIConfig `json:"ui_config"`
 }
 
@@ -26,4 +27,5 @@ type FieldConfig struct {
 	Type        string `json:"type"`
 	Required    bool   `json:"required"`
 	Placeholder string `json:"placeholder,omitempty"`
+	Secret      bool   `json:"secret,omitempty"`
 }
@@ -0,0 +1,42 @@
+package entity
+
+type CompareSide struct {
+	ConnectionID int64  `json:"connection_id"`
+	Query        string `json:"query"`
+}
+
+type CompareRequest struct {
+	Left       CompareSide `json:"left"`
+	Right      CompareSide `json:"right"`
+	KeyColumns []string    `json:"key_columns"`
+	MaxRows    int         `json:"max_rows,omitempty"`
+}
+
+type CompareRow struct {
+	Key            []any    `json:"key"`
+	Left           []any    `json:"left,omitempty"`
+	Right          []any    `json:"right,omitempty"`
+	ChangedColumns []string `json:"changed_columns,omitempty"`
+}
+
+type CompareSummary struct {
+	LeftRows      int64 `json:"left_rows"`
+	RightRows     int64 `json:"right_rows"`
+	Added         int64 `json:"added"`
+	Removed       int64 `json:"removed"`
+	Changed       int64 `json:"changed"`
+	Unchanged     int64 `json:"unchanged"`
+	DuplicateKeys int64 `json:"duplicate_keys"`
+}
+
+type CompareResult struct {
+	Columns          []string       `json:"columns"`
+	KeyColumns       []string       `json:"key_columns"`
+	LeftOnlyColumns  []string       `json:"left_only_columns,omitempty"`
+	RightOnlyColumns []string       `json:"right_only_columns,omitempty"`
+	Added            []CompareRow   `json:"added"`
+	Removed          []CompareRow   `json:"removed"`
+	Changed          []CompareRow   `json:"changed"`
+	Summary          CompareSummary `json:"summary"`
+	Truncated        bool           `json:"truncated"`
+}
@@ -0,0 +1,33 @@
+package entity
+
+type CompletionRequest struct {
+	SQL    string `json:"sql"`
+	Cursor int    `json:"cursor"`
+}
+
+type CompletionResponse struct {
+	Dialect     string            `json:"dialect"`
+	Keywords    []string          `json:"keywords"`
+	Functions   []string          `json:"functions"`
+	Types       []string          `json:"types"`
+	Tables      []CompletionTable `json:"tables"`
+	Context     string            `json:"context,omitempty"`
+	Suggestions []CompletionItem  `json:"suggestions,omitempty"`
+}
+
+type CompletionTable struct {
+	Name    string             `json:"name"`
+	Type    string             `json:"type"`
+	Columns []CompletionColumn `json:"columns"`
+}
+
+type CompletionColumn struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+type CompletionItem struct {
+	Label  string `json:"label"`
+	Kind   string `json:"kind"`
+	Detail string `json:"detail,omitempty"`
+}
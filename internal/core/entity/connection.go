@@ -2,27 +2,72 @@ package entity
 
 import "time"
 
+const (
+	EnvironmentDev     = "dev"
+	EnvironmentStaging = "staging"
+	EnvironmentProd    = "prod"
+)
+
 type Connection struct {
 	ID          int64          `json:"id"`
 	Name        string         `json:"name"`
 	Type        string         `json:"type"`
 	Credentials map[string]any `json:"credentials"`
-	CreatedAt   time.Time      `json:"created_at"`
+	Group       string         `json:"group"`
+	Tags        []string       `json:"tags"`
+	Environment string         `json:"environment"`
+	Color       string         `json:"color"`
+	SortOrder   int            `json:"sort_order"`
+	// Only trusted connections resolve ${file:} and ${cmd:} references in
+	// their credentials; imported ones start untrusted.
+	TrustReferences bool      `json:"trust_references"`
+	CreatedAt       time.Time `json:"created_at"`
 }
 
 type CreateConnectionRequest struct {
 	Name        string         `json:"name"`
 	Type        string         `json:"type"`
 	Credentials map[string]any `json:"credentials"`
+	Group       string         `json:"group,omitempty"`
+	Tags        []string       `json:"tags,omitempty"`
+	Environment string         `json:"environment,omitempty"`
+	Color       string         `json:"color,omitempty"`
+	// Trust is granted by the CLI or POST /api/connections/{id}/trust, never
+	// as a side effect of saving credentials.
+	TrustReferences bool `json:"-"`
 }
 
 type UpdateConnectionRequest struct {
 	Name        string         `json:"name"`
 	Type        string         `json:"type"`
 	Credentials map[string]any `json:"credentials"`
+	// Nil keeps the current value, so callers that only change credentials
+	// do not clear how the connection is organized.
+	Group           *string   `json:"group,omitempty"`
+	Tags            *[]string `json:"tags,omitempty"`
+	Environment     *string   `json:"environment,omitempty"`
+	Color           *string   `json:"color,omitempty"`
+	TrustReferences *bool     `json:"-"`
 }
 
 type TestConnectionRequest struct {
 	Type        string         `json:"type"`
 	Credentials map[string]any `json:"credentials"`
+	// Set only by local callers such as the CLI; payloads posted to the API
+	// can never ask for file or cmd references to be resolved.
+	TrustReferences bool `json:"-"`
+}
+
+type TrustConnectionRequest struct {
+	Trusted bool `json:"trusted"`
+}
+
+type ConnectionFilter struct {
+	Tag         string
+	Environment string
+	Group       string
+}
+
+type ReorderConnectionsRequest struct {
+	IDs []int64 `json:"ids"`
 }
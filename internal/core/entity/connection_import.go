@@ -0,0 +1,64 @@
+package entity
+
+const (
+	ConnectionSourcePgpass    = "pgpass"
+	ConnectionSourcePgService = "pg_service"
+	ConnectionSourceEnv       = "env"
+	ConnectionSourceDBeaver   = "dbeaver"
+	ConnectionSourceTablePlus = "tableplus"
+)
+
+const (
+	OnDuplicateSkip    = "skip"
+	OnDuplicateRename  = "rename"
+	OnDuplicateReplace = "replace"
+)
+
+const (
+	ImportActionCreate  = "create"
+	ImportActionRename  = "rename"
+	ImportActionReplace = "replace"
+	ImportActionSkip    = "skip"
+	ImportActionInvalid = "invalid"
+)
+
+type ConnectionImportRequest struct {
+	Source      string   `json:"source"`
+	Path        string   `json:"path,omitempty"`
+	Content     string   `json:"content,omitempty"`
+	OnDuplicate string   `json:"on_duplicate,omitempty"`
+	Names       []string `json:"names,omitempty"`
+	// Lets imported ${file:} and ${cmd:} references resolve; without it they
+	// stay inert until the connection is trusted by hand. Only the CLI sets it.
+	TrustReferences bool `json:"-"`
+}
+
+type ConnectionCandidate struct {
+	Name        string
+	Type        string
+	Credentials map[string]any
+	Origin      string
+	Warnings    []string
+}
+
+type ConnectionImportItem struct {
+	Name         string   `json:"name"`
+	SourceName   string   `json:"source_name"`
+	Type         string   `json:"type"`
+	Origin       string   `json:"origin"`
+	Action       string   `json:"action"`
+	Error        string   `json:"error,omitempty"`
+	Warnings     []string `json:"warnings,omitempty"`
+	ConnectionID int64    `json:"connection_id,omitempty"`
+}
+
+type ConnectionImportResult struct {
+	Source   string                 `json:"source"`
+	Path     string                 `json:"path,omitempty"`
+	DryRun   bool                   `json:"dry_run"`
+	Items    []ConnectionImportItem `json:"items"`
+	Created  int                    `json:"created"`
+	Replaced int                    `json:"replaced"`
+	Skipped  int                    `json:"skipped"`
+	Invalid  int                    `json:"invalid"`
+}
@@ -0,0 +1,12 @@
+package entity
+
+type CopyRequest struct {
+	TargetConnectionID int64    `json:"target_connection_id"`
+	Table              string   `json:"table,omitempty"`
+	Filters            []Filter `json:"filters,omitempty"`
+	Query              string   `json:"query,omitempty"`
+	TargetTable        string   `json:"target_table"`
+	Mode               string   `json:"mode"`
+	BatchSize          int      `json:"batch_size,omitempty"`
+	ConfirmProd        bool     `json:"confirm_prod,omitempty"`
+}
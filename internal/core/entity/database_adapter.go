@@ -1,5 +1,7 @@
 package entity
 
+import "context"
+
 type DatabaseAdapter interface {
 	Connect(credentials map[string]any) error
 	Close() error
@@ -8,9 +10,15 @@ type DatabaseAdapter interface {
 	ExecuteQuery(query string) (*QueryResult, error)
 	Ping() error
 	GetTableSchema(tableName string) (*TableSchema, error)
+	GetTableDDL(tableName string) (*TableDDL, error)
+	GetColumnValues(tableName, column, search string, filters []Filter, limit, offset int) ([]ColumnValue, error)
+	Explain(query string, analyze bool) (*QueryPlan, error)
+	StreamQuery(ctx context.Context, query string, sink RowSink) error
+	StreamTableData(ctx context.Context, tableName string, filters []Filter, sink RowSink) error
 }
 
 type AdapterRegistration struct {
 	Info    AdapterInfo
+	Dialect SQLDialect
 	Factory func() DatabaseAdapter
 }
@@ -0,0 +1,8 @@
+package entity
+
+type SQLDialect struct {
+	Name      string   `json:"name"`
+	Keywords  []string `json:"keywords"`
+	Functions []string `json:"functions"`
+	Types     []string `json:"types"`
+}
@@ -0,0 +1,33 @@
+package entity
+
+type ERDFilter struct {
+	Schema       string `json:"schema"`
+	TablePattern string `json:"table_pattern"`
+}
+
+type ERDiagram struct {
+	Tables        []ERDTable        `json:"tables"`
+	Relationships []ERDRelationship `json:"relationships"`
+	Errors        []ERDTableError   `json:"errors,omitempty"`
+}
+
+type ERDTable struct {
+	Name       string       `json:"name"`
+	Schema     string       `json:"schema,omitempty"`
+	Type       string       `json:"type"`
+	Columns    []ColumnInfo `json:"columns"`
+	PrimaryKey []string     `json:"primary_key"`
+}
+
+type ERDRelationship struct {
+	Name        string   `json:"name"`
+	FromTable   string   `json:"from_table"`
+	FromColumns []string `json:"from_columns"`
+	ToTable     string   `json:"to_table"`
+	ToColumns   []string `json:"to_columns"`
+}
+
+type ERDTableError struct {
+	Table string `json:"table"`
+	Error string `json:"error"`
+}
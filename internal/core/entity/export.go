@@ -0,0 +1,33 @@
+package entity
+
+type ExportRequest struct {
+	Format       string        `json:"format"`
+	Path         string        `json:"path"`
+	Overwrite    bool          `json:"overwrite"`
+	Query        string        `json:"query,omitempty"`
+	SavedQueryID int64         `json:"saved_query_id,omitempty"`
+	Table        string        `json:"table,omitempty"`
+	Filters      []Filter      `json:"filters,omitempty"`
+	Options      ExportOptions `json:"options"`
+}
+
+type ExportOptions struct {
+	Delimiter  string `json:"delimiter,omitempty"`
+	QuoteAll   bool   `json:"quote_all,omitempty"`
+	OmitHeader bool   `json:"omit_header,omitempty"`
+	NullValue  string `json:"null_value,omitempty"`
+	Dialect    string `json:"dialect,omitempty"`
+	TableName  string `json:"table_name,omitempty"`
+	BatchSize  int    `json:"batch_size,omitempty"`
+	OmitDDL    bool   `json:"omit_ddl,omitempty"`
+	DropTable  bool   `json:"drop_table,omitempty"`
+}
+
+type ExportTarget struct {
+	Path       string
+	Format     string
+	Options    ExportOptions
+	SourceType string
+	Table      string
+	Schema     *TableSchema
+}
@@ -0,0 +1,39 @@
+package entity
+
+type ImportOptions struct {
+	Format    string `json:"format,omitempty"`
+	Delimiter string `json:"delimiter,omitempty"`
+	NoHeader  bool   `json:"no_header,omitempty"`
+}
+
+type ImportColumn struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+}
+
+type ImportPreviewRequest struct {
+	Path    string        `json:"path"`
+	Options ImportOptions `json:"options"`
+}
+
+type ImportPreview struct {
+	Format      string         `json:"format"`
+	Columns     []ImportColumn `json:"columns"`
+	Rows        [][]any        `json:"rows"`
+	SampledRows int            `json:"sampled_rows"`
+}
+
+type ImportRequest struct {
+	Path        string         `json:"path"`
+	Options     ImportOptions  `json:"options"`
+	Table       string         `json:"table"`
+	CreateTable bool           `json:"create_table"`
+	Columns     []ImportColumn `json:"columns,omitempty"`
+	ConfirmProd bool           `json:"confirm_prod,omitempty"`
+}
+
+type ImportUpload struct {
+	Path string `json:"path"`
+	Name string `json:"name"`
+	Size int64  `json:"size"`
+}
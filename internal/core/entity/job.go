@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+const (
+	JobStatusRunning   = "running"
+	JobStatusCompleted = "completed"
+	JobStatusFailed    = "failed"
+	JobStatusCancelled = "cancelled"
+)
+
+type Job struct {
+	ID            string     `json:"id"`
+	Kind          string     `json:"kind"`
+	ConnectionID  int64      `json:"connection_id"`
+	Status        string     `json:"status"`
+	RowsProcessed int64      `json:"rows_processed"`
+	Path          string     `json:"path,omitempty"`
+	Error         string     `json:"error,omitempty"`
+	StartedAt     time.Time  `json:"started_at"`
+	FinishedAt    *time.Time `json:"finished_at,omitempty"`
+}
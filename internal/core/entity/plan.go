@@ -0,0 +1,27 @@
+package entity
+
+type ExplainRequest struct {
+	Query          string `json:"query"`
+	Analyze        bool   `json:"analyze"`
+	ConfirmAnalyze bool   `json:"confirm_analyze"`
+}
+
+type QueryPlan struct {
+	Dialect  string         `json:"dialect"`
+	Analyzed bool           `json:"analyzed"`
+	Root     *PlanNode      `json:"root"`
+	Summary  map[string]any `json:"summary,omitempty"`
+}
+
+type PlanNode struct {
+	Operation     string         `json:"operation"`
+	Relation      string         `json:"relation,omitempty"`
+	Detail        string         `json:"detail,omitempty"`
+	StartupCost   *float64       `json:"startup_cost,omitempty"`
+	TotalCost     *float64       `json:"total_cost,omitempty"`
+	EstimatedRows *float64       `json:"estimated_rows,omitempty"`
+	ActualRows    *float64       `json:"actual_rows,omitempty"`
+	ActualTimeMs  *float64       `json:"actual_time_ms,omitempty"`
+	Extra         map[string]any `json:"extra,omitempty"`
+	Children      []*PlanNode    `json:"children"`
+}
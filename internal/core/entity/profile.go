@@ -0,0 +1,54 @@
+package entity
+
+const (
+	ProfileKindNumeric  = "numeric"
+	ProfileKindTemporal = "temporal"
+	ProfileKindBoolean  = "boolean"
+	ProfileKindText     = "text"
+	ProfileKindBinary   = "binary"
+	ProfileKindComplex  = "complex"
+)
+
+type ProfileRequest struct {
+	Table       string   `json:"table,omitempty"`
+	Query       string   `json:"query,omitempty"`
+	Columns     []string `json:"columns,omitempty"`
+	TopN        int      `json:"top_n,omitempty"`
+	Buckets     int      `json:"buckets,omitempty"`
+	Approximate bool     `json:"approximate,omitempty"`
+}
+
+type ProfileValueCount struct {
+	Value any   `json:"value"`
+	Count int64 `json:"count"`
+}
+
+type HistogramBucket struct {
+	Lower float64 `json:"lower"`
+	Upper float64 `json:"upper"`
+	Count int64   `json:"count"`
+}
+
+type ColumnProfile struct {
+	Name                string              `json:"name"`
+	Type                string              `json:"type"`
+	Kind                string              `json:"kind"`
+	NullCount           int64               `json:"null_count"`
+	DistinctCount       *int64              `json:"distinct_count,omitempty"`
+	DistinctApproximate bool                `json:"distinct_approximate,omitempty"`
+	Min                 any                 `json:"min,omitempty"`
+	Max                 any                 `json:"max,omitempty"`
+	Average             *float64            `json:"average,omitempty"`
+	TopValues           []ProfileValueCount `json:"top_values,omitempty"`
+	Histogram           []HistogramBucket   `json:"histogram,omitempty"`
+	Error               string              `json:"error,omitempty"`
+}
+
+type ProfileReport struct {
+	Table      string          `json:"table,omitempty"`
+	Query      string          `json:"query,omitempty"`
+	Dialect    string          `json:"dialect"`
+	Rows       int64           `json:"rows"`
+	Columns    []ColumnProfile `json:"columns"`
+	DurationMs int64           `json:"duration_ms"`
+}
@@ -0,0 +1,56 @@
+package entity
+
+type SchemaDiffRequest struct {
+	SourceConnectionID int64  `json:"source_connection_id"`
+	TargetConnectionID int64  `json:"target_connection_id"`
+	Schema             string `json:"schema,omitempty"`
+	TablePattern       string `json:"tables,omitempty"`
+	Refresh            bool   `json:"refresh,omitempty"`
+	GenerateScript     bool   `json:"generate_script,omitempty"`
+}
+
+type SchemaDiff struct {
+	SourceConnectionID int64           `json:"source_connection_id"`
+	TargetConnectionID int64           `json:"target_connection_id"`
+	MissingTables      []string        `json:"missing_tables"`
+	ExtraTables        []string        `json:"extra_tables"`
+	Tables             []TableDiff     `json:"tables"`
+	Errors             []ERDTableError `json:"errors,omitempty"`
+	Dialect            string          `json:"dialect,omitempty"`
+	Script             string          `json:"script,omitempty"`
+}
+
+type TableDiff struct {
+	Table              string           `json:"table"`
+	AddedColumns       []ColumnInfo     `json:"added_columns,omitempty"`
+	RemovedColumns     []ColumnInfo     `json:"removed_columns,omitempty"`
+	ChangedColumns     []ColumnDiff     `json:"changed_columns,omitempty"`
+	SourcePrimaryKey   []string         `json:"source_primary_key,omitempty"`
+	TargetPrimaryKey   []string         `json:"target_primary_key,omitempty"`
+	AddedIndexes       []IndexInfo      `json:"added_indexes,omitempty"`
+	RemovedIndexes     []IndexInfo      `json:"removed_indexes,omitempty"`
+	ChangedIndexes     []IndexDiff      `json:"changed_indexes,omitempty"`
+	AddedForeignKeys   []ForeignKeyInfo `json:"added_foreign_keys,omitempty"`
+	RemovedForeignKeys []ForeignKeyInfo `json:"removed_foreign_keys,omitempty"`
+	AddedConstraints   []ConstraintInfo `json:"added_constraints,omitempty"`
+	RemovedConstraints []ConstraintInfo `json:"removed_constraints,omitempty"`
+}
+
+type ColumnDiff struct {
+	Name               string `json:"name"`
+	SourceType         string `json:"source_type"`
+	TargetType         string `json:"target_type"`
+	SourceNullable     bool   `json:"source_nullable"`
+	TargetNullable     bool   `json:"target_nullable"`
+	SourceDefault      string `json:"source_default,omitempty"`
+	TargetDefault      string `json:"target_default,omitempty"`
+	TypeChanged        bool   `json:"type_changed"`
+	NullabilityChanged bool   `json:"nullability_changed"`
+	DefaultChanged     bool   `json:"default_changed"`
+}
+
+type IndexDiff struct {
+	Name   string    `json:"name"`
+	Source IndexInfo `json:"source"`
+	Target IndexInfo `json:"target"`
+}
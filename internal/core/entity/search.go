@@ -0,0 +1,35 @@
+package entity
+
+import "time"
+
+type SchemaIndexEntry struct {
+	TableName  string
+	TableType  string
+	ColumnName string
+	ColumnType string
+	Comment    string
+}
+
+type SearchResult struct {
+	ConnectionID   int64  `json:"connection_id"`
+	ConnectionName string `json:"connection_name"`
+	TableName      string `json:"table_name"`
+	TableType      string `json:"table_type"`
+	ColumnName     string `json:"column_name,omitempty"`
+	ColumnType     string `json:"column_type,omitempty"`
+	Comment        string `json:"comment,omitempty"`
+	MatchedOn      string `json:"matched_on"`
+}
+
+type SearchResponse struct {
+	Results  []SearchResult `json:"results"`
+	Indexing []int64        `json:"indexing"`
+}
+
+type SchemaIndexStatus struct {
+	ConnectionID int64      `json:"connection_id"`
+	IndexedAt    *time.Time `json:"indexed_at"`
+	Tables       int        `json:"tables"`
+	Columns      int        `json:"columns"`
+	Errors       []string   `json:"errors,omitempty"`
+}
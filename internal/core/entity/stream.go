@@ -0,0 +1,28 @@
+package entity
+
+import "context"
+
+type ResultColumn struct {
+	Name         string `json:"name"`
+	DatabaseType string `json:"database_type"`
+	Precision    int64  `json:"precision,omitempty"`
+	Scale        int64  `json:"scale,omitempty"`
+}
+
+type RowSink interface {
+	WriteHeader(columns []ResultColumn) error
+	WriteRow(values []any) error
+}
+
+type RowSource func() ([]any, error)
+
+type BulkInsertRequest struct {
+	Table        string
+	DropExisting bool
+	Create       []ColumnInfo
+	Columns      []string
+}
+
+type BulkWriter interface {
+	BulkInsert(ctx context.Context, req BulkInsertRequest, next RowSource, progress func(rows int64)) error
+}
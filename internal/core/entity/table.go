@@ -1,8 +1,9 @@
 package entity
 
 type TableInfo struct {
-	Name string `json:"name"`
-	Type string `json:"type"`
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	Schema string `json:"schema,omitempty"`
 }
 
 type TableSchema struct {
@@ -10,14 +11,18 @@ type TableSchema struct {
 	Columns     []ColumnInfo     `json:"columns"`
 	Indexes     []IndexInfo      `json:"indexes"`
 	Constraints []ConstraintInfo `json:"constraints"`
+	ForeignKeys []ForeignKeyInfo `json:"foreign_keys"`
+	Comment     string           `json:"comment,omitempty"`
 }
 
 type ColumnInfo struct {
-	Name         string `json:"name"`
-	Type         string `json:"type"`
-	Nullable     bool   `json:"nullable"`
-	DefaultValue string `json:"default_value"`
-	IsPrimaryKey bool   `json:"is_primary_key"`
+	Name         string   `json:"name"`
+	Type         string   `json:"type"`
+	Nullable     bool     `json:"nullable"`
+	DefaultValue string   `json:"default_value"`
+	IsPrimaryKey bool     `json:"is_primary_key"`
+	Comment      string   `json:"comment,omitempty"`
+	EnumValues   []string `json:"enum_values,omitempty"`
 }
 
 type IndexInfo struct {
@@ -32,3 +37,30 @@ type ConstraintInfo struct {
 	Column     string `json:"column"`
 	Definition string `json:"definition"`
 }
+
+type ForeignKeyInfo struct {
+	Name              string   `json:"name"`
+	Columns           []string `json:"columns"`
+	ReferencedTable   string   `json:"referenced_table"`
+	ReferencedColumns []string `json:"referenced_columns"`
+}
+
+type TableDDL struct {
+	TableName string `json:"table_name"`
+	Type      string `json:"type"`
+	DDL       string `json:"ddl"`
+}
+
+type ColumnValue struct {
+	Value any   `json:"value"`
+	Count int64 `json:"count"`
+}
+
+type ColumnValues struct {
+	Column  string        `json:"column"`
+	Source  string        `json:"source"`
+	Values  []ColumnValue `json:"values"`
+	Page    int           `json:"page"`
+	Limit   int           `json:"limit"`
+	HasMore bool          `json:"has_more"`
+}
@@ -0,0 +1,79 @@
+package entity
+
+import "time"
+
+const (
+	WorkspaceBundleFormat  = "datafrost-workspace"
+	WorkspaceBundleVersion = 1
+)
+
+type WorkspaceBundle struct {
+	Format      string                `json:"format"`
+	Version     int                   `json:"version"`
+	ExportedAt  time.Time             `json:"exported_at"`
+	Connections []WorkspaceConnection `json:"connections"`
+	Layouts     map[string]string     `json:"layouts,omitempty"`
+	Secrets     *SealedSecrets        `json:"secrets,omitempty"`
+}
+
+type WorkspaceConnection struct {
+	Name           string                `json:"name"`
+	Type           string                `json:"type"`
+	Credentials    map[string]any        `json:"credentials"`
+	Group          string                `json:"group,omitempty"`
+	Tags           []string              `json:"tags,omitempty"`
+	Environment    string                `json:"environment,omitempty"`
+	Color          string                `json:"color,omitempty"`
+	OmittedSecrets []string              `json:"omitted_secrets,omitempty"`
+	SavedQueries   []WorkspaceSavedQuery `json:"saved_queries,omitempty"`
+	Tabs           []Tab                 `json:"tabs,omitempty"`
+}
+
+type WorkspaceSavedQuery struct {
+	Name  string `json:"name"`
+	Query string `json:"query"`
+}
+
+type SealedSecrets struct {
+	KDF        string `json:"kdf"`
+	Iterations int    `json:"iterations"`
+	Salt       []byte `json:"salt"`
+	Cipher     string `json:"cipher"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+type WorkspaceExportOptions struct {
+	Connections []string
+	Passphrase  string
+}
+
+type WorkspaceImportOptions struct {
+	Passphrase      string
+	SkipSecrets     bool
+	OnDuplicate     string
+	DryRun          bool
+	TrustReferences bool
+}
+
+type WorkspaceImportItem struct {
+	Name         string   `json:"name"`
+	SourceName   string   `json:"source_name"`
+	Type         string   `json:"type"`
+	Action       string   `json:"action"`
+	Error        string   `json:"error,omitempty"`
+	Warnings     []string `json:"warnings,omitempty"`
+	ConnectionID int64    `json:"connection_id,omitempty"`
+	SavedQueries int      `json:"saved_queries"`
+	Tabs         int      `json:"tabs"`
+}
+
+type WorkspaceImportResult struct {
+	DryRun   bool                  `json:"dry_run"`
+	Items    []WorkspaceImportItem `json:"items"`
+	Created  int                   `json:"created"`
+	Replaced int                   `json:"replaced"`
+	Skipped  int                   `json:"skipped"`
+	Invalid  int                   `json:"invalid"`
+	Layouts  int                   `json:"layouts"`
+}
@@ -3,6 +3,7 @@ package usecase
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/3-lines-studio/datafrost/internal/core/entity"
 	"github.com/3-lines-studio/datafrost/internal/usecase/port"
@@ -43,6 +44,18 @@ func (u *AppStateUsecase) SaveLayout(key, layout string) error {
 	return u.repo.Set("layout_"+key, layout)
 }
 
+func (u *AppStateUsecase) Layouts() (map[string]string, error) {
+	values, err := u.repo.List("layout_")
+	if err != nil {
+		return nil, err
+	}
+	layouts := make(map[string]string, len(values))
+	for key, value := range values {
+		layouts[strings.TrimPrefix(key, "layout_")] = value
+	}
+	return layouts, nil
+}
+
 func (u *AppStateUsecase) GetTabs(connectionID int) ([]entity.Tab, error) {
 	key := fmt.Sprintf("tabs_%d", connectionID)
 	value, err := u.repo.Get(key)
@@ -0,0 +1,138 @@
+package usecase
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/3-lines-studio/datafrost/internal/core/entity"
+)
+
+const (
+	defaultColumnValuesLimit = 50
+	maxColumnValuesLimit     = 200
+	maxKnownColumnValues     = 1000
+)
+
+var (
+	checkListPattern = regexp.MustCompile(`(?i)^CHECK\s*\(+\s*"?([A-Za-z_][\w$]*)"?\)?(?:::[\w ]+?)?\s*(?:=\s*ANY|IN)\s*\(`)
+	quotedLiteral    = regexp.MustCompile(`'((?:[^']|'')*)'`)
+)
+
+func (u *TableUsecase) GetColumnValues(connectionID int64, tableName, column, search string, filters []entity.Filter, page, limit int) (*entity.ColumnValues, error) {
+	if limit <= 0 {
+		limit = defaultColumnValuesLimit
+	}
+	if limit > maxColumnValuesLimit {
+		limit = maxColumnValuesLimit
+	}
+	if page <= 0 {
+		page = 1
+	}
+	offset := (page - 1) * limit
+
+	adapter, _, err := u.getAdapter(connectionID)
+	if err != nil {
+		return nil, err
+	}
+	schema, err := u.GetTableSchema(connectionID, tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	var info *entity.ColumnInfo
+	for i := range schema.Columns {
+		if schema.Columns[i].Name == column {
+			info = &schema.Columns[i]
+			break
+		}
+	}
+	if info == nil {
+		return nil, ErrColumnNotFound
+	}
+
+	// Filters on the picked column are dropped so the picker shows the alternatives.
+	var others []entity.Filter
+	for _, f := range filters {
+		if f.Column != column {
+			others = append(others, f)
+		}
+	}
+
+	result := &entity.ColumnValues{Column: column, Source: "data", Page: page, Limit: limit}
+
+	known, source := knownColumnValues(schema, *info)
+	if len(known) == 0 {
+		values, err := adapter.GetColumnValues(tableName, column, search, others, limit+1, offset)
+		if err != nil {
+			return nil, err
+		}
+		if len(values) > limit {
+			values = values[:limit]
+			result.HasMore = true
+		}
+		result.Values = values
+		return result, nil
+	}
+
+	counted, err := adapter.GetColumnValues(tableName, column, "", others, maxKnownColumnValues, 0)
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[string]int64, len(counted))
+	for _, v := range counted {
+		counts[fmt.Sprint(v.Value)] = v.Count
+	}
+
+	needle := strings.ToLower(search)
+	values := []entity.ColumnValue{}
+	for _, v := range known {
+		if needle == "" || strings.Contains(strings.ToLower(v), needle) {
+			values = append(values, entity.ColumnValue{Value: v, Count: counts[v]})
+		}
+	}
+	sort.SliceStable(values, func(i, j int) bool { return values[i].Count > values[j].Count })
+
+	if offset > len(values) {
+		offset = len(values)
+	}
+	end := offset + limit
+	if end < len(values) {
+		result.HasMore = true
+	} else {
+		end = len(values)
+	}
+	result.Source = source
+	result.Values = values[offset:end]
+	return result, nil
+}
+
+func knownColumnValues(schema *entity.TableSchema, column entity.ColumnInfo) ([]string, string) {
+	if len(column.EnumValues) > 0 {
+		return column.EnumValues, "enum"
+	}
+
+	for _, c := range schema.Constraints {
+		if c.Type != "CHECK" {
+			continue
+		}
+		upper := strings.ToUpper(c.Definition)
+		if strings.Contains(upper, " AND ") || strings.Contains(upper, " OR ") {
+			continue
+		}
+		m := checkListPattern.FindStringSubmatchIndex(c.Definition)
+		if m == nil || c.Definition[m[2]:m[3]] != column.Name {
+			continue
+		}
+
+		var values []string
+		for _, lit := range quotedLiteral.FindAllStringSubmatch(c.Definition[m[1]:], -1) {
+			values = append(values, strings.ReplaceAll(lit[1], "''", "'"))
+		}
+		if len(values) > 0 {
+			return values, "check"
+		}
+	}
+	return nil, ""
+}
@@ -0,0 +1,426 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/3-lines-studio/datafrost/internal/core/entity"
+	"github.com/3-lines-studio/datafrost/internal/usecase/port"
+)
+
+const (
+	defaultCompareMaxRows = 1000
+	maxCompareMaxRows     = 10000
+)
+
+var errCompareHeaderRead = errors.New("compare header read")
+
+type CompareUsecase struct {
+	connRepo port.ConnectionRepository
+	cache    port.AdapterCache
+}
+
+func NewCompareUsecase(connRepo port.ConnectionRepository, cache port.AdapterCache) *CompareUsecase {
+	return &CompareUsecase{connRepo: connRepo, cache: cache}
+}
+
+type compareEntry struct {
+	hash      uint64
+	seen      bool
+	collected bool
+}
+
+type compareState struct {
+	keys       []string
+	columns    []string
+	maxRows    int
+	left       map[string]compareEntry
+	addedKeys  map[string]struct{}
+	changedIdx map[string]int
+	result     *entity.CompareResult
+}
+
+func (u *CompareUsecase) Compare(ctx context.Context, req entity.CompareRequest) (*entity.CompareResult, error) {
+	if strings.TrimSpace(req.Left.Query) == "" || strings.TrimSpace(req.Right.Query) == "" {
+		return nil, ErrQueryRequired
+	}
+	if len(req.KeyColumns) == 0 {
+		return nil, ErrKeyColumnsRequired
+	}
+
+	maxRows := req.MaxRows
+	if maxRows <= 0 {
+		maxRows = defaultCompareMaxRows
+	}
+	if maxRows > maxCompareMaxRows {
+		maxRows = maxCompareMaxRows
+	}
+
+	left, err := u.adapter(req.Left.ConnectionID)
+	if err != nil {
+		return nil, err
+	}
+	right, err := u.adapter(req.Right.ConnectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only the right header is read up front, so the hash pass already knows
+	// which columns both sides share and each full pass runs once.
+	rightHeader, err := queryHeader(ctx, right, req.Right.Query)
+	if err != nil {
+		return nil, err
+	}
+	if _, _, err := newCompareLayout(rightHeader, req.KeyColumns, nil); err != nil {
+		return nil, err
+	}
+
+	state := &compareState{
+		keys:       req.KeyColumns,
+		maxRows:    maxRows,
+		left:       make(map[string]compareEntry),
+		addedKeys:  make(map[string]struct{}),
+		changedIdx: make(map[string]int),
+		result: &entity.CompareResult{
+			KeyColumns: req.KeyColumns,
+			Added:      []entity.CompareRow{},
+			Removed:    []entity.CompareRow{},
+			Changed:    []entity.CompareRow{},
+		},
+	}
+
+	if err := left.StreamQuery(ctx, req.Left.Query, &compareHashSink{state: state, right: headerNames(rightHeader)}); err != nil {
+		return nil, err
+	}
+	if err := right.StreamQuery(ctx, req.Right.Query, &compareRightSink{state: state}); err != nil {
+		return nil, err
+	}
+
+	summary := &state.result.Summary
+	for _, entry := range state.left {
+		if !entry.seen {
+			summary.Removed++
+		}
+	}
+
+	// Only hashes are kept from the first pass, so the left values of removed
+	// and changed rows come from running the left query again. Rows that
+	// changed in between are reported with their newer values.
+	if summary.Removed > 0 || len(state.changedIdx) > 0 {
+		if err := left.StreamQuery(ctx, req.Left.Query, &compareCollectSink{state: state}); err != nil {
+			return nil, err
+		}
+	}
+
+	result := state.result
+	result.Truncated = summary.Added > int64(len(result.Added)) ||
+		summary.Removed > int64(len(result.Removed)) ||
+		summary.Changed > int64(len(result.Changed))
+	return result, nil
+}
+
+func (u *CompareUsecase) adapter(connectionID int64) (entity.DatabaseAdapter, error) {
+	conn, err := u.connRepo.GetByID(connectionID)
+	if err != nil {
+		return nil, err
+	}
+	if conn == nil {
+		return nil, ErrConnectionNotFound
+	}
+	return u.cache.Get(conn)
+}
+
+func queryHeader(ctx context.Context, adapter entity.DatabaseAdapter, query string) ([]entity.ResultColumn, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	sink := &compareHeaderSink{cancel: cancel}
+	err := adapter.StreamQuery(ctx, query, sink)
+	if sink.header != nil {
+		return sink.header, nil
+	}
+	if err == nil || errors.Is(err, errCompareHeaderRead) {
+		return nil, fmt.Errorf("%w: query returned no columns", ErrInvalidRequest)
+	}
+	return nil, err
+}
+
+// compareHeaderSink stops the stream as soon as the header arrives; the
+// cancel keeps drivers from reading the rest of the result.
+type compareHeaderSink struct {
+	cancel context.CancelFunc
+	header []entity.ResultColumn
+}
+
+func (s *compareHeaderSink) WriteHeader(header []entity.ResultColumn) error {
+	s.header = header
+	s.cancel()
+	return errCompareHeaderRead
+}
+
+func (s *compareHeaderSink) WriteRow([]any) error {
+	return errCompareHeaderRead
+}
+
+type compareLayout struct {
+	keyIdx []int
+	colIdx []int
+}
+
+func newCompareLayout(header []entity.ResultColumn, keys, columns []string) (*compareLayout, []string, error) {
+	positions := make(map[string]int, len(header))
+	for i, col := range header {
+		if _, ok := positions[col.Name]; !ok {
+			positions[col.Name] = i
+		}
+	}
+
+	layout := &compareLayout{}
+	for _, key := range keys {
+		idx, ok := positions[key]
+		if !ok {
+			return nil, nil, fmt.Errorf("%w: key column %q not found", ErrInvalidRequest, key)
+		}
+		layout.keyIdx = append(layout.keyIdx, idx)
+	}
+
+	var missing []string
+	for _, col := range columns {
+		idx, ok := positions[col]
+		if !ok {
+			missing = append(missing, col)
+			continue
+		}
+		layout.colIdx = append(layout.colIdx, idx)
+	}
+	return layout, missing, nil
+}
+
+func headerNames(header []entity.ResultColumn) []string {
+	names := make([]string, len(header))
+	for i, col := range header {
+		names[i] = col.Name
+	}
+	return names
+}
+
+type compareHashSink struct {
+	state  *compareState
+	right  []string
+	layout *compareLayout
+}
+
+func (s *compareHashSink) WriteHeader(header []entity.ResultColumn) error {
+	names := headerNames(header)
+	s.state.columns = difference(names, difference(names, s.right))
+	layout, _, err := newCompareLayout(header, s.state.keys, s.state.columns)
+	if err != nil {
+		return err
+	}
+	s.layout = layout
+	s.state.result.Columns = s.state.columns
+	s.state.result.LeftOnlyColumns = difference(headerNames(header), s.state.columns)
+	return nil
+}
+
+func (s *compareHashSink) WriteRow(values []any) error {
+	s.state.result.Summary.LeftRows++
+	key := compareKey(values, s.layout.keyIdx)
+	if _, exists := s.state.left[key]; exists {
+		s.state.result.Summary.DuplicateKeys++
+		return nil
+	}
+	s.state.left[key] = compareEntry{hash: compareHash(values, s.layout.colIdx)}
+	return nil
+}
+
+type compareRightSink struct {
+	state  *compareState
+	layout *compareLayout
+}
+
+func (s *compareRightSink) WriteHeader(header []entity.ResultColumn) error {
+	layout, missing, err := newCompareLayout(header, s.state.keys, s.state.columns)
+	if err != nil {
+		return err
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("right query columns changed between passes: %s", strings.Join(missing, ", "))
+	}
+	s.layout = layout
+	s.state.result.RightOnlyColumns = difference(headerNames(header), s.state.columns)
+	return nil
+}
+
+func (s *compareRightSink) WriteRow(values []any) error {
+	state := s.state
+	summary := &state.result.Summary
+	summary.RightRows++
+
+	key := compareKey(values, s.layout.keyIdx)
+	entry, inLeft := state.left[key]
+	if !inLeft {
+		if _, dup := state.addedKeys[key]; dup {
+			summary.DuplicateKeys++
+			return nil
+		}
+		state.addedKeys[key] = struct{}{}
+		summary.Added++
+		if len(state.result.Added) < state.maxRows {
+			state.result.Added = append(state.result.Added, entity.CompareRow{
+				Key:   pick(values, s.layout.keyIdx),
+				Right: pick(values, s.layout.colIdx),
+			})
+		}
+		return nil
+	}
+
+	if entry.seen {
+		summary.DuplicateKeys++
+		return nil
+	}
+	entry.seen = true
+	state.left[key] = entry
+
+	if entry.hash == compareHash(values, s.layout.colIdx) {
+		summary.Unchanged++
+		return nil
+	}
+
+	summary.Changed++
+	if len(state.result.Changed) < state.maxRows {
+		state.changedIdx[key] = len(state.result.Changed)
+		state.result.Changed = append(state.result.Changed, entity.CompareRow{
+			Key:   pick(values, s.layout.keyIdx),
+			Right: pick(values, s.layout.colIdx),
+		})
+	}
+	return nil
+}
+
+type compareCollectSink struct {
+	state  *compareState
+	layout *compareLayout
+}
+
+func (s *compareCollectSink) WriteHeader(header []entity.ResultColumn) error {
+	layout, missing, err := newCompareLayout(header, s.state.keys, s.state.columns)
+	if err != nil {
+		return err
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("left query columns changed between passes: %s", strings.Join(missing, ", "))
+	}
+	s.layout = layout
+	return nil
+}
+
+func (s *compareCollectSink) WriteRow(values []any) error {
+	state := s.state
+	key := compareKey(values, s.layout.keyIdx)
+	entry, ok := state.left[key]
+	if !ok || entry.collected {
+		return nil
+	}
+	entry.collected = true
+	state.left[key] = entry
+
+	if !entry.seen {
+		if len(state.result.Removed) < state.maxRows {
+			state.result.Removed = append(state.result.Removed, entity.CompareRow{
+				Key:  pick(values, s.layout.keyIdx),
+				Left: pick(values, s.layout.colIdx),
+			})
+		}
+		return nil
+	}
+
+	idx, ok := state.changedIdx[key]
+	if !ok {
+		return nil
+	}
+	row := &state.result.Changed[idx]
+	row.Left = pick(values, s.layout.colIdx)
+	for i, col := range state.columns {
+		if compareText(row.Left[i]) != compareText(row.Right[i]) {
+			row.ChangedColumns = append(row.ChangedColumns, col)
+		}
+	}
+	return nil
+}
+
+func compareKey(values []any, idx []int) string {
+	var b strings.Builder
+	for i, pos := range idx {
+		if i > 0 {
+			b.WriteByte(0x1f)
+		}
+		b.WriteString(compareText(values[pos]))
+	}
+	return b.String()
+}
+
+func compareHash(values []any, idx []int) uint64 {
+	h := fnv.New64a()
+	for _, pos := range idx {
+		text := compareText(values[pos])
+		_, _ = h.Write([]byte(strconv.Itoa(len(text))))
+		_, _ = h.Write([]byte{':'})
+		_, _ = h.Write([]byte(text))
+	}
+	return h.Sum64()
+}
+
+func compareText(val any) string {
+	switch v := val.(type) {
+	case nil:
+		return "\x00"
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) < 1e15 {
+			return strconv.FormatInt(int64(v), 10)
+		}
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case time.Time:
+		return v.UTC().Format(time.RFC3339Nano)
+	default:
+		return fmt.Sprintf("%v", normalizeCopyValue(v))
+	}
+}
+
+func pick(values []any, idx []int) []any {
+	picked := make([]any, len(idx))
+	for i, pos := range idx {
+		val := values[pos]
+		if b, ok := val.([]byte); ok {
+			val = string(b)
+		}
+		picked[i] = val
+	}
+	return picked
+}
+
+func difference(all, remove []string) []string {
+	excluded := make(map[string]bool, len(remove))
+	for _, name := range remove {
+		excluded[name] = true
+	}
+	var out []string
+	for _, name := range all {
+		if !excluded[name] {
+			out = append(out, name)
+		}
+	}
+	return out
+}
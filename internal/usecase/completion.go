@@ -0,0 +1,395 @@
+package usecase
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+
+	"github.com/3-lines-studio/datafrost/internal/core/entity"
+	"github.com/3-lines-studio/datafrost/internal/usecase/port"
+)
+
+const (
+	maxCompletionTables      = 200
+	maxCompletionSuggestions = 100
+)
+
+type CompletionUsecase struct {
+	connRepo port.ConnectionRepository
+	factory  port.AdapterFactory
+	tables   *TableUsecase
+}
+
+func NewCompletionUsecase(
+	connRepo port.ConnectionRepository,
+	factory port.AdapterFactory,
+	tables *TableUsecase,
+) *CompletionUsecase {
+	return &CompletionUsecase{
+		connRepo: connRepo,
+		factory:  factory,
+		tables:   tables,
+	}
+}
+
+func (u *CompletionUsecase) Complete(connectionID int64, req entity.CompletionRequest) (*entity.CompletionResponse, error) {
+	conn, err := u.connRepo.GetByID(connectionID)
+	if err != nil {
+		return nil, err
+	}
+	if conn == nil {
+		return nil, ErrConnectionNotFound
+	}
+
+	dialect, err := u.factory.GetDialect(conn.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	tableInfos, err := u.tables.ListTables(connectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	var sqlCtx completionContext
+	if req.SQL != "" {
+		sqlCtx = parseCompletionContext(req.SQL, req.Cursor)
+	}
+
+	// Columns are loaded for every table on small connections; on large ones
+	// only for the tables the statement already references.
+	referenced := make(map[string]bool)
+	for _, ref := range sqlCtx.tables {
+		referenced[strings.ToLower(ref.name)] = true
+	}
+	withColumns := len(tableInfos) <= maxCompletionTables
+
+	tables := make([]entity.CompletionTable, len(tableInfos))
+	forEachLimit(len(tableInfos), schemaFetchConcurrency, func(i int) {
+		t := tableInfos[i]
+		tables[i] = entity.CompletionTable{Name: t.Name, Type: t.Type, Columns: []entity.CompletionColumn{}}
+		if !withColumns && !referenced[strings.ToLower(t.Name)] {
+			return
+		}
+		schema, err := u.tables.GetTableSchema(connectionID, t.Name)
+		if err != nil {
+			return
+		}
+		for _, col := range schema.Columns {
+			tables[i].Columns = append(tables[i].Columns, entity.CompletionColumn{Name: col.Name, Type: col.Type})
+		}
+	})
+
+	response := &entity.CompletionResponse{
+		Dialect:   dialect.Name,
+		Keywords:  dialect.Keywords,
+		Functions: dialect.Functions,
+		Types:     dialect.Types,
+		Tables:    tables,
+	}
+
+	if req.SQL != "" {
+		response.Context = sqlCtx.kind
+		response.Suggestions = suggest(sqlCtx, dialect, tables)
+	}
+
+	return response, nil
+}
+
+type tableRef struct {
+	name  string
+	alias string
+}
+
+type completionContext struct {
+	kind      string
+	qualifier string
+	partial   string
+	tables    []tableRef
+}
+
+type sqlToken struct {
+	text   string
+	quoted bool
+	end    int
+}
+
+func parseCompletionContext(sql string, cursor int) completionContext {
+	runes := []rune(sql)
+	pos := runeOffset(runes, cursor)
+	tokens := tokenizeSQL(runes)
+
+	ctx := completionContext{tables: referencedTables(tokens)}
+
+	// The word under the cursor, possibly qualified as alias.partial.
+	start := pos
+	for start > 0 && (isIdentRune(runes[start-1]) || runes[start-1] == '.') {
+		start--
+	}
+	word := string(runes[start:pos])
+	if dot := strings.LastIndex(word, "."); dot >= 0 {
+		ctx.qualifier = strings.Trim(word[:dot], `"`+"`")
+		ctx.partial = word[dot+1:]
+	} else {
+		ctx.partial = word
+	}
+
+	if ctx.qualifier != "" {
+		ctx.kind = "column"
+		return ctx
+	}
+
+	var prev *sqlToken
+	for i := range tokens {
+		if tokens[i].end <= start {
+			prev = &tokens[i]
+		}
+	}
+
+	ctx.kind = "keyword"
+	if prev == nil {
+		return ctx
+	}
+
+	switch strings.ToUpper(prev.text) {
+	case "FROM", "JOIN", "INTO", "UPDATE", "TABLE":
+		ctx.kind = "table"
+	case "SELECT", "WHERE", "AND", "OR", "ON", "BY", "HAVING", "SET", "DISTINCT", "NOT",
+		"(", "=", "<", ">", "<=", ">=", "<>", "!=", "+", "-", "*", "/", "WHEN", "THEN", "ELSE":
+		ctx.kind = "column"
+	case ",":
+		ctx.kind = "column"
+		if clause := lastClauseKeyword(tokens, start); clause == "FROM" {
+			ctx.kind = "table"
+		}
+	}
+	return ctx
+}
+
+func suggest(ctx completionContext, dialect entity.SQLDialect, tables []entity.CompletionTable) []entity.CompletionItem {
+	var items []entity.CompletionItem
+	partial := strings.ToLower(ctx.partial)
+	matches := func(label string) bool {
+		return strings.HasPrefix(strings.ToLower(label), partial)
+	}
+
+	byName := make(map[string]entity.CompletionTable, len(tables))
+	for _, t := range tables {
+		byName[strings.ToLower(t.Name)] = t
+	}
+	resolve := func(name string) (entity.CompletionTable, bool) {
+		name = strings.ToLower(name)
+		for _, ref := range ctx.tables {
+			if strings.ToLower(ref.alias) == name {
+				name = strings.ToLower(ref.name)
+				break
+			}
+		}
+		if dot := strings.LastIndex(name, "."); dot >= 0 {
+			name = name[dot+1:]
+		}
+		t, ok := byName[name]
+		return t, ok
+	}
+
+	addColumns := func(t entity.CompletionTable) {
+		for _, col := range t.Columns {
+			if matches(col.Name) {
+				items = append(items, entity.CompletionItem{Label: col.Name, Kind: "column", Detail: t.Name + " · " + col.Type})
+			}
+		}
+	}
+	addTables := func() {
+		for _, t := range tables {
+			if matches(t.Name) {
+				items = append(items, entity.CompletionItem{Label: t.Name, Kind: "table", Detail: t.Type})
+			}
+		}
+	}
+	addWords := func(words []string, kind string) {
+		for _, w := range words {
+			if matches(w) {
+				items = append(items, entity.CompletionItem{Label: w, Kind: kind})
+			}
+		}
+	}
+
+	switch ctx.kind {
+	case "table":
+		addTables()
+	case "column":
+		if ctx.qualifier != "" {
+			if t, ok := resolve(ctx.qualifier); ok {
+				addColumns(t)
+			}
+			break
+		}
+		seen := make(map[string]bool)
+		for _, ref := range ctx.tables {
+			if t, ok := resolve(ref.name); ok && !seen[t.Name] {
+				seen[t.Name] = true
+				addColumns(t)
+			}
+		}
+		addWords(dialect.Functions, "function")
+		addWords(dialect.Keywords, "keyword")
+	default:
+		addWords(dialect.Keywords, "keyword")
+		if partial != "" {
+			addWords(dialect.Functions, "function")
+			addTables()
+		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool {
+		return completionRank(items[i].Kind) < completionRank(items[j].Kind)
+	})
+	if len(items) > maxCompletionSuggestions {
+		items = items[:maxCompletionSuggestions]
+	}
+	return items
+}
+
+func completionRank(kind string) int {
+	switch kind {
+	case "column":
+		return 0
+	case "table":
+		return 1
+	case "function":
+		return 2
+	default:
+		return 3
+	}
+}
+
+func referencedTables(tokens []sqlToken) []tableRef {
+	var refs []tableRef
+	for i := 0; i < len(tokens); i++ {
+		kw := strings.ToUpper(tokens[i].text)
+		if tokens[i].quoted || (kw != "FROM" && kw != "JOIN") {
+			continue
+		}
+		for j := i + 1; j < len(tokens); {
+			if tokens[j].text == "(" || (!tokens[j].quoted && isReservedWord(tokens[j].text)) {
+				break
+			}
+			ref := tableRef{name: tokens[j].text}
+			j++
+			if j < len(tokens) && strings.EqualFold(tokens[j].text, "AS") {
+				j++
+			}
+			if j < len(tokens) && isIdentToken(tokens[j]) && !isReservedWord(tokens[j].text) {
+				ref.alias = tokens[j].text
+				j++
+			}
+			refs = append(refs, ref)
+			if kw != "FROM" || j >= len(tokens) || tokens[j].text != "," {
+				break
+			}
+			j++
+		}
+	}
+	return refs
+}
+
+func lastClauseKeyword(tokens []sqlToken, before int) string {
+	clause := ""
+	for _, t := range tokens {
+		if t.end > before {
+			break
+		}
+		switch kw := strings.ToUpper(t.text); kw {
+		case "SELECT", "FROM", "WHERE", "GROUP", "ORDER", "HAVING", "SET", "ON", "JOIN":
+			clause = kw
+		}
+	}
+	return clause
+}
+
+func tokenizeSQL(runes []rune) []sqlToken {
+	var tokens []sqlToken
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '-' && i+1 < len(runes) && runes[i+1] == '-':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+		case r == '\'':
+			i++
+			for i < len(runes) && runes[i] != '\'' {
+				i++
+			}
+			i++
+		case r == '"' || r == '`':
+			start := i + 1
+			i++
+			for i < len(runes) && runes[i] != r {
+				i++
+			}
+			end := min(i, len(runes))
+			i++
+			tokens = append(tokens, sqlToken{text: string(runes[start:end]), quoted: true, end: min(i, len(runes))})
+		case isIdentRune(r):
+			start := i
+			for i < len(runes) && (isIdentRune(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, sqlToken{text: string(runes[start:i]), end: i})
+		default:
+			start := i
+			i++
+			if i < len(runes) && strings.ContainsRune("=<>", runes[i]) && strings.ContainsRune("<>!", r) {
+				i++
+			}
+			tokens = append(tokens, sqlToken{text: string(runes[start:i]), end: i})
+		}
+	}
+	return tokens
+}
+
+// runeOffset converts a UTF-16 offset, as reported by the browser editor,
+// into a rune offset.
+func runeOffset(runes []rune, cursor int) int {
+	if cursor < 0 {
+		return len(runes)
+	}
+	units := 0
+	for i, r := range runes {
+		if units >= cursor {
+			return i
+		}
+		units += len(utf16.Encode([]rune{r}))
+	}
+	return len(runes)
+}
+
+func isIdentRune(r rune) bool {
+	return r == '_' || r == '$' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func isIdentToken(t sqlToken) bool {
+	if t.quoted {
+		return true
+	}
+	for _, r := range t.text {
+		if !isIdentRune(r) {
+			return false
+		}
+	}
+	return t.text != ""
+}
+
+func isReservedWord(word string) bool {
+	switch strings.ToUpper(word) {
+	case "SELECT", "FROM", "WHERE", "JOIN", "INNER", "LEFT", "RIGHT", "FULL", "OUTER", "CROSS",
+		"ON", "USING", "GROUP", "ORDER", "BY", "HAVING", "LIMIT", "OFFSET", "UNION", "INTERSECT",
+		"EXCEPT", "WINDOW", "QUALIFY", "AS", "AND", "OR", "NATURAL", "LATERAL":
+		return true
+	}
+	return false
+}
@@ -1,25 +1,41 @@
 package usecase
 
 import (
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
 	"github.com/3-lines-studio/datafrost/internal/core/entity"
 	"github.com/3-lines-studio/datafrost/internal/usecase/port"
 )
 
 type ConnectionUsecase struct {
-	repo    port.ConnectionRepository
-	factory port.AdapterFactory
-	cache   port.AdapterCache
+	repo      port.ConnectionRepository
+	factory   port.AdapterFactory
+	cache     port.AdapterCache
+	metaCache port.MetadataCache
+	indexRepo port.SchemaIndexRepository
+	stateRepo port.AppStateRepository
 }
 
 func NewConnectionUsecase(
 	repo port.ConnectionRepository,
 	factory port.AdapterFactory,
 	cache port.AdapterCache,
+	metaCache port.MetadataCache,
+	indexRepo port.SchemaIndexRepository,
+	stateRepo port.AppStateRepository,
 ) *ConnectionUsecase {
 	return &ConnectionUsecase{
-		repo:    repo,
-		factory: factory,
-		cache:   cache,
+		repo:      repo,
+		factory:   factory,
+		cache:     cache,
+		metaCache: metaCache,
+		indexRepo: indexRepo,
+		stateRepo: stateRepo,
 	}
 }
 
@@ -32,6 +48,31 @@ func (u *ConnectionUsecase) List() ([]entity.Connection, int64, error) {
 	return connections, lastID, nil
 }
 
+func (u *ConnectionUsecase) ListFiltered(filter entity.ConnectionFilter) ([]entity.Connection, int64, error) {
+	if filter.Environment != "" && !validEnvironment(filter.Environment) {
+		return nil, 0, ErrInvalidEnvironment
+	}
+	connections, lastID, err := u.List()
+	if err != nil {
+		return nil, 0, err
+	}
+
+	filtered := make([]entity.Connection, 0, len(connections))
+	for _, conn := range connections {
+		if filter.Environment != "" && conn.Environment != filter.Environment {
+			continue
+		}
+		if filter.Group != "" && !strings.EqualFold(conn.Group, filter.Group) {
+			continue
+		}
+		if filter.Tag != "" && !slices.ContainsFunc(conn.Tags, func(tag string) bool { return strings.EqualFold(tag, filter.Tag) }) {
+			continue
+		}
+		filtered = append(filtered, conn)
+	}
+	return filtered, lastID, nil
+}
+
 func (u *ConnectionUsecase) Create(req entity.CreateConnectionRequest) (*entity.Connection, error) {
 	if req.Name == "" {
 		return nil, ErrNameRequired
@@ -39,12 +80,19 @@ func (u *ConnectionUsecase) Create(req entity.CreateConnectionRequest) (*entity.
 	if req.Type == "" {
 		return nil, ErrTypeRequired
 	}
+	if err := normalizeOrganization(&req.Group, &req.Tags, &req.Environment, &req.Color); err != nil {
+		return nil, err
+	}
 	return u.repo.Create(req)
 }
 
 func (u *ConnectionUsecase) Delete(id int64) error {
 	u.cache.Invalidate(id)
-	return u.repo.Delete(id)
+	u.metaCache.Remove(id)
+	if err := u.repo.Delete(id); err != nil {
+		return err
+	}
+	return u.stateRepo.Delete(metadataTTLKey(id))
 }
 
 func (u *ConnectionUsecase) Update(id int64, req entity.UpdateConnectionRequest) (*entity.Connection, error) {
@@ -54,10 +102,98 @@ func (u *ConnectionUsecase) Update(id int64, req entity.UpdateConnectionRequest)
 	if req.Type == "" {
 		return nil, ErrTypeRequired
 	}
+	var group, environment, color string
+	var tags []string
+	if req.Group != nil {
+		group = *req.Group
+	}
+	if req.Tags != nil {
+		tags = *req.Tags
+	}
+	if req.Environment != nil {
+		environment = *req.Environment
+	}
+	if req.Color != nil {
+		color = *req.Color
+	}
+	if err := normalizeOrganization(&group, &tags, &environment, &color); err != nil {
+		return nil, err
+	}
+	if req.Group != nil {
+		req.Group = &group
+	}
+	if req.Tags != nil {
+		req.Tags = &tags
+	}
+	if req.Environment != nil {
+		req.Environment = &environment
+	}
+	if req.Color != nil {
+		req.Color = &color
+	}
+	if req.TrustReferences == nil {
+		current, err := u.repo.GetByID(id)
+		if err != nil {
+			return nil, err
+		}
+		secret, err := u.SecretFields(req.Type)
+		if err != nil {
+			return nil, err
+		}
+		if current != nil && current.TrustReferences && addsTrustedReference(current.Credentials, req.Credentials, secret) {
+			untrusted := false
+			req.TrustReferences = &untrusted
+		}
+	}
 	u.cache.Invalidate(id)
+	u.metaCache.Invalidate(id)
+	// The edit may point the connection at another database, so its search
+	// index is rebuilt on the next search.
+	if err := u.indexRepo.Delete(id); err != nil {
+		return nil, err
+	}
 	return u.repo.Update(id, req)
 }
 
+func (u *ConnectionUsecase) SetTrust(id int64, trusted bool) (*entity.Connection, error) {
+	conn, err := u.repo.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if conn == nil {
+		return nil, ErrConnectionNotFound
+	}
+	u.cache.Invalidate(id)
+	return u.repo.Update(id, entity.UpdateConnectionRequest{
+		Name:            conn.Name,
+		Type:            conn.Type,
+		Credentials:     conn.Credentials,
+		TrustReferences: &trusted,
+	})
+}
+
+func (u *ConnectionUsecase) Reorder(ids []int64) error {
+	connections, err := u.repo.List()
+	if err != nil {
+		return err
+	}
+	known := make(map[int64]bool, len(connections))
+	for _, conn := range connections {
+		known[conn.ID] = true
+	}
+	seen := make(map[int64]bool, len(ids))
+	for _, id := range ids {
+		if !known[id] {
+			return ErrConnectionNotFound
+		}
+		if seen[id] {
+			return ErrInvalidRequest
+		}
+		seen[id] = true
+	}
+	return u.repo.Reorder(ids)
+}
+
 func (u *ConnectionUsecase) SetLastConnected(id int64) error {
 	return u.repo.SetLastConnected(id)
 }
@@ -66,7 +202,7 @@ func (u *ConnectionUsecase) Test(req entity.TestConnectionRequest) error {
 	if req.Type == "" {
 		return ErrTypeRequired
 	}
-	return u.factory.TestConnection(req.Type, req.Credentials)
+	return u.factory.TestConnection(req.Type, req.Credentials, req.TrustReferences)
 }
 
 func (u *ConnectionUsecase) TestExisting(id int64) error {
@@ -77,7 +213,7 @@ func (u *ConnectionUsecase) TestExisting(id int64) error {
 	if conn == nil {
 		return ErrConnectionNotFound
 	}
-	return u.factory.TestConnection(conn.Type, conn.Credentials)
+	return u.factory.TestConnection(conn.Type, conn.Credentials, conn.TrustReferences)
 }
 
 func (u *ConnectionUsecase) GetConnection(id int64) (*entity.Connection, error) {
@@ -90,3 +226,218 @@ func (u *ConnectionUsecase) GetConnection(id int64) (*entity.Connection, error)
 	}
 	return conn, nil
 }
+
+func (u *ConnectionUsecase) FindByName(name string) (*entity.Connection, error) {
+	connections, err := u.repo.List()
+	if err != nil {
+		return nil, err
+	}
+
+	var folded *entity.Connection
+	for i := range connections {
+		if connections[i].Name == name {
+			return &connections[i], nil
+		}
+		if strings.EqualFold(connections[i].Name, name) {
+			if folded != nil {
+				return nil, fmt.Errorf("connection name %q is ambiguous", name)
+			}
+			folded = &connections[i]
+		}
+	}
+	if folded != nil {
+		return folded, nil
+	}
+
+	if id, err := strconv.ParseInt(name, 10, 64); err == nil {
+		return u.GetConnection(id)
+	}
+	return nil, ErrConnectionNotFound
+}
+
+func (u *ConnectionUsecase) ValidateCredentials(adapterType string, credentials map[string]any) (map[string]any, error) {
+	info, err := u.factory.GetAdapterInfo(adapterType)
+	if err != nil {
+		return nil, err
+	}
+
+	normalized := make(map[string]any)
+	allowed := make(map[string]bool)
+	fields := info.UIConfig.Fields
+	if modes := info.UIConfig.Modes; len(modes) > 0 {
+		mode, err := credentialMode(modes, credentials)
+		if err != nil {
+			return nil, err
+		}
+		fields = mode.Fields
+		normalized["mode"] = mode.Key
+		allowed["mode"] = true
+	}
+
+	var missing []string
+	for _, field := range fields {
+		allowed[field.Key] = true
+		if !hasCredential(credentials, field.Key) {
+			if field.Required {
+				missing = append(missing, field.Key)
+			}
+			continue
+		}
+		normalized[field.Key] = credentials[field.Key]
+	}
+
+	var unknown []string
+	for key := range credentials {
+		if !allowed[key] {
+			unknown = append(unknown, key)
+		}
+	}
+	sort.Strings(unknown)
+
+	if len(unknown) > 0 {
+		return nil, fmt.Errorf("%w: unknown field(s) for %s: %s", ErrInvalidCredentials, adapterType, strings.Join(unknown, ", "))
+	}
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("%w: missing required field(s): %s", ErrInvalidCredentials, strings.Join(missing, ", "))
+	}
+	return normalized, nil
+}
+
+func (u *ConnectionUsecase) SecretFields(adapterType string) (map[string]bool, error) {
+	info, err := u.factory.GetAdapterInfo(adapterType)
+	if err != nil {
+		return nil, err
+	}
+	fields := slices.Clone(info.UIConfig.Fields)
+	for _, mode := range info.UIConfig.Modes {
+		fields = append(fields, mode.Fields...)
+	}
+	secret := make(map[string]bool)
+	for _, field := range fields {
+		if field.Secret {
+			secret[field.Key] = true
+		}
+	}
+	return secret, nil
+}
+
+var colorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+func normalizeOrganization(group *string, tags *[]string, environment, color *string) error {
+	*group = strings.TrimSpace(*group)
+	*environment = strings.ToLower(strings.TrimSpace(*environment))
+	if *environment != "" && !validEnvironment(*environment) {
+		return ErrInvalidEnvironment
+	}
+	*color = strings.ToLower(strings.TrimSpace(*color))
+	if *color != "" && !colorPattern.MatchString(*color) {
+		return ErrInvalidColor
+	}
+
+	normalized := make([]string, 0, len(*tags))
+	seen := make(map[string]bool, len(*tags))
+	for _, tag := range *tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || seen[strings.ToLower(tag)] {
+			continue
+		}
+		seen[strings.ToLower(tag)] = true
+		normalized = append(normalized, tag)
+	}
+	*tags = normalized
+	return nil
+}
+
+const untrustedReferenceWarning = "uses ${file:} or ${cmd:} references, which stay unresolved until the connection is trusted"
+
+func hasLocalReference(credentials map[string]any) bool {
+	for _, value := range credentials {
+		if s, ok := value.(string); ok && (strings.Contains(s, "${file:") || strings.Contains(s, "${cmd:")) {
+			return true
+		}
+	}
+	return false
+}
+
+var wholeEnvReference = regexp.MustCompile(`^\$\{env:[^}]*\}$`)
+
+// Trust covers the references that were there when it was granted, so an
+// edit that brings new ones has to be trusted again. Like the resolver, an
+// env reference only counts when it is not the whole value of a secret field.
+func addsTrustedReference(current, updated map[string]any, secret map[string]bool) bool {
+	for key, value := range updated {
+		s, ok := value.(string)
+		if !ok || current[key] == value {
+			continue
+		}
+		if strings.Contains(s, "${file:") || strings.Contains(s, "${cmd:") {
+			return true
+		}
+		if strings.Contains(s, "${env:") && !(secret[key] && wholeEnvReference.MatchString(s)) {
+			return true
+		}
+	}
+	return false
+}
+
+// A replaced connection stays trusted only while the imported credentials
+// bring no file or cmd references of their own.
+func importTrust(imported map[string]any, replace *entity.Connection, optIn bool) bool {
+	if optIn {
+		return true
+	}
+	return replace != nil && replace.TrustReferences && !hasLocalReference(imported)
+}
+
+func validEnvironment(environment string) bool {
+	switch environment {
+	case entity.EnvironmentDev, entity.EnvironmentStaging, entity.EnvironmentProd:
+		return true
+	}
+	return false
+}
+
+func credentialMode(modes []entity.UIMode, credentials map[string]any) (*entity.UIMode, error) {
+	if key, _ := credentials["mode"].(string); key != "" {
+		keys := make([]string, len(modes))
+		for i := range modes {
+			if modes[i].Key == key {
+				return &modes[i], nil
+			}
+			keys[i] = modes[i].Key
+		}
+		return nil, fmt.Errorf("%w: unknown mode %q (expected one of %s)", ErrInvalidCredentials, key, strings.Join(keys, ", "))
+	}
+
+	// Without an explicit mode, prefer the first complete mode, then the one
+	// that matches the most given fields so the error lists what is missing.
+	best, bestMatches := 0, -1
+	for i := range modes {
+		complete, matches := true, 0
+		for _, field := range modes[i].Fields {
+			if hasCredential(credentials, field.Key) {
+				matches++
+			} else if field.Required {
+				complete = false
+			}
+		}
+		if complete {
+			return &modes[i], nil
+		}
+		if matches > bestMatches {
+			best, bestMatches = i, matches
+		}
+	}
+	return &modes[best], nil
+}
+
+func hasCredential(credentials map[string]any, key string) bool {
+	value, ok := credentials[key]
+	if !ok || value == nil {
+		return false
+	}
+	if s, isString := value.(string); isString && strings.TrimSpace(s) == "" {
+		return false
+	}
+	return true
+}
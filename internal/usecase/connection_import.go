@@ -0,0 +1,167 @@
+package usecase
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/3-lines-studio/datafrost/internal/core/entity"
+	"github.com/3-lines-studio/datafrost/internal/usecase/port"
+)
+
+type ConnectionImportUsecase struct {
+	connections *ConnectionUsecase
+	repo        port.ConnectionRepository
+	reader      port.ConnectionSourceReader
+}
+
+func NewConnectionImportUsecase(
+	connections *ConnectionUsecase,
+	repo port.ConnectionRepository,
+	reader port.ConnectionSourceReader,
+) *ConnectionImportUsecase {
+	return &ConnectionImportUsecase{
+		connections: connections,
+		repo:        repo,
+		reader:      reader,
+	}
+}
+
+func (u *ConnectionImportUsecase) Sources() []string {
+	return u.reader.Sources()
+}
+
+func (u *ConnectionImportUsecase) Preview(req entity.ConnectionImportRequest) (*entity.ConnectionImportResult, error) {
+	return u.run(req, true)
+}
+
+func (u *ConnectionImportUsecase) Import(req entity.ConnectionImportRequest) (*entity.ConnectionImportResult, error) {
+	return u.run(req, false)
+}
+
+func (u *ConnectionImportUsecase) run(req entity.ConnectionImportRequest, dryRun bool) (*entity.ConnectionImportResult, error) {
+	if !slices.Contains(u.reader.Sources(), req.Source) {
+		return nil, ErrUnknownSource
+	}
+	onDuplicate := req.OnDuplicate
+	if onDuplicate == "" {
+		onDuplicate = entity.OnDuplicateSkip
+	}
+	switch onDuplicate {
+	case entity.OnDuplicateSkip, entity.OnDuplicateRename, entity.OnDuplicateReplace:
+	default:
+		return nil, ErrInvalidOnDuplicate
+	}
+
+	var content []byte
+	if req.Content != "" {
+		content = []byte(req.Content)
+	}
+	candidates, path, err := u.reader.Read(req.Source, req.Path, content)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrSourceUnreadable, err)
+	}
+
+	existing, err := u.repo.List()
+	if err != nil {
+		return nil, err
+	}
+	// connections.name is UNIQUE; names differing only in case are treated
+	// as duplicates too because lookups by name are case-insensitive.
+	byName := make(map[string]*entity.Connection, len(existing))
+	taken := make(map[string]bool, len(existing))
+	for i := range existing {
+		key := strings.ToLower(existing[i].Name)
+		byName[key] = &existing[i]
+		taken[key] = true
+	}
+
+	result := &entity.ConnectionImportResult{Source: req.Source, Path: path, DryRun: dryRun, Items: []entity.ConnectionImportItem{}}
+	for _, candidate := range candidates {
+		if len(req.Names) > 0 && !slices.Contains(req.Names, candidate.Name) {
+			continue
+		}
+
+		item := entity.ConnectionImportItem{
+			Name:       candidate.Name,
+			SourceName: candidate.Name,
+			Type:       candidate.Type,
+			Origin:     candidate.Origin,
+			Action:     entity.ImportActionCreate,
+			Warnings:   candidate.Warnings,
+		}
+
+		credentials, err := u.connections.ValidateCredentials(candidate.Type, candidate.Credentials)
+		if err == nil && candidate.Name == "" {
+			err = ErrNameRequired
+		}
+		if err != nil {
+			item.Action = entity.ImportActionInvalid
+			item.Error = err.Error()
+			result.Invalid++
+			result.Items = append(result.Items, item)
+			continue
+		}
+
+		var replace *entity.Connection
+		key := strings.ToLower(candidate.Name)
+		if taken[key] {
+			switch {
+			case onDuplicate == entity.OnDuplicateRename:
+				item.Name = uniqueConnectionName(candidate.Name, taken)
+				item.Action = entity.ImportActionRename
+			case onDuplicate == entity.OnDuplicateReplace && byName[key] != nil:
+				replace = byName[key]
+				// Each existing connection is replaced at most once per import.
+				delete(byName, key)
+				item.Name = replace.Name
+				item.Action = entity.ImportActionReplace
+				item.ConnectionID = replace.ID
+			default:
+				item.Action = entity.ImportActionSkip
+				result.Skipped++
+				result.Items = append(result.Items, item)
+				continue
+			}
+		}
+		taken[strings.ToLower(item.Name)] = true
+		trusted := importTrust(credentials, replace, req.TrustReferences)
+		if !trusted && hasLocalReference(credentials) {
+			item.Warnings = append(item.Warnings, untrustedReferenceWarning)
+		}
+
+		if !dryRun {
+			var conn *entity.Connection
+			if replace != nil {
+				conn, err = u.connections.Update(replace.ID, entity.UpdateConnectionRequest{Name: item.Name, Type: candidate.Type, Credentials: credentials, TrustReferences: &trusted})
+			} else {
+				conn, err = u.connections.Create(entity.CreateConnectionRequest{Name: item.Name, Type: candidate.Type, Credentials: credentials, TrustReferences: trusted})
+			}
+			if err != nil {
+				item.Action = entity.ImportActionInvalid
+				item.Error = err.Error()
+				result.Invalid++
+				result.Items = append(result.Items, item)
+				continue
+			}
+			item.ConnectionID = conn.ID
+		}
+
+		if replace != nil {
+			result.Replaced++
+		} else {
+			result.Created++
+		}
+		result.Items = append(result.Items, item)
+	}
+	return result, nil
+}
+
+func uniqueConnectionName(name string, taken map[string]bool) string {
+	for i := 2; ; i++ {
+		candidate := fmt.Sprintf("%s (%d)", name, i)
+		if !taken[strings.ToLower(candidate)] {
+			return candidate
+		}
+	}
+}
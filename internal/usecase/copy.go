@@ -0,0 +1,286 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/3-lines-studio/datafrost/internal/core/entity"
+	"github.com/3-lines-studio/datafrost/internal/usecase/port"
+)
+
+const defaultCopyBatchSize = 1000
+
+type CopyUsecase struct {
+	connRepo  port.ConnectionRepository
+	factory   port.AdapterFactory
+	cache     port.AdapterCache
+	metaCache port.MetadataCache
+	jobs      *JobUsecase
+}
+
+func NewCopyUsecase(
+	connRepo port.ConnectionRepository,
+	factory port.AdapterFactory,
+	cache port.AdapterCache,
+	metaCache port.MetadataCache,
+	jobs *JobUsecase,
+) *CopyUsecase {
+	return &CopyUsecase{
+		connRepo:  connRepo,
+		factory:   factory,
+		cache:     cache,
+		metaCache: metaCache,
+		jobs:      jobs,
+	}
+}
+
+func (u *CopyUsecase) Start(sourceID int64, req entity.CopyRequest) (*entity.Job, error) {
+	req.Query = strings.TrimSpace(req.Query)
+	if (req.Query == "") == (req.Table == "") {
+		return nil, ErrCopySourceInvalid
+	}
+	if req.TargetTable == "" {
+		req.TargetTable = req.Table
+	}
+	if req.TargetTable == "" {
+		return nil, ErrTableRequired
+	}
+	if req.Mode == "" {
+		req.Mode = "create"
+	}
+	if req.Mode != "create" && req.Mode != "replace" && req.Mode != "append" {
+		return nil, fmt.Errorf("%w: mode must be create, replace or append", ErrInvalidRequest)
+	}
+	if req.BatchSize <= 0 {
+		req.BatchSize = defaultCopyBatchSize
+	}
+	if sourceID == req.TargetConnectionID && req.Table == req.TargetTable {
+		return nil, fmt.Errorf("%w: source and target table are the same", ErrInvalidRequest)
+	}
+
+	sourceConn, err := u.connRepo.GetByID(sourceID)
+	if err != nil {
+		return nil, err
+	}
+	targetConn, err := u.connRepo.GetByID(req.TargetConnectionID)
+	if err != nil {
+		return nil, err
+	}
+	if sourceConn == nil || targetConn == nil {
+		return nil, ErrConnectionNotFound
+	}
+	if targetConn.Environment == entity.EnvironmentProd && !req.ConfirmProd {
+		return nil, ErrProdNotConfirmed
+	}
+
+	source, err := u.cache.Get(sourceConn)
+	if err != nil {
+		return nil, err
+	}
+	targetAdapter, err := u.cache.Get(targetConn)
+	if err != nil {
+		return nil, err
+	}
+	target, ok := targetAdapter.(entity.BulkWriter)
+	if !ok {
+		return nil, ErrCopyNotSupported
+	}
+
+	var sourceColumns map[string]entity.ColumnInfo
+	if req.Table != "" {
+		schema, err := source.GetTableSchema(req.Table)
+		if err != nil {
+			return nil, err
+		}
+		sourceColumns = make(map[string]entity.ColumnInfo, len(schema.Columns))
+		for _, col := range schema.Columns {
+			sourceColumns[col.Name] = col
+		}
+	}
+
+	job := u.jobs.start("copy", sourceID, targetConn.Name+":"+req.TargetTable, func(ctx context.Context, progress jobProgress) error {
+		ctx, cancel := context.WithCancel(ctx)
+		defer cancel()
+
+		pipe := newCopyPipe(ctx, req.BatchSize)
+		streamDone := make(chan error, 1)
+		go func() {
+			var err error
+			if req.Table != "" {
+				err = source.StreamTableData(ctx, req.Table, req.Filters, pipe)
+			} else {
+				err = source.StreamQuery(ctx, req.Query, pipe)
+			}
+			streamDone <- pipe.close(err)
+		}()
+
+		header, err := pipe.header()
+		if err != nil {
+			return err
+		}
+
+		insert := entity.BulkInsertRequest{
+			Table:        req.TargetTable,
+			DropExisting: req.Mode == "replace",
+			Columns:      make([]string, len(header)),
+		}
+		for i, col := range header {
+			insert.Columns[i] = col.Name
+		}
+		if req.Mode != "append" {
+			insert.Create = make([]entity.ColumnInfo, len(header))
+			for i, col := range header {
+				info, ok := sourceColumns[col.Name]
+				if !ok {
+					info = entity.ColumnInfo{Name: col.Name, Type: col.DatabaseType, Nullable: true}
+				}
+				info.Type, err = u.factory.MapColumnType(sourceConn.Type, targetConn.Type, info.Type)
+				if err != nil {
+					return err
+				}
+				info.DefaultValue = ""
+				insert.Create[i] = info
+			}
+		}
+
+		insertErr := target.BulkInsert(ctx, insert, pipe.next, progress)
+		cancel()
+		streamErr := <-streamDone
+		u.metaCache.Invalidate(targetConn.ID)
+
+		if insertErr != nil {
+			return insertErr
+		}
+		if streamErr != nil && streamErr != context.Canceled {
+			return streamErr
+		}
+		return nil
+	})
+
+	return &job, nil
+}
+
+type copyPipe struct {
+	ctx       context.Context
+	batchSize int
+	headerCh  chan []entity.ResultColumn
+	batches   chan [][]any
+	errCh     chan error
+	pending   [][]any
+	current   [][]any
+}
+
+func newCopyPipe(ctx context.Context, batchSize int) *copyPipe {
+	return &copyPipe{
+		ctx:       ctx,
+		batchSize: batchSize,
+		headerCh:  make(chan []entity.ResultColumn, 1),
+		batches:   make(chan [][]any, 4),
+		errCh:     make(chan error, 1),
+	}
+}
+
+func (p *copyPipe) WriteHeader(columns []entity.ResultColumn) error {
+	p.headerCh <- columns
+	return nil
+}
+
+func (p *copyPipe) WriteRow(values []any) error {
+	row := make([]any, len(values))
+	for i, val := range values {
+		row[i] = normalizeCopyValue(val)
+	}
+	p.pending = append(p.pending, row)
+	if len(p.pending) >= p.batchSize {
+		return p.flush()
+	}
+	return nil
+}
+
+func (p *copyPipe) flush() error {
+	if len(p.pending) == 0 {
+		return nil
+	}
+	select {
+	case p.batches <- p.pending:
+		p.pending = nil
+		return nil
+	case <-p.ctx.Done():
+		return p.ctx.Err()
+	}
+}
+
+func (p *copyPipe) close(streamErr error) error {
+	if streamErr == nil {
+		streamErr = p.flush()
+	}
+	p.errCh <- streamErr
+	close(p.batches)
+	close(p.headerCh)
+	return streamErr
+}
+
+func (p *copyPipe) header() ([]entity.ResultColumn, error) {
+	select {
+	case header, ok := <-p.headerCh:
+		if ok {
+			return header, nil
+		}
+		err := <-p.errCh
+		p.errCh <- err
+		if err == nil {
+			err = fmt.Errorf("source returned no columns")
+		}
+		return nil, err
+	case <-p.ctx.Done():
+		return nil, p.ctx.Err()
+	}
+}
+
+func (p *copyPipe) next() ([]any, error) {
+	for len(p.current) == 0 {
+		batch, ok := <-p.batches
+		if !ok {
+			err := <-p.errCh
+			p.errCh <- err
+			if err != nil {
+				return nil, err
+			}
+			return nil, io.EOF
+		}
+		p.current = batch
+	}
+
+	row := p.current[0]
+	p.current = p.current[1:]
+	return row, nil
+}
+
+func normalizeCopyValue(val any) any {
+	switch v := val.(type) {
+	case nil, int64, float64, bool, string, []byte, time.Time:
+		return v
+	case int:
+		return int64(v)
+	case int32:
+		return int64(v)
+	case int16:
+		return int64(v)
+	case float32:
+		return float64(v)
+	case [16]byte:
+		return fmt.Sprintf("%x-%x-%x-%x-%x", v[0:4], v[4:6], v[6:8], v[8:10], v[10:16])
+	case map[string]any, []any:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprintf("%v", v)
+		}
+		return string(data)
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
@@ -0,0 +1,105 @@
+package usecase
+
+import (
+	"path"
+	"sync"
+
+	"github.com/3-lines-studio/datafrost/internal/core/entity"
+)
+
+const schemaFetchConcurrency = 8
+
+func (u *TableUsecase) GetERDiagram(connectionID int64, filter entity.ERDFilter) (*entity.ERDiagram, error) {
+	if filter.TablePattern != "" {
+		if _, err := path.Match(filter.TablePattern, ""); err != nil {
+			return nil, ErrInvalidPattern
+		}
+	}
+
+	allTables, err := u.ListTables(connectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	var tables []entity.TableInfo
+	for _, t := range allTables {
+		if filter.Schema != "" && t.Schema != filter.Schema {
+			continue
+		}
+		if filter.TablePattern != "" {
+			if ok, _ := path.Match(filter.TablePattern, t.Name); !ok {
+				continue
+			}
+		}
+		tables = append(tables, t)
+	}
+
+	schemas := make([]*entity.TableSchema, len(tables))
+	errs := make([]error, len(tables))
+	forEachLimit(len(tables), schemaFetchConcurrency, func(i int) {
+		schemas[i], errs[i] = u.GetTableSchema(connectionID, tables[i].Name)
+	})
+
+	diagram := &entity.ERDiagram{
+		Tables:        []entity.ERDTable{},
+		Relationships: []entity.ERDRelationship{},
+	}
+	included := make(map[string]bool, len(tables))
+	for i, t := range tables {
+		if errs[i] != nil {
+			diagram.Errors = append(diagram.Errors, entity.ERDTableError{Table: t.Name, Error: errs[i].Error()})
+			continue
+		}
+		included[t.Name] = true
+
+		primaryKey := []string{}
+		for _, col := range schemas[i].Columns {
+			if col.IsPrimaryKey {
+				primaryKey = append(primaryKey, col.Name)
+			}
+		}
+
+		diagram.Tables = append(diagram.Tables, entity.ERDTable{
+			Name:       t.Name,
+			Schema:     t.Schema,
+			Type:       t.Type,
+			Columns:    schemas[i].Columns,
+			PrimaryKey: primaryKey,
+		})
+	}
+
+	for i, t := range tables {
+		if errs[i] != nil {
+			continue
+		}
+		for _, fk := range schemas[i].ForeignKeys {
+			if !included[fk.ReferencedTable] {
+				continue
+			}
+			diagram.Relationships = append(diagram.Relationships, entity.ERDRelationship{
+				Name:        fk.Name,
+				FromTable:   t.Name,
+				FromColumns: fk.Columns,
+				ToTable:     fk.ReferencedTable,
+				ToColumns:   fk.ReferencedColumns,
+			})
+		}
+	}
+
+	return diagram, nil
+}
+
+func forEachLimit(n, limit int, fn func(i int)) {
+	sem := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			fn(i)
+		}(i)
+	}
+	wg.Wait()
+}
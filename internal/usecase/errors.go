@@ -3,10 +3,37 @@ package usecase
 import "errors"
 
 var (
-	ErrConnectionNotFound = errors.New("connection not found")
-	ErrQueryNotFound      = errors.New("query not found")
-	ErrInvalidRequest     = errors.New("invalid request")
-	ErrNameRequired       = errors.New("name is required")
-	ErrTypeRequired       = errors.New("type is required")
-	ErrQueryRequired      = errors.New("query is required")
+	ErrConnectionNotFound   = errors.New("connection not found")
+	ErrQueryNotFound        = errors.New("query not found")
+	ErrInvalidRequest       = errors.New("invalid request")
+	ErrNameRequired         = errors.New("name is required")
+	ErrTypeRequired         = errors.New("type is required")
+	ErrQueryRequired        = errors.New("query is required")
+	ErrInvalidPattern       = errors.New("invalid table pattern")
+	ErrIndexInProgress      = errors.New("schema index is already being built")
+	ErrAnalyzeNotConfirmed  = errors.New("EXPLAIN ANALYZE executes the query; set confirm_analyze to run it")
+	ErrJobNotFound          = errors.New("job not found")
+	ErrExportSourceInvalid  = errors.New("exactly one of query, saved_query_id or table is required")
+	ErrFileExists           = errors.New("file already exists; set overwrite to replace it")
+	ErrExportPathNotAllowed = errors.New("exports can only be written to the export directory")
+	ErrImportNotSupported   = errors.New("import is only supported for local file connections")
+	ErrCopyNotSupported     = errors.New("this connection type cannot be a copy target")
+	ErrProdNotConfirmed     = errors.New("the target connection is in the prod environment; set confirm_prod to write to it")
+	ErrImportPathRequired   = errors.New("path is required")
+	ErrTableRequired        = errors.New("table is required")
+	ErrCopySourceInvalid    = errors.New("exactly one of query or table is required")
+	ErrKeyColumnsRequired   = errors.New("at least one key column is required")
+	ErrProfileSourceInvalid = errors.New("exactly one of query or table is required")
+	ErrColumnNotFound       = errors.New("column not found")
+	ErrTableNotFound        = errors.New("table not found")
+	ErrInvalidCredentials   = errors.New("invalid credentials")
+	ErrUnknownSource        = errors.New("unknown connection source")
+	ErrInvalidOnDuplicate   = errors.New("on_duplicate must be skip, rename or replace")
+	ErrSourceUnreadable     = errors.New("failed to read connection source")
+	ErrInvalidBundle        = errors.New("not a Datafrost workspace bundle")
+	ErrBundleTooNew         = errors.New("workspace bundle was written by a newer version of Datafrost")
+	ErrPassphraseRequired   = errors.New("bundle secrets are encrypted; a passphrase is required")
+	ErrSecretsUnreadable    = errors.New("failed to decrypt bundle secrets")
+	ErrInvalidEnvironment   = errors.New("environment must be dev, staging or prod")
+	ErrInvalidColor         = errors.New("color must be a hex value like #d93f0b")
 )
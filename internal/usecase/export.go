@@ -0,0 +1,224 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/3-lines-studio/datafrost/internal/core/entity"
+	"github.com/3-lines-studio/datafrost/internal/usecase/port"
+)
+
+var unsafeFileChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+type ExportUsecase struct {
+	connRepo       port.ConnectionRepository
+	savedQueryRepo port.SavedQueryRepository
+	cache          port.AdapterCache
+	writers        port.ExportWriterFactory
+	jobs           *JobUsecase
+}
+
+func NewExportUsecase(
+	connRepo port.ConnectionRepository,
+	savedQueryRepo port.SavedQueryRepository,
+	cache port.AdapterCache,
+	writers port.ExportWriterFactory,
+	jobs *JobUsecase,
+) *ExportUsecase {
+	return &ExportUsecase{
+		connRepo:       connRepo,
+		savedQueryRepo: savedQueryRepo,
+		cache:          cache,
+		writers:        writers,
+		jobs:           jobs,
+	}
+}
+
+func (u *ExportUsecase) Formats() []string {
+	return u.writers.Formats()
+}
+
+func (u *ExportUsecase) Start(connectionID int64, req entity.ExportRequest) (*entity.Job, error) {
+	req.Query = strings.TrimSpace(req.Query)
+	sources := 0
+	for _, set := range []bool{req.Query != "", req.SavedQueryID != 0, req.Table != ""} {
+		if set {
+			sources++
+		}
+	}
+	if sources != 1 {
+		return nil, ErrExportSourceInvalid
+	}
+	if req.Format == "" {
+		req.Format = "csv"
+	}
+	extension, err := u.writers.Extension(req.Format)
+	if err != nil {
+		return nil, err
+	}
+
+	conn, err := u.connRepo.GetByID(connectionID)
+	if err != nil {
+		return nil, err
+	}
+	if conn == nil {
+		return nil, ErrConnectionNotFound
+	}
+
+	baseName := req.Table
+	if req.SavedQueryID != 0 {
+		saved, err := u.savedQueryRepo.GetByID(req.SavedQueryID)
+		if err != nil {
+			return nil, err
+		}
+		if saved == nil || saved.ConnectionID != connectionID {
+			return nil, ErrQueryNotFound
+		}
+		req.Query = saved.Query
+		baseName = saved.Name
+	}
+	if baseName == "" {
+		baseName = conn.Name + "-query"
+	}
+
+	adapter, err := u.cache.Get(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	var schema *entity.TableSchema
+	typeHints := map[string]string{}
+	if req.Table != "" {
+		schema, err = adapter.GetTableSchema(req.Table)
+		if err != nil {
+			return nil, err
+		}
+		for _, col := range schema.Columns {
+			typeHints[col.Name] = col.Type
+		}
+	}
+
+	path, err := exportPath(req.Path, baseName, extension)
+	if err != nil {
+		return nil, err
+	}
+	if !req.Overwrite {
+		if _, err := os.Stat(path); err == nil {
+			return nil, ErrFileExists
+		}
+	}
+
+	writer, err := u.writers.Create(entity.ExportTarget{
+		Path:       path,
+		Format:     req.Format,
+		Options:    req.Options,
+		SourceType: conn.Type,
+		Table:      req.Table,
+		Schema:     schema,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	job := u.jobs.start("export", connectionID, path, func(ctx context.Context, progress jobProgress) error {
+		sink := &progressSink{ctx: ctx, sink: writer, progress: progress, typeHints: typeHints}
+
+		var streamErr error
+		if req.Table != "" {
+			streamErr = adapter.StreamTableData(ctx, req.Table, req.Filters, sink)
+		} else {
+			streamErr = adapter.StreamQuery(ctx, req.Query, sink)
+		}
+
+		if streamErr == nil {
+			streamErr = ctx.Err()
+		}
+		if streamErr != nil {
+			_ = writer.Discard()
+			return streamErr
+		}
+		return writer.Close()
+	})
+
+	return &job, nil
+}
+
+type progressSink struct {
+	ctx       context.Context
+	sink      entity.RowSink
+	progress  jobProgress
+	typeHints map[string]string
+	rows      int64
+}
+
+func (s *progressSink) WriteHeader(columns []entity.ResultColumn) error {
+	for i, col := range columns {
+		if col.DatabaseType == "" {
+			columns[i].DatabaseType = s.typeHints[col.Name]
+		}
+	}
+	return s.sink.WriteHeader(columns)
+}
+
+func (s *progressSink) WriteRow(values []any) error {
+	if err := s.ctx.Err(); err != nil {
+		return err
+	}
+	if err := s.sink.WriteRow(values); err != nil {
+		return err
+	}
+	s.rows++
+	s.progress(s.rows)
+	return nil
+}
+
+// The path comes from the API, so it may only name a file in the export
+// directory; anything else could overwrite files such as ~/.bashrc.
+func exportPath(path, baseName, extension string) (string, error) {
+	dir, err := exportDir()
+	if err != nil {
+		return "", err
+	}
+
+	if path != "" {
+		if strings.HasPrefix(path, "~/") {
+			home, err := os.UserHomeDir()
+			if err != nil {
+				return "", err
+			}
+			path = filepath.Join(home, path[2:])
+		} else if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+		path = filepath.Clean(path)
+		if filepath.Dir(path) != dir {
+			return "", ErrExportPathNotAllowed
+		}
+		return path, nil
+	}
+
+	base := strings.Trim(unsafeFileChars.ReplaceAllString(baseName, "_"), "_")
+	if base == "" {
+		base = "export"
+	}
+
+	name := fmt.Sprintf("%s-%s.%s", base, time.Now().Format("20060102-150405"), extension)
+	return filepath.Join(dir, name), nil
+}
+
+func exportDir() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(home, "Downloads")
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		dir = home
+	}
+	return filepath.Abs(dir)
+}
@@ -0,0 +1,380 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/3-lines-studio/datafrost/internal/core/entity"
+	"github.com/3-lines-studio/datafrost/internal/usecase/port"
+)
+
+const (
+	importSampleRows  = 1000
+	importPreviewRows = 20
+)
+
+var importDateLayouts = []string{"2006-01-02"}
+
+var importTimestampLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04",
+}
+
+type ImportUsecase struct {
+	connRepo  port.ConnectionRepository
+	factory   port.AdapterFactory
+	cache     port.AdapterCache
+	metaCache port.MetadataCache
+	readers   port.ImportReaderFactory
+	jobs      *JobUsecase
+}
+
+func NewImportUsecase(
+	connRepo port.ConnectionRepository,
+	factory port.AdapterFactory,
+	cache port.AdapterCache,
+	metaCache port.MetadataCache,
+	readers port.ImportReaderFactory,
+	jobs *JobUsecase,
+) *ImportUsecase {
+	return &ImportUsecase{
+		connRepo:  connRepo,
+		factory:   factory,
+		cache:     cache,
+		metaCache: metaCache,
+		readers:   readers,
+		jobs:      jobs,
+	}
+}
+
+func (u *ImportUsecase) SaveUpload(name string, r io.Reader) (*entity.ImportUpload, error) {
+	dir := filepath.Join(os.TempDir(), "datafrost-imports")
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, err
+	}
+
+	base := strings.Trim(unsafeFileChars.ReplaceAllString(filepath.Base(name), "_"), "_")
+	if base == "" {
+		base = "upload"
+	}
+
+	file, err := os.CreateTemp(dir, "*-"+base)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+
+	size, err := io.Copy(file, r)
+	if err != nil {
+		_ = os.Remove(file.Name())
+		return nil, err
+	}
+
+	return &entity.ImportUpload{Path: file.Name(), Name: name, Size: size}, nil
+}
+
+func (u *ImportUsecase) Preview(connectionID int64, req entity.ImportPreviewRequest) (*entity.ImportPreview, error) {
+	if _, _, err := u.bulkWriter(connectionID); err != nil {
+		return nil, err
+	}
+	if req.Path == "" {
+		return nil, ErrImportPathRequired
+	}
+
+	reader, err := u.readers.Open(req.Path, req.Options)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = reader.Close() }()
+
+	samples, err := sampleImportRows(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &entity.ImportPreview{
+		Format:      reader.Format(),
+		Columns:     inferImportColumns(reader.Columns(), samples),
+		Rows:        samples[:min(len(samples), importPreviewRows)],
+		SampledRows: len(samples),
+	}
+	return preview, nil
+}
+
+func (u *ImportUsecase) Start(connectionID int64, req entity.ImportRequest) (*entity.Job, error) {
+	if req.Path == "" {
+		return nil, ErrImportPathRequired
+	}
+	if strings.TrimSpace(req.Table) == "" {
+		return nil, ErrTableRequired
+	}
+
+	adapter, conn, err := u.bulkWriter(connectionID)
+	if err != nil {
+		return nil, err
+	}
+	if conn.Environment == entity.EnvironmentProd && !req.ConfirmProd {
+		return nil, ErrProdNotConfirmed
+	}
+
+	reader, err := u.readers.Open(req.Path, req.Options)
+	if err != nil {
+		return nil, err
+	}
+	samples, err := sampleImportRows(reader)
+	_ = reader.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	columns := inferImportColumns(reader.Columns(), samples)
+	overrides := map[string]string{}
+	for _, col := range req.Columns {
+		overrides[col.Name] = col.Type
+	}
+	for i, col := range columns {
+		if typ := overrides[col.Name]; typ != "" {
+			columns[i].Type = typ
+		}
+	}
+
+	names := make([]string, len(columns))
+	for i, col := range columns {
+		names[i] = col.Name
+	}
+
+	insert := entity.BulkInsertRequest{Table: req.Table, Columns: names}
+	if req.CreateTable {
+		insert.Create = make([]entity.ColumnInfo, len(columns))
+		for i, col := range columns {
+			insert.Create[i] = entity.ColumnInfo{Name: col.Name, Type: col.Type, Nullable: true}
+		}
+	} else {
+		schema, err := adapter.GetTableSchema(req.Table)
+		if err != nil {
+			return nil, err
+		}
+		if len(schema.Columns) == 0 {
+			return nil, fmt.Errorf("table %q does not exist", req.Table)
+		}
+
+		existing := map[string]bool{}
+		for _, col := range schema.Columns {
+			existing[col.Name] = true
+		}
+		var missing []string
+		for _, name := range names {
+			if !existing[name] {
+				missing = append(missing, name)
+			}
+		}
+		if len(missing) > 0 {
+			return nil, fmt.Errorf("columns not found in %q: %s", req.Table, strings.Join(missing, ", "))
+		}
+	}
+
+	job := u.jobs.start("import", connectionID, req.Path, func(ctx context.Context, progress jobProgress) error {
+		reader, err := u.readers.Open(req.Path, req.Options)
+		if err != nil {
+			return err
+		}
+		defer func() { _ = reader.Close() }()
+
+		next := func() ([]any, error) {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			row, err := reader.Next()
+			if err != nil {
+				return nil, err
+			}
+			for i, val := range row {
+				row[i] = convertImportValue(val, columns[i].Type)
+			}
+			return row, nil
+		}
+
+		err = adapter.BulkInsert(ctx, insert, next, progress)
+		u.metaCache.Invalidate(connectionID)
+		return err
+	})
+
+	return &job, nil
+}
+
+type importTarget interface {
+	entity.DatabaseAdapter
+	entity.BulkWriter
+}
+
+// Checked against the adapter's registration rather than its methods: remote
+// adapters implement BulkInsert for copy, but an import must never reach them.
+func (u *ImportUsecase) bulkWriter(connectionID int64) (importTarget, *entity.Connection, error) {
+	conn, err := u.connRepo.GetByID(connectionID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if conn == nil {
+		return nil, nil, ErrConnectionNotFound
+	}
+	info, err := u.factory.GetAdapterInfo(conn.Type)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !info.LocalFile {
+		return nil, nil, ErrImportNotSupported
+	}
+	adapter, err := u.cache.Get(conn)
+	if err != nil {
+		return nil, nil, err
+	}
+	target, ok := adapter.(importTarget)
+	if !ok {
+		return nil, nil, ErrImportNotSupported
+	}
+	return target, conn, nil
+}
+
+func sampleImportRows(reader port.ImportReader) ([][]any, error) {
+	var samples [][]any
+	for len(samples) < importSampleRows {
+		row, err := reader.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		samples = append(samples, row)
+	}
+	return samples, nil
+}
+
+func inferImportColumns(names []string, samples [][]any) []entity.ImportColumn {
+	columns := make([]entity.ImportColumn, len(names))
+	for i, name := range names {
+		values := make([]any, 0, len(samples))
+		for _, row := range samples {
+			values = append(values, row[i])
+		}
+		columns[i] = entity.ImportColumn{Name: name, Type: inferImportType(values)}
+	}
+	return columns
+}
+
+func inferImportType(values []any) string {
+	candidates := map[string]bool{
+		"INTEGER": true, "REAL": true, "BOOLEAN": true, "DATE": true, "DATETIME": true,
+	}
+	seen := false
+
+	for _, val := range values {
+		switch v := val.(type) {
+		case nil:
+			continue
+		case int64:
+			seen = true
+			candidates["BOOLEAN"], candidates["DATE"], candidates["DATETIME"] = false, false, false
+		case float64:
+			seen = true
+			candidates["INTEGER"], candidates["BOOLEAN"], candidates["DATE"], candidates["DATETIME"] = false, false, false, false
+		case bool:
+			seen = true
+			candidates["INTEGER"], candidates["REAL"], candidates["DATE"], candidates["DATETIME"] = false, false, false, false
+		case string:
+			s := strings.TrimSpace(v)
+			if s == "" {
+				continue
+			}
+			seen = true
+			if _, err := strconv.ParseInt(s, 10, 64); err != nil {
+				candidates["INTEGER"] = false
+			}
+			if _, err := strconv.ParseFloat(s, 64); err != nil {
+				candidates["REAL"] = false
+			}
+			if !isImportBool(s) {
+				candidates["BOOLEAN"] = false
+			}
+			if !parsesAs(s, importDateLayouts) {
+				candidates["DATE"] = false
+			}
+			if !parsesAs(s, importTimestampLayouts) {
+				candidates["DATETIME"] = false
+			}
+		default:
+			return "TEXT"
+		}
+	}
+
+	if !seen {
+		return "TEXT"
+	}
+	for _, typ := range []string{"BOOLEAN", "INTEGER", "REAL", "DATE", "DATETIME"} {
+		if candidates[typ] {
+			return typ
+		}
+	}
+	return "TEXT"
+}
+
+func isImportBool(s string) bool {
+	switch strings.ToLower(s) {
+	case "true", "false", "yes", "no":
+		return true
+	}
+	return false
+}
+
+func parsesAs(s string, layouts []string) bool {
+	for _, layout := range layouts {
+		if _, err := time.Parse(layout, s); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+func convertImportValue(val any, typ string) any {
+	s, ok := val.(string)
+	if !ok {
+		return val
+	}
+
+	kind := strings.ToUpper(typ)
+	if strings.TrimSpace(s) == "" {
+		if strings.Contains(kind, "CHAR") || strings.Contains(kind, "TEXT") || strings.Contains(kind, "CLOB") {
+			return s
+		}
+		return nil
+	}
+
+	trimmed := strings.TrimSpace(s)
+	switch {
+	case strings.Contains(kind, "INT"):
+		if n, err := strconv.ParseInt(trimmed, 10, 64); err == nil {
+			return n
+		}
+	case strings.Contains(kind, "REAL"), strings.Contains(kind, "FLOA"), strings.Contains(kind, "DOUB"),
+		strings.Contains(kind, "NUMERIC"), strings.Contains(kind, "DECIMAL"):
+		if f, err := strconv.ParseFloat(trimmed, 64); err == nil {
+			return f
+		}
+	case strings.Contains(kind, "BOOL"):
+		switch strings.ToLower(trimmed) {
+		case "true", "yes", "1":
+			return true
+		case "false", "no", "0":
+			return false
+		}
+	}
+	return s
+}
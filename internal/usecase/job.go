@@ -0,0 +1,145 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/3-lines-studio/datafrost/internal/core/entity"
+)
+
+const finishedJobRetention = time.Hour
+
+type jobState struct {
+	job    entity.Job
+	rows   atomic.Int64
+	cancel context.CancelFunc
+}
+
+type JobUsecase struct {
+	mu   sync.Mutex
+	jobs map[string]*jobState
+}
+
+func NewJobUsecase() *JobUsecase {
+	return &JobUsecase{jobs: make(map[string]*jobState)}
+}
+
+type jobProgress func(rows int64)
+
+func (u *JobUsecase) start(kind string, connectionID int64, path string, run func(ctx context.Context, progress jobProgress) error) entity.Job {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	state := &jobState{
+		job: entity.Job{
+			ID:           newJobID(),
+			Kind:         kind,
+			ConnectionID: connectionID,
+			Status:       entity.JobStatusRunning,
+			Path:         path,
+			StartedAt:    time.Now(),
+		},
+		cancel: cancel,
+	}
+
+	u.mu.Lock()
+	u.pruneLocked()
+	u.jobs[state.job.ID] = state
+	u.mu.Unlock()
+
+	go func() {
+		defer cancel()
+		err := run(ctx, func(rows int64) { state.rows.Store(rows) })
+
+		u.mu.Lock()
+		defer u.mu.Unlock()
+		now := time.Now()
+		state.job.FinishedAt = &now
+		// A cancel that arrives after run finished cleanly does not undo the
+		// written result.
+		switch {
+		case err == nil:
+			state.job.Status = entity.JobStatusCompleted
+		case errors.Is(ctx.Err(), context.Canceled):
+			state.job.Status = entity.JobStatusCancelled
+		default:
+			state.job.Status = entity.JobStatusFailed
+			state.job.Error = err.Error()
+		}
+	}()
+
+	return u.snapshotLocked(state)
+}
+
+func (u *JobUsecase) Get(id string) (*entity.Job, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	state, ok := u.jobs[id]
+	if !ok {
+		return nil, ErrJobNotFound
+	}
+	job := u.snapshotLocked(state)
+	return &job, nil
+}
+
+func (u *JobUsecase) List() []entity.Job {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	jobs := make([]entity.Job, 0, len(u.jobs))
+	for _, state := range u.jobs {
+		jobs = append(jobs, u.snapshotLocked(state))
+	}
+	sort.Slice(jobs, func(i, j int) bool {
+		return jobs[i].StartedAt.After(jobs[j].StartedAt)
+	})
+	return jobs
+}
+
+func (u *JobUsecase) Running() int {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	running := 0
+	for _, state := range u.jobs {
+		if state.job.Status == entity.JobStatusRunning {
+			running++
+		}
+	}
+	return running
+}
+
+func (u *JobUsecase) Cancel(id string) (*entity.Job, error) {
+	u.mu.Lock()
+	state, ok := u.jobs[id]
+	u.mu.Unlock()
+	if !ok {
+		return nil, ErrJobNotFound
+	}
+	state.cancel()
+	return u.Get(id)
+}
+
+func (u *JobUsecase) snapshotLocked(state *jobState) entity.Job {
+	job := state.job
+	job.RowsProcessed = state.rows.Load()
+	return job
+}
+
+func (u *JobUsecase) pruneLocked() {
+	cutoff := time.Now().Add(-finishedJobRetention)
+	for id, state := range u.jobs {
+		if state.job.FinishedAt != nil && state.job.FinishedAt.Before(cutoff) {
+			delete(u.jobs, id)
+		}
+	}
+}
+
+func newJobID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
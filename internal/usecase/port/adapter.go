@@ -5,6 +5,12 @@ import "github.com/3-lines-studio/datafrost/internal/core/entity"
 type AdapterFactory interface {
 	GetAdapter(adapterType string) (entity.DatabaseAdapter, error)
 	GetAdapterInfo(adapterType string) (entity.AdapterInfo, error)
+	GetDialect(adapterType string) (entity.SQLDialect, error)
+	MapColumnType(fromType, toType, columnType string) (string, error)
 	ListAdapters() []entity.AdapterInfo
-	TestConnection(adapterType string, credentials map[string]any) error
+	TestConnection(adapterType string, credentials map[string]any, trusted bool) error
+}
+
+type CredentialResolver interface {
+	Resolve(credentials map[string]any, trusted bool, secretFields map[string]bool) (map[string]any, error)
 }
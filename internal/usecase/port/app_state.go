@@ -3,4 +3,6 @@ package port
 type AppStateRepository interface {
 	Get(key string) (string, error)
 	Set(key, value string) error
+	Delete(key string) error
+	List(prefix string) (map[string]string, error)
 }
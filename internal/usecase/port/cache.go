@@ -3,7 +3,8 @@ package port
 import "github.com/3-lines-studio/datafrost/internal/core/entity"
 
 type AdapterCache interface {
-	Get(id int64, connType string, credentials map[string]any) (entity.DatabaseAdapter, error)
+	Get(conn *entity.Connection) (entity.DatabaseAdapter, error)
+	IsOpen(id int64) bool
 	Invalidate(id int64)
 	Close()
 }
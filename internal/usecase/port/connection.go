@@ -8,6 +8,7 @@ type ConnectionRepository interface {
 	List() ([]entity.Connection, error)
 	Delete(id int64) error
 	Update(id int64, req entity.UpdateConnectionRequest) (*entity.Connection, error)
+	Reorder(ids []int64) error
 	SetLastConnected(id int64) error
 	GetLastConnected() (int64, error)
 }
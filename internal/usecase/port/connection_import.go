@@ -0,0 +1,8 @@
+package port
+
+import "github.com/3-lines-studio/datafrost/internal/core/entity"
+
+type ConnectionSourceReader interface {
+	Sources() []string
+	Read(source, path string, content []byte) ([]entity.ConnectionCandidate, string, error)
+}
@@ -0,0 +1,24 @@
+package port
+
+import (
+	"io"
+
+	"github.com/3-lines-studio/datafrost/internal/core/entity"
+)
+
+type ExportWriter interface {
+	entity.RowSink
+	Close() error
+}
+
+type ExportFileWriter interface {
+	ExportWriter
+	Discard() error
+}
+
+type ExportWriterFactory interface {
+	Formats() []string
+	Extension(format string) (string, error)
+	Create(target entity.ExportTarget) (ExportFileWriter, error)
+	CreateStream(w io.Writer, target entity.ExportTarget) (ExportWriter, error)
+}
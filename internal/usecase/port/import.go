@@ -0,0 +1,14 @@
+package port
+
+import "github.com/3-lines-studio/datafrost/internal/core/entity"
+
+type ImportReader interface {
+	Format() string
+	Columns() []string
+	Next() ([]any, error)
+	Close() error
+}
+
+type ImportReaderFactory interface {
+	Open(path string, options entity.ImportOptions) (ImportReader, error)
+}
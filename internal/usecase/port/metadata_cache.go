@@ -0,0 +1,18 @@
+package port
+
+import (
+	"time"
+
+	"github.com/3-lines-studio/datafrost/internal/core/entity"
+)
+
+type MetadataCache interface {
+	GetTables(id int64, maxAge time.Duration) ([]entity.TableInfo, bool)
+	SetTables(id int64, tables []entity.TableInfo)
+	GetTableSchema(id int64, tableName string, maxAge time.Duration) (*entity.TableSchema, bool)
+	SetTableSchema(id int64, tableName string, schema *entity.TableSchema)
+	GetTTL(id int64) (time.Duration, bool)
+	SetTTL(id int64, ttl time.Duration)
+	Invalidate(id int64)
+	Remove(id int64)
+}
@@ -0,0 +1,7 @@
+package port
+
+import "github.com/3-lines-studio/datafrost/internal/core/entity"
+
+type MigrationGenerator interface {
+	MigrationScript(sourceType, targetType string, diff *entity.SchemaDiff, missing []*entity.TableSchema) (string, error)
+}
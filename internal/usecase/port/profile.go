@@ -0,0 +1,11 @@
+package port
+
+import "github.com/3-lines-studio/datafrost/internal/core/entity"
+
+type ProfileQueryBuilder interface {
+	Source(conn *entity.Connection, table, query string) (string, error)
+	ColumnKind(adapterType, columnType string) string
+	StatsQuery(adapterType, source, column, columnType string, approximate bool) (query string, approximateDistinct bool, err error)
+	TopValuesQuery(adapterType, source, column string, limit int) (string, error)
+	HistogramQuery(adapterType, source, column string, min, max float64, buckets int) (string, error)
+}
@@ -0,0 +1,14 @@
+package port
+
+import (
+	"time"
+
+	"github.com/3-lines-studio/datafrost/internal/core/entity"
+)
+
+type SchemaIndexRepository interface {
+	Replace(connectionID int64, entries []entity.SchemaIndexEntry) error
+	Search(connectionID int64, term string, limit int) ([]entity.SearchResult, error)
+	IndexedAt(connectionID int64) (*time.Time, error)
+	Delete(connectionID int64) error
+}
@@ -0,0 +1,8 @@
+package port
+
+import "github.com/3-lines-studio/datafrost/internal/core/entity"
+
+type SecretSealer interface {
+	Seal(plaintext []byte, passphrase string) (*entity.SealedSecrets, error)
+	Open(sealed entity.SealedSecrets, passphrase string) ([]byte, error)
+}
@@ -0,0 +1,329 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/3-lines-studio/datafrost/internal/core/entity"
+	"github.com/3-lines-studio/datafrost/internal/usecase/port"
+)
+
+const (
+	defaultProfileTopN    = 10
+	maxProfileTopN        = 100
+	defaultProfileBuckets = 10
+	maxProfileBuckets     = 100
+	profileConcurrency    = 4
+)
+
+var errProfileHeaderRead = errors.New("profile header read")
+
+type ProfileUsecase struct {
+	connRepo port.ConnectionRepository
+	cache    port.AdapterCache
+	tables   *TableUsecase
+	builder  port.ProfileQueryBuilder
+}
+
+func NewProfileUsecase(
+	connRepo port.ConnectionRepository,
+	cache port.AdapterCache,
+	tables *TableUsecase,
+	builder port.ProfileQueryBuilder,
+) *ProfileUsecase {
+	return &ProfileUsecase{
+		connRepo: connRepo,
+		cache:    cache,
+		tables:   tables,
+		builder:  builder,
+	}
+}
+
+func (u *ProfileUsecase) Profile(ctx context.Context, connectionID int64, req entity.ProfileRequest) (*entity.ProfileReport, error) {
+	req.Query = strings.TrimSpace(req.Query)
+	if (req.Table == "") == (req.Query == "") {
+		return nil, ErrProfileSourceInvalid
+	}
+
+	topN := req.TopN
+	if topN <= 0 {
+		topN = defaultProfileTopN
+	}
+	if topN > maxProfileTopN {
+		topN = maxProfileTopN
+	}
+	buckets := req.Buckets
+	if buckets <= 0 {
+		buckets = defaultProfileBuckets
+	}
+	if buckets > maxProfileBuckets {
+		buckets = maxProfileBuckets
+	}
+
+	conn, err := u.connRepo.GetByID(connectionID)
+	if err != nil {
+		return nil, err
+	}
+	if conn == nil {
+		return nil, ErrConnectionNotFound
+	}
+
+	adapter, err := u.cache.Get(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	source, err := u.builder.Source(conn, req.Table, req.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	columns, err := u.columns(ctx, connectionID, adapter, source, req.Table)
+	if err != nil {
+		return nil, err
+	}
+	if len(req.Columns) > 0 {
+		byName := make(map[string]entity.ResultColumn, len(columns))
+		for _, col := range columns {
+			byName[col.Name] = col
+		}
+		selected := make([]entity.ResultColumn, 0, len(req.Columns))
+		for _, name := range req.Columns {
+			col, ok := byName[name]
+			if !ok {
+				return nil, ErrColumnNotFound
+			}
+			selected = append(selected, col)
+		}
+		columns = selected
+	}
+
+	countResult, err := adapter.ExecuteQuery("SELECT COUNT(*) FROM " + source)
+	if err != nil {
+		return nil, err
+	}
+	var rows int64
+	if len(countResult.Rows) > 0 && len(countResult.Rows[0]) > 0 {
+		rows, _ = profileInt(countResult.Rows[0][0])
+	}
+
+	profiles := make([]entity.ColumnProfile, len(columns))
+	forEachLimit(len(columns), profileConcurrency, func(i int) {
+		if err := ctx.Err(); err != nil {
+			profiles[i] = entity.ColumnProfile{Name: columns[i].Name, Type: columns[i].DatabaseType, Error: err.Error()}
+			return
+		}
+		profiles[i] = u.profileColumn(adapter, conn.Type, source, columns[i], rows, req.Approximate, topN, buckets)
+	})
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return &entity.ProfileReport{
+		Table:      req.Table,
+		Query:      req.Query,
+		Dialect:    conn.Type,
+		Rows:       rows,
+		Columns:    profiles,
+		DurationMs: time.Since(start).Milliseconds(),
+	}, nil
+}
+
+func (u *ProfileUsecase) columns(ctx context.Context, connectionID int64, adapter entity.DatabaseAdapter, source, table string) ([]entity.ResultColumn, error) {
+	if table != "" {
+		schema, err := u.tables.GetTableSchema(connectionID, table)
+		if err != nil {
+			return nil, err
+		}
+		columns := make([]entity.ResultColumn, len(schema.Columns))
+		for i, col := range schema.Columns {
+			columns[i] = entity.ResultColumn{Name: col.Name, DatabaseType: col.Type}
+		}
+		return columns, nil
+	}
+
+	sink := &profileHeaderSink{}
+	err := adapter.StreamQuery(ctx, "SELECT * FROM "+source+" LIMIT 0", sink)
+	if err != nil && err != errProfileHeaderRead {
+		return nil, err
+	}
+	return sink.columns, nil
+}
+
+func (u *ProfileUsecase) profileColumn(adapter entity.DatabaseAdapter, adapterType, source string, column entity.ResultColumn, rows int64, approximate bool, topN, buckets int) entity.ColumnProfile {
+	profile := entity.ColumnProfile{
+		Name: column.Name,
+		Type: column.DatabaseType,
+		Kind: u.builder.ColumnKind(adapterType, column.DatabaseType),
+	}
+
+	query, approximateDistinct, err := u.builder.StatsQuery(adapterType, source, column.Name, column.DatabaseType, approximate)
+	if err != nil {
+		profile.Error = err.Error()
+		return profile
+	}
+	result, err := adapter.ExecuteQuery(query)
+	if err != nil {
+		profile.Error = err.Error()
+		return profile
+	}
+	if len(result.Rows) == 0 || len(result.Rows[0]) < 5 {
+		profile.Error = "no statistics returned"
+		return profile
+	}
+
+	stats := result.Rows[0]
+	profile.NullCount, _ = profileInt(stats[0])
+	if distinct, ok := profileInt(stats[1]); ok {
+		profile.DistinctCount = &distinct
+		profile.DistinctApproximate = approximateDistinct
+	}
+	profile.Min = normalizeCopyValue(stats[2])
+	profile.Max = normalizeCopyValue(stats[3])
+	if avg, ok := profileFloat(stats[4]); ok {
+		profile.Average = &avg
+	}
+
+	if profile.Kind != entity.ProfileKindComplex && profile.Kind != entity.ProfileKindBinary {
+		topValues, err := u.topValues(adapter, adapterType, source, column.Name, topN)
+		if err != nil {
+			profile.Error = err.Error()
+			return profile
+		}
+		profile.TopValues = topValues
+	}
+
+	if profile.Kind == entity.ProfileKindNumeric {
+		min, minOK := profileFloat(stats[2])
+		max, maxOK := profileFloat(stats[3])
+		if minOK && maxOK {
+			histogram, err := u.histogram(adapter, adapterType, source, column.Name, min, max, buckets, rows-profile.NullCount)
+			if err != nil {
+				profile.Error = err.Error()
+				return profile
+			}
+			profile.Histogram = histogram
+		}
+	}
+
+	return profile
+}
+
+func (u *ProfileUsecase) topValues(adapter entity.DatabaseAdapter, adapterType, source, column string, limit int) ([]entity.ProfileValueCount, error) {
+	query, err := u.builder.TopValuesQuery(adapterType, source, column, limit)
+	if err != nil {
+		return nil, err
+	}
+	result, err := adapter.ExecuteQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]entity.ProfileValueCount, 0, len(result.Rows))
+	for _, row := range result.Rows {
+		if len(row) < 2 {
+			continue
+		}
+		count, _ := profileInt(row[1])
+		values = append(values, entity.ProfileValueCount{Value: normalizeCopyValue(row[0]), Count: count})
+	}
+	return values, nil
+}
+
+func (u *ProfileUsecase) histogram(adapter entity.DatabaseAdapter, adapterType, source, column string, min, max float64, buckets int, nonNull int64) ([]entity.HistogramBucket, error) {
+	if max <= min {
+		return []entity.HistogramBucket{{Lower: min, Upper: max, Count: nonNull}}, nil
+	}
+
+	query, err := u.builder.HistogramQuery(adapterType, source, column, min, max, buckets)
+	if err != nil {
+		return nil, err
+	}
+	result, err := adapter.ExecuteQuery(query)
+	if err != nil {
+		return nil, err
+	}
+
+	width := (max - min) / float64(buckets)
+	histogram := make([]entity.HistogramBucket, buckets)
+	for i := range histogram {
+		histogram[i].Lower = min + float64(i)*width
+		histogram[i].Upper = min + float64(i+1)*width
+	}
+	histogram[buckets-1].Upper = max
+
+	for _, row := range result.Rows {
+		if len(row) < 2 {
+			continue
+		}
+		bucket, ok := profileInt(row[0])
+		if !ok || bucket < 0 || bucket >= int64(buckets) {
+			continue
+		}
+		histogram[bucket].Count, _ = profileInt(row[1])
+	}
+	return histogram, nil
+}
+
+type profileHeaderSink struct {
+	columns []entity.ResultColumn
+}
+
+func (s *profileHeaderSink) WriteHeader(columns []entity.ResultColumn) error {
+	s.columns = columns
+	return nil
+}
+
+func (s *profileHeaderSink) WriteRow(values []any) error {
+	return errProfileHeaderRead
+}
+
+func profileInt(val any) (int64, bool) {
+	switch v := val.(type) {
+	case int64:
+		return v, true
+	case int:
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	case float64:
+		return int64(v), true
+	case string:
+		n, err := strconv.ParseInt(v, 10, 64)
+		return n, err == nil
+	case []byte:
+		n, err := strconv.ParseInt(string(v), 10, 64)
+		return n, err == nil
+	}
+	return 0, false
+}
+
+func profileFloat(val any) (float64, bool) {
+	switch v := val.(type) {
+	case float64:
+		return v, true
+	case float32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case int:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		return f, err == nil
+	case []byte:
+		f, err := strconv.ParseFloat(string(v), 64)
+		return f, err == nil
+	case fmt.Stringer:
+		f, err := strconv.ParseFloat(v.String(), 64)
+		return f, err == nil
+	}
+	return 0, false
+}
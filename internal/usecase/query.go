@@ -1,6 +1,9 @@
 package usecase
 
 import (
+	"context"
+	"strings"
+
 	"github.com/3-lines-studio/datafrost/internal/core/entity"
 	"github.com/3-lines-studio/datafrost/internal/usecase/port"
 )
@@ -31,9 +34,48 @@ func (u *QueryUsecase) Execute(connectionID int64, query string) (*entity.QueryR
 	if conn == nil {
 		return nil, ErrConnectionNotFound
 	}
-	adapter, err := u.cache.Get(conn.ID, conn.Type, conn.Credentials)
+	adapter, err := u.cache.Get(conn)
 	if err != nil {
 		return nil, err
 	}
 	return adapter.ExecuteQuery(query)
 }
+
+func (u *QueryUsecase) Stream(ctx context.Context, connectionID int64, query string, sink entity.RowSink) error {
+	if strings.TrimSpace(query) == "" {
+		return ErrQueryRequired
+	}
+	conn, err := u.connRepo.GetByID(connectionID)
+	if err != nil {
+		return err
+	}
+	if conn == nil {
+		return ErrConnectionNotFound
+	}
+	adapter, err := u.cache.Get(conn)
+	if err != nil {
+		return err
+	}
+	return adapter.StreamQuery(ctx, query, sink)
+}
+
+func (u *QueryUsecase) Explain(connectionID int64, req entity.ExplainRequest) (*entity.QueryPlan, error) {
+	if req.Query == "" {
+		return nil, ErrQueryRequired
+	}
+	if req.Analyze && !req.ConfirmAnalyze {
+		return nil, ErrAnalyzeNotConfirmed
+	}
+	conn, err := u.connRepo.GetByID(connectionID)
+	if err != nil {
+		return nil, err
+	}
+	if conn == nil {
+		return nil, ErrConnectionNotFound
+	}
+	adapter, err := u.cache.Get(conn)
+	if err != nil {
+		return nil, err
+	}
+	return adapter.Explain(req.Query, req.Analyze)
+}
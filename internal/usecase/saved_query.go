@@ -1,6 +1,8 @@
 package usecase
 
 import (
+	"strings"
+
 	"github.com/3-lines-studio/datafrost/internal/core/entity"
 	"github.com/3-lines-studio/datafrost/internal/usecase/port"
 )
@@ -47,3 +49,21 @@ func (u *SavedQueryUsecase) Update(id int64, name, query string) (*entity.SavedQ
 func (u *SavedQueryUsecase) Delete(id int64) error {
 	return u.repo.Delete(id)
 }
+
+func (u *SavedQueryUsecase) FindByName(connectionID int64, name string) (*entity.SavedQuery, error) {
+	queries, err := u.repo.ListByConnection(connectionID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range queries {
+		if queries[i].Name == name {
+			return &queries[i], nil
+		}
+	}
+	for i := range queries {
+		if strings.EqualFold(queries[i].Name, name) {
+			return &queries[i], nil
+		}
+	}
+	return nil, ErrQueryNotFound
+}
@@ -0,0 +1,434 @@
+package usecase
+
+import (
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/3-lines-studio/datafrost/internal/core/entity"
+	"github.com/3-lines-studio/datafrost/internal/usecase/port"
+)
+
+type SchemaDiffUsecase struct {
+	connRepo port.ConnectionRepository
+	factory  port.AdapterFactory
+	tables   *TableUsecase
+	migrator port.MigrationGenerator
+}
+
+func NewSchemaDiffUsecase(
+	connRepo port.ConnectionRepository,
+	factory port.AdapterFactory,
+	tables *TableUsecase,
+	migrator port.MigrationGenerator,
+) *SchemaDiffUsecase {
+	return &SchemaDiffUsecase{
+		connRepo: connRepo,
+		factory:  factory,
+		tables:   tables,
+		migrator: migrator,
+	}
+}
+
+func (u *SchemaDiffUsecase) Diff(req entity.SchemaDiffRequest) (*entity.SchemaDiff, error) {
+	if req.TablePattern != "" {
+		if _, err := path.Match(req.TablePattern, ""); err != nil {
+			return nil, ErrInvalidPattern
+		}
+	}
+
+	sourceConn, err := u.connRepo.GetByID(req.SourceConnectionID)
+	if err != nil {
+		return nil, err
+	}
+	targetConn, err := u.connRepo.GetByID(req.TargetConnectionID)
+	if err != nil {
+		return nil, err
+	}
+	if sourceConn == nil || targetConn == nil {
+		return nil, ErrConnectionNotFound
+	}
+
+	if req.Refresh {
+		if _, err := u.tables.RefreshMetadata(sourceConn.ID); err != nil {
+			return nil, err
+		}
+		if _, err := u.tables.RefreshMetadata(targetConn.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	filter := entity.ERDFilter{Schema: req.Schema, TablePattern: req.TablePattern}
+	sourceSchemas, sourceErrs, err := u.loadSchemas(sourceConn.ID, filter)
+	if err != nil {
+		return nil, err
+	}
+	targetSchemas, targetErrs, err := u.loadSchemas(targetConn.ID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	diff := &entity.SchemaDiff{
+		SourceConnectionID: sourceConn.ID,
+		TargetConnectionID: targetConn.ID,
+		MissingTables:      []string{},
+		ExtraTables:        []string{},
+		Tables:             []entity.TableDiff{},
+		Errors:             append(sourceErrs, targetErrs...),
+	}
+
+	sameDialect := sourceConn.Type == targetConn.Type
+	pairs := matchTables(sourceSchemas, targetSchemas)
+	sourceNames, targetNames := displayNames(sourceSchemas), displayNames(targetSchemas)
+	pairedTargets := make(map[string]bool, len(pairs))
+	var missing []*entity.TableSchema
+	for _, key := range sortedKeys(sourceSchemas) {
+		targetKey, ok := pairs[key]
+		if !ok {
+			diff.MissingTables = append(diff.MissingTables, sourceNames[key])
+			missing = append(missing, sourceSchemas[key])
+			continue
+		}
+		pairedTargets[targetKey] = true
+		if tableDiff, changed := u.diffTable(sourceConn.Type, targetConn.Type, sameDialect, sourceSchemas[key], targetSchemas[targetKey]); changed {
+			tableDiff.Table = sourceNames[key]
+			diff.Tables = append(diff.Tables, tableDiff)
+		}
+	}
+	for _, key := range sortedKeys(targetSchemas) {
+		if !pairedTargets[key] {
+			diff.ExtraTables = append(diff.ExtraTables, targetNames[key])
+		}
+	}
+
+	if req.GenerateScript {
+		diff.Dialect = targetConn.Type
+		diff.Script, err = u.migrator.MigrationScript(sourceConn.Type, targetConn.Type, diff, missing)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return diff, nil
+}
+
+func (u *SchemaDiffUsecase) loadSchemas(connectionID int64, filter entity.ERDFilter) (map[string]*entity.TableSchema, []entity.ERDTableError, error) {
+	allTables, err := u.tables.ListTables(connectionID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var tables []entity.TableInfo
+	seen := map[string]bool{}
+	for _, t := range allTables {
+		if t.Type != "" && t.Type != "table" && !strings.EqualFold(t.Type, "BASE TABLE") {
+			continue
+		}
+		if filter.Schema != "" && t.Schema != filter.Schema {
+			continue
+		}
+		if filter.TablePattern != "" {
+			if ok, _ := path.Match(filter.TablePattern, t.Name); !ok {
+				continue
+			}
+		}
+		if seen[tableKey(t)] {
+			continue
+		}
+		seen[tableKey(t)] = true
+		tables = append(tables, t)
+	}
+
+	schemas := make([]*entity.TableSchema, len(tables))
+	errs := make([]error, len(tables))
+	forEachLimit(len(tables), schemaFetchConcurrency, func(i int) {
+		schemas[i], errs[i] = u.tables.GetTableSchema(connectionID, tables[i].Name)
+	})
+
+	result := make(map[string]*entity.TableSchema, len(tables))
+	var tableErrs []entity.ERDTableError
+	for i, t := range tables {
+		if errs[i] != nil {
+			tableErrs = append(tableErrs, entity.ERDTableError{Table: t.Name, Error: errs[i].Error()})
+			continue
+		}
+		result[tableKey(t)] = schemas[i]
+	}
+	return result, tableErrs, nil
+}
+
+// Keyed by schema and name so same-named tables in different schemas stay
+// apart.
+func tableKey(t entity.TableInfo) string {
+	if t.Schema == "" {
+		return t.Name
+	}
+	return t.Schema + "." + t.Name
+}
+
+// Pairs source and target tables by schema-qualified key first. Dialects name
+// their default schema differently (main, public, a dataset), so tables left
+// over are then paired by bare name when that name is unique on both sides.
+func matchTables(source, target map[string]*entity.TableSchema) map[string]string {
+	pairs := make(map[string]string, len(source))
+	matchedTargets := map[string]bool{}
+	for key := range source {
+		if _, ok := target[key]; ok {
+			pairs[key] = key
+			matchedTargets[key] = true
+		}
+	}
+
+	sourceByName := map[string][]string{}
+	for key, schema := range source {
+		if _, ok := pairs[key]; !ok {
+			sourceByName[schema.TableName] = append(sourceByName[schema.TableName], key)
+		}
+	}
+	targetByName := map[string][]string{}
+	for key, schema := range target {
+		if !matchedTargets[key] {
+			targetByName[schema.TableName] = append(targetByName[schema.TableName], key)
+		}
+	}
+	for name, keys := range sourceByName {
+		if len(keys) == 1 && len(targetByName[name]) == 1 {
+			pairs[keys[0]] = targetByName[name][0]
+		}
+	}
+	return pairs
+}
+
+// Tables are reported by bare name unless the name exists in more than one
+// schema of the connection.
+func displayNames(schemas map[string]*entity.TableSchema) map[string]string {
+	counts := map[string]int{}
+	for _, schema := range schemas {
+		counts[schema.TableName]++
+	}
+	names := make(map[string]string, len(schemas))
+	for key, schema := range schemas {
+		names[key] = schema.TableName
+		if counts[schema.TableName] > 1 {
+			names[key] = key
+		}
+	}
+	return names
+}
+
+func (u *SchemaDiffUsecase) diffTable(sourceType, targetType string, sameDialect bool, source, target *entity.TableSchema) (entity.TableDiff, bool) {
+	var diff entity.TableDiff
+	changed := false
+
+	targetColumns := make(map[string]entity.ColumnInfo, len(target.Columns))
+	for _, col := range target.Columns {
+		targetColumns[col.Name] = col
+	}
+	sourceColumns := make(map[string]bool, len(source.Columns))
+
+	for _, col := range source.Columns {
+		sourceColumns[col.Name] = true
+		tcol, ok := targetColumns[col.Name]
+		if !ok {
+			diff.AddedColumns = append(diff.AddedColumns, col)
+			changed = true
+			continue
+		}
+
+		cd := entity.ColumnDiff{
+			Name:           col.Name,
+			SourceType:     col.Type,
+			TargetType:     tcol.Type,
+			SourceNullable: col.Nullable,
+			TargetNullable: tcol.Nullable,
+			SourceDefault:  col.DefaultValue,
+			TargetDefault:  tcol.DefaultValue,
+		}
+		cd.TypeChanged = !u.sameColumnType(sourceType, targetType, col.Type, tcol.Type)
+		cd.NullabilityChanged = col.Nullable != tcol.Nullable && !col.IsPrimaryKey
+		cd.DefaultChanged = sameDialect && col.DefaultValue != tcol.DefaultValue
+		if cd.TypeChanged || cd.NullabilityChanged || cd.DefaultChanged {
+			diff.ChangedColumns = append(diff.ChangedColumns, cd)
+			changed = true
+		}
+	}
+	for _, col := range target.Columns {
+		if !sourceColumns[col.Name] {
+			diff.RemovedColumns = append(diff.RemovedColumns, col)
+			changed = true
+		}
+	}
+
+	sourcePK, targetPK := primaryKeyColumns(source), primaryKeyColumns(target)
+	if strings.Join(sourcePK, ",") != strings.Join(targetPK, ",") {
+		diff.SourcePrimaryKey, diff.TargetPrimaryKey = sourcePK, targetPK
+		changed = true
+	}
+
+	added, removed, changedIdx := diffIndexes(comparableIndexes(source, sourcePK), comparableIndexes(target, targetPK))
+	if len(added)+len(removed)+len(changedIdx) > 0 {
+		diff.AddedIndexes, diff.RemovedIndexes, diff.ChangedIndexes = added, removed, changedIdx
+		changed = true
+	}
+
+	sourceFKs, targetFKs := foreignKeySignatures(source), foreignKeySignatures(target)
+	for _, fk := range source.ForeignKeys {
+		if !targetFKs[foreignKeySignature(fk)] {
+			diff.AddedForeignKeys = append(diff.AddedForeignKeys, fk)
+			changed = true
+		}
+	}
+	for _, fk := range target.ForeignKeys {
+		if !sourceFKs[foreignKeySignature(fk)] {
+			diff.RemovedForeignKeys = append(diff.RemovedForeignKeys, fk)
+			changed = true
+		}
+	}
+
+	if sameDialect {
+		sourceCons, targetCons := constraintSignatures(source), constraintSignatures(target)
+		for _, c := range source.Constraints {
+			if c.Type != "FOREIGN KEY" && !targetCons[c.Type+" "+c.Definition] {
+				diff.AddedConstraints = append(diff.AddedConstraints, c)
+				changed = true
+			}
+		}
+		for _, c := range target.Constraints {
+			if c.Type != "FOREIGN KEY" && !sourceCons[c.Type+" "+c.Definition] {
+				diff.RemovedConstraints = append(diff.RemovedConstraints, c)
+				changed = true
+			}
+		}
+	}
+
+	return diff, changed
+}
+
+func (u *SchemaDiffUsecase) sameColumnType(sourceType, targetType, sourceColumnType, targetColumnType string) bool {
+	if sourceType == targetType {
+		return strings.EqualFold(strings.TrimSpace(sourceColumnType), strings.TrimSpace(targetColumnType))
+	}
+
+	mappedSource, err := u.factory.MapColumnType(sourceType, targetType, sourceColumnType)
+	if err != nil {
+		return strings.EqualFold(sourceColumnType, targetColumnType)
+	}
+	roundTrip, err := u.factory.MapColumnType(targetType, sourceType, targetColumnType)
+	if err != nil {
+		return strings.EqualFold(sourceColumnType, targetColumnType)
+	}
+	roundTrip, _ = u.factory.MapColumnType(sourceType, targetType, roundTrip)
+	return strings.EqualFold(mappedSource, roundTrip)
+}
+
+func primaryKeyColumns(schema *entity.TableSchema) []string {
+	var pk []string
+	for _, col := range schema.Columns {
+		if col.IsPrimaryKey {
+			pk = append(pk, col.Name)
+		}
+	}
+	return pk
+}
+
+func comparableIndexes(schema *entity.TableSchema, pk []string) []entity.IndexInfo {
+	pkSig := strings.Join(pk, ",")
+	var indexes []entity.IndexInfo
+	for _, idx := range schema.Indexes {
+		if strings.HasPrefix(idx.Name, "sqlite_autoindex_") {
+			continue
+		}
+		if idx.Unique && strings.Join(idx.Columns, ",") == pkSig {
+			continue
+		}
+		indexes = append(indexes, idx)
+	}
+	return indexes
+}
+
+func indexSignature(idx entity.IndexInfo) string {
+	sig := strings.Join(idx.Columns, ",")
+	if idx.Unique {
+		sig = "unique:" + sig
+	}
+	return sig
+}
+
+// Indexes are paired by name first, so one whose definition changed is
+// reported as changed rather than as a removal plus an addition of the same
+// name. Unpaired indexes are then matched by definition, which lets
+// differently named but identical indexes count as equal.
+func diffIndexes(source, target []entity.IndexInfo) (added, removed []entity.IndexInfo, changed []entity.IndexDiff) {
+	targetByName := make(map[string]entity.IndexInfo, len(target))
+	for _, idx := range target {
+		targetByName[idx.Name] = idx
+	}
+	pairedTarget := map[string]bool{}
+	var unpaired []entity.IndexInfo
+	for _, idx := range source {
+		t, ok := targetByName[idx.Name]
+		if !ok {
+			unpaired = append(unpaired, idx)
+			continue
+		}
+		pairedTarget[t.Name] = true
+		if indexSignature(idx) != indexSignature(t) {
+			changed = append(changed, entity.IndexDiff{Name: idx.Name, Source: idx, Target: t})
+		}
+	}
+
+	targetBySig := map[string][]entity.IndexInfo{}
+	for _, idx := range target {
+		if !pairedTarget[idx.Name] {
+			targetBySig[indexSignature(idx)] = append(targetBySig[indexSignature(idx)], idx)
+		}
+	}
+	for _, idx := range unpaired {
+		sig := indexSignature(idx)
+		if candidates := targetBySig[sig]; len(candidates) > 0 {
+			pairedTarget[candidates[0].Name] = true
+			targetBySig[sig] = candidates[1:]
+			continue
+		}
+		added = append(added, idx)
+	}
+	for _, idx := range target {
+		if !pairedTarget[idx.Name] {
+			removed = append(removed, idx)
+		}
+	}
+
+	sort.Slice(added, func(i, j int) bool { return added[i].Name < added[j].Name })
+	sort.Slice(removed, func(i, j int) bool { return removed[i].Name < removed[j].Name })
+	sort.Slice(changed, func(i, j int) bool { return changed[i].Name < changed[j].Name })
+	return added, removed, changed
+}
+
+func foreignKeySignature(fk entity.ForeignKeyInfo) string {
+	return strings.Join(fk.Columns, ",") + "->" + fk.ReferencedTable + "(" + strings.Join(fk.ReferencedColumns, ",") + ")"
+}
+
+func foreignKeySignatures(schema *entity.TableSchema) map[string]bool {
+	sigs := make(map[string]bool, len(schema.ForeignKeys))
+	for _, fk := range schema.ForeignKeys {
+		sigs[foreignKeySignature(fk)] = true
+	}
+	return sigs
+}
+
+func constraintSignatures(schema *entity.TableSchema) map[string]bool {
+	sigs := make(map[string]bool, len(schema.Constraints))
+	for _, c := range schema.Constraints {
+		sigs[c.Type+" "+c.Definition] = true
+	}
+	return sigs
+}
+
+func sortedKeys(m map[string]*entity.TableSchema) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
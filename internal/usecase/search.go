@@ -0,0 +1,231 @@
+package usecase
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/3-lines-studio/datafrost/internal/core/entity"
+	"github.com/3-lines-studio/datafrost/internal/usecase/port"
+)
+
+const (
+	schemaIndexMaxAge  = 24 * time.Hour
+	schemaIndexBackoff = 10 * time.Minute
+	defaultSearchLimit = 50
+	maxSearchLimit     = 500
+)
+
+type SearchUsecase struct {
+	indexRepo port.SchemaIndexRepository
+	connRepo  port.ConnectionRepository
+	cache     port.AdapterCache
+	tables    *TableUsecase
+
+	mu       sync.Mutex
+	indexing map[int64]bool
+	failedAt map[int64]time.Time
+}
+
+func NewSearchUsecase(
+	indexRepo port.SchemaIndexRepository,
+	connRepo port.ConnectionRepository,
+	cache port.AdapterCache,
+	tables *TableUsecase,
+) *SearchUsecase {
+	return &SearchUsecase{
+		indexRepo: indexRepo,
+		connRepo:  connRepo,
+		cache:     cache,
+		tables:    tables,
+		indexing:  make(map[int64]bool),
+		failedAt:  make(map[int64]time.Time),
+	}
+}
+
+func (u *SearchUsecase) Search(connectionID int64, term string, limit int) (*entity.SearchResponse, error) {
+	if term == "" {
+		return nil, ErrQueryRequired
+	}
+	conn, err := u.connRepo.GetByID(connectionID)
+	if err != nil {
+		return nil, err
+	}
+	if conn == nil {
+		return nil, ErrConnectionNotFound
+	}
+
+	u.refreshIfStale(connectionID)
+
+	results, err := u.indexRepo.Search(connectionID, term, clampSearchLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+	return &entity.SearchResponse{Results: results, Indexing: u.indexingIDs()}, nil
+}
+
+func (u *SearchUsecase) SearchAll(term string, limit int) (*entity.SearchResponse, error) {
+	if term == "" {
+		return nil, ErrQueryRequired
+	}
+	connections, err := u.connRepo.List()
+	if err != nil {
+		return nil, err
+	}
+	// Only connections already in use are refreshed; opening every saved one
+	// would reach prod databases and billed warehouses on each keystroke.
+	for _, conn := range connections {
+		if u.cache.IsOpen(conn.ID) {
+			u.refreshIfStale(conn.ID)
+		}
+	}
+
+	results, err := u.indexRepo.Search(0, term, clampSearchLimit(limit))
+	if err != nil {
+		return nil, err
+	}
+	return &entity.SearchResponse{Results: results, Indexing: u.indexingIDs()}, nil
+}
+
+func (u *SearchUsecase) Reindex(connectionID int64) (*entity.SchemaIndexStatus, error) {
+	if !u.startIndexing(connectionID) {
+		return nil, ErrIndexInProgress
+	}
+	defer u.finishIndexing(connectionID)
+
+	// An explicit reindex is usually asked for after a schema change, so the
+	// cached table list and schemas cannot be trusted.
+	return u.buildIndex(connectionID, u.tables.RefreshMetadata)
+}
+
+func (u *SearchUsecase) refreshIfStale(connectionID int64) {
+	indexedAt, err := u.indexRepo.IndexedAt(connectionID)
+	if err != nil {
+		log.Printf("schema index status for connection %d: %v", connectionID, err)
+		return
+	}
+	if indexedAt != nil && time.Since(*indexedAt) < schemaIndexMaxAge {
+		return
+	}
+	if !u.startStaleIndexing(connectionID) {
+		return
+	}
+
+	go func() {
+		defer u.finishIndexing(connectionID)
+		_, err := u.buildIndex(connectionID, u.tables.ListTables)
+
+		u.mu.Lock()
+		defer u.mu.Unlock()
+		if err != nil {
+			log.Printf("schema index for connection %d: %v", connectionID, err)
+			u.failedAt[connectionID] = time.Now()
+			return
+		}
+		delete(u.failedAt, connectionID)
+	}()
+}
+
+func (u *SearchUsecase) buildIndex(connectionID int64, listTables func(int64) ([]entity.TableInfo, error)) (*entity.SchemaIndexStatus, error) {
+	tables, err := listTables(connectionID)
+	if err != nil {
+		return nil, err
+	}
+
+	schemas := make([]*entity.TableSchema, len(tables))
+	errs := make([]error, len(tables))
+	forEachLimit(len(tables), schemaFetchConcurrency, func(i int) {
+		schemas[i], errs[i] = u.tables.GetTableSchema(connectionID, tables[i].Name)
+	})
+
+	status := &entity.SchemaIndexStatus{ConnectionID: connectionID}
+	var entries []entity.SchemaIndexEntry
+	for i, t := range tables {
+		tableEntry := entity.SchemaIndexEntry{TableName: t.Name, TableType: t.Type}
+		if errs[i] != nil {
+			status.Errors = append(status.Errors, t.Name+": "+errs[i].Error())
+			entries = append(entries, tableEntry)
+			continue
+		}
+
+		tableEntry.Comment = schemas[i].Comment
+		entries = append(entries, tableEntry)
+		status.Tables++
+
+		for _, col := range schemas[i].Columns {
+			entries = append(entries, entity.SchemaIndexEntry{
+				TableName:  t.Name,
+				TableType:  t.Type,
+				ColumnName: col.Name,
+				ColumnType: col.Type,
+				Comment:    col.Comment,
+			})
+			status.Columns++
+		}
+	}
+
+	if err := u.indexRepo.Replace(connectionID, entries); err != nil {
+		return nil, err
+	}
+
+	status.IndexedAt, err = u.indexRepo.IndexedAt(connectionID)
+	if err != nil {
+		return nil, err
+	}
+	return status, nil
+}
+
+func (u *SearchUsecase) startIndexing(connectionID int64) bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if u.indexing[connectionID] {
+		return false
+	}
+	u.indexing[connectionID] = true
+	return true
+}
+
+// A connection whose last background index failed is not retried until the
+// backoff passes, so a broken one is not reconnected on every search.
+func (u *SearchUsecase) startStaleIndexing(connectionID int64) bool {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if u.indexing[connectionID] {
+		return false
+	}
+	if failed, ok := u.failedAt[connectionID]; ok && time.Since(failed) < schemaIndexBackoff {
+		return false
+	}
+	u.indexing[connectionID] = true
+	return true
+}
+
+func (u *SearchUsecase) finishIndexing(connectionID int64) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	delete(u.indexing, connectionID)
+}
+
+func (u *SearchUsecase) indexingIDs() []int64 {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	ids := make([]int64, 0, len(u.indexing))
+	for id := range u.indexing {
+		ids = append(ids, id)
+	}
+	return ids
+}
+
+func clampSearchLimit(limit int) int {
+	if limit <= 0 {
+		return defaultSearchLimit
+	}
+	if limit > maxSearchLimit {
+		return maxSearchLimit
+	}
+	return limit
+}
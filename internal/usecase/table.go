@@ -1,22 +1,34 @@
 package usecase
 
 import (
+	"fmt"
+	"strconv"
+	"time"
+
 	"github.com/3-lines-studio/datafrost/internal/core/entity"
 	"github.com/3-lines-studio/datafrost/internal/usecase/port"
 )
 
+const defaultMetadataTTL = 5 * time.Minute
+
 type TableUsecase struct {
-	connRepo port.ConnectionRepository
-	cache    port.AdapterCache
+	connRepo  port.ConnectionRepository
+	cache     port.AdapterCache
+	metaCache port.MetadataCache
+	stateRepo port.AppStateRepository
 }
 
 func NewTableUsecase(
 	connRepo port.ConnectionRepository,
 	cache port.AdapterCache,
+	metaCache port.MetadataCache,
+	stateRepo port.AppStateRepository,
 ) *TableUsecase {
 	return &TableUsecase{
-		connRepo: connRepo,
-		cache:    cache,
+		connRepo:  connRepo,
+		cache:     cache,
+		metaCache: metaCache,
+		stateRepo: stateRepo,
 	}
 }
 
@@ -28,7 +40,7 @@ func (u *TableUsecase) getAdapter(connectionID int64) (entity.DatabaseAdapter, *
 	if conn == nil {
 		return nil, nil, ErrConnectionNotFound
 	}
-	adapter, err := u.cache.Get(conn.ID, conn.Type, conn.Credentials)
+	adapter, err := u.cache.Get(conn)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -36,11 +48,19 @@ func (u *TableUsecase) getAdapter(connectionID int64) (entity.DatabaseAdapter, *
 }
 
 func (u *TableUsecase) ListTables(connectionID int64) ([]entity.TableInfo, error) {
+	if tables, ok := u.metaCache.GetTables(connectionID, u.metadataTTL(connectionID)); ok {
+		return tables, nil
+	}
 	adapter, _, err := u.getAdapter(connectionID)
 	if err != nil {
 		return nil, err
 	}
-	return adapter.ListTables()
+	tables, err := adapter.ListTables()
+	if err != nil {
+		return nil, err
+	}
+	u.metaCache.SetTables(connectionID, tables)
+	return tables, nil
 }
 
 func (u *TableUsecase) GetTableData(connectionID int64, tableName string, limit, offset int, filters []entity.Filter) (*entity.QueryResult, error) {
@@ -52,9 +72,69 @@ func (u *TableUsecase) GetTableData(connectionID int64, tableName string, limit,
 }
 
 func (u *TableUsecase) GetTableSchema(connectionID int64, tableName string) (*entity.TableSchema, error) {
+	if schema, ok := u.metaCache.GetTableSchema(connectionID, tableName, u.metadataTTL(connectionID)); ok {
+		return schema, nil
+	}
+	adapter, _, err := u.getAdapter(connectionID)
+	if err != nil {
+		return nil, err
+	}
+	schema, err := adapter.GetTableSchema(tableName)
+	if err != nil {
+		return nil, err
+	}
+	u.metaCache.SetTableSchema(connectionID, tableName, schema)
+	return schema, nil
+}
+
+func (u *TableUsecase) GetTableDDL(connectionID int64, tableName string) (*entity.TableDDL, error) {
 	adapter, _, err := u.getAdapter(connectionID)
 	if err != nil {
 		return nil, err
 	}
-	return adapter.GetTableSchema(tableName)
+	return adapter.GetTableDDL(tableName)
+}
+
+func (u *TableUsecase) RefreshMetadata(connectionID int64) ([]entity.TableInfo, error) {
+	u.metaCache.Invalidate(connectionID)
+	return u.ListTables(connectionID)
+}
+
+func (u *TableUsecase) GetMetadataTTL(connectionID int64) time.Duration {
+	return u.metadataTTL(connectionID)
+}
+
+func (u *TableUsecase) SetMetadataTTL(connectionID int64, ttl time.Duration) error {
+	if ttl < 0 {
+		return ErrInvalidRequest
+	}
+	ttl = ttl.Truncate(time.Second)
+	if err := u.stateRepo.Set(metadataTTLKey(connectionID), strconv.FormatInt(int64(ttl/time.Second), 10)); err != nil {
+		return err
+	}
+	u.metaCache.Invalidate(connectionID)
+	u.metaCache.SetTTL(connectionID, ttl)
+	return nil
+}
+
+// The stored TTL is read from app_state once per connection and kept in the
+// metadata cache, so cached lookups do not touch config.db.
+func (u *TableUsecase) metadataTTL(connectionID int64) time.Duration {
+	if ttl, ok := u.metaCache.GetTTL(connectionID); ok {
+		return ttl
+	}
+	value, err := u.stateRepo.Get(metadataTTLKey(connectionID))
+	if err != nil {
+		return defaultMetadataTTL
+	}
+	ttl := defaultMetadataTTL
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil && seconds >= 0 {
+		ttl = time.Duration(seconds) * time.Second
+	}
+	u.metaCache.SetTTL(connectionID, ttl)
+	return ttl
+}
+
+func metadataTTLKey(connectionID int64) string {
+	return fmt.Sprintf("metadata_ttl_%d", connectionID)
 }
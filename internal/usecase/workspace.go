@@ -0,0 +1,363 @@
+package usecase
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/3-lines-studio/datafrost/internal/core/entity"
+	"github.com/3-lines-studio/datafrost/internal/usecase/port"
+)
+
+type WorkspaceUsecase struct {
+	connections  *ConnectionUsecase
+	savedQueries *SavedQueryUsecase
+	appState     *AppStateUsecase
+	sealer       port.SecretSealer
+}
+
+func NewWorkspaceUsecase(
+	connections *ConnectionUsecase,
+	savedQueries *SavedQueryUsecase,
+	appState *AppStateUsecase,
+	sealer port.SecretSealer,
+) *WorkspaceUsecase {
+	return &WorkspaceUsecase{
+		connections:  connections,
+		savedQueries: savedQueries,
+		appState:     appState,
+		sealer:       sealer,
+	}
+}
+
+func (u *WorkspaceUsecase) Export(opts entity.WorkspaceExportOptions) (*entity.WorkspaceBundle, error) {
+	connections, err := u.selectConnections(opts.Connections)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle := &entity.WorkspaceBundle{
+		Format:      entity.WorkspaceBundleFormat,
+		Version:     entity.WorkspaceBundleVersion,
+		ExportedAt:  time.Now().UTC(),
+		Connections: make([]entity.WorkspaceConnection, 0, len(connections)),
+	}
+	secrets := make(map[string]map[string]any)
+
+	for _, conn := range connections {
+		secretFields, err := u.connections.SecretFields(conn.Type)
+		if err != nil {
+			return nil, fmt.Errorf("connection %q: %w", conn.Name, err)
+		}
+
+		exported := entity.WorkspaceConnection{
+			Name:        conn.Name,
+			Type:        conn.Type,
+			Credentials: map[string]any{},
+			Group:       conn.Group,
+			Tags:        conn.Tags,
+			Environment: conn.Environment,
+			Color:       conn.Color,
+		}
+		for key, value := range conn.Credentials {
+			if !secretFields[key] {
+				exported.Credentials[key] = value
+				continue
+			}
+			if !hasCredential(conn.Credentials, key) {
+				continue
+			}
+			if opts.Passphrase == "" {
+				exported.OmittedSecrets = append(exported.OmittedSecrets, key)
+				continue
+			}
+			if secrets[conn.Name] == nil {
+				secrets[conn.Name] = map[string]any{}
+			}
+			secrets[conn.Name][key] = value
+		}
+		sort.Strings(exported.OmittedSecrets)
+
+		queries, err := u.savedQueries.ListByConnection(conn.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, q := range queries {
+			exported.SavedQueries = append(exported.SavedQueries, entity.WorkspaceSavedQuery{Name: q.Name, Query: q.Query})
+		}
+		tabs, err := u.appState.GetTabs(int(conn.ID))
+		if err != nil {
+			return nil, err
+		}
+		exported.Tabs = tabs
+
+		bundle.Connections = append(bundle.Connections, exported)
+	}
+
+	layouts, err := u.appState.Layouts()
+	if err != nil {
+		return nil, err
+	}
+	if len(layouts) > 0 {
+		bundle.Layouts = layouts
+	}
+
+	if len(secrets) > 0 {
+		plaintext, err := json.Marshal(secrets)
+		if err != nil {
+			return nil, err
+		}
+		bundle.Secrets, err = u.sealer.Seal(plaintext, opts.Passphrase)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return bundle, nil
+}
+
+func (u *WorkspaceUsecase) selectConnections(names []string) ([]entity.Connection, error) {
+	if len(names) == 0 {
+		connections, _, err := u.connections.List()
+		return connections, err
+	}
+	var connections []entity.Connection
+	seen := make(map[int64]bool)
+	for _, name := range names {
+		conn, err := u.connections.FindByName(name)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", err, name)
+		}
+		if !seen[conn.ID] {
+			seen[conn.ID] = true
+			connections = append(connections, *conn)
+		}
+	}
+	return connections, nil
+}
+
+func (u *WorkspaceUsecase) Import(bundle entity.WorkspaceBundle, opts entity.WorkspaceImportOptions) (*entity.WorkspaceImportResult, error) {
+	if bundle.Format != entity.WorkspaceBundleFormat || bundle.Version < 1 {
+		return nil, ErrInvalidBundle
+	}
+	if bundle.Version > entity.WorkspaceBundleVersion {
+		return nil, ErrBundleTooNew
+	}
+	onDuplicate := opts.OnDuplicate
+	if onDuplicate == "" {
+		onDuplicate = entity.OnDuplicateSkip
+	}
+	switch onDuplicate {
+	case entity.OnDuplicateSkip, entity.OnDuplicateRename, entity.OnDuplicateReplace:
+	default:
+		return nil, ErrInvalidOnDuplicate
+	}
+
+	secrets := make(map[string]map[string]any)
+	if bundle.Secrets != nil && !opts.SkipSecrets {
+		if opts.Passphrase == "" {
+			return nil, ErrPassphraseRequired
+		}
+		plaintext, err := u.sealer.Open(*bundle.Secrets, opts.Passphrase)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrSecretsUnreadable, err)
+		}
+		if err := json.Unmarshal(plaintext, &secrets); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrSecretsUnreadable, err)
+		}
+	}
+
+	existing, _, err := u.connections.List()
+	if err != nil {
+		return nil, err
+	}
+	byName := make(map[string]*entity.Connection, len(existing))
+	taken := make(map[string]bool, len(existing))
+	for i := range existing {
+		key := strings.ToLower(existing[i].Name)
+		byName[key] = &existing[i]
+		taken[key] = true
+	}
+
+	result := &entity.WorkspaceImportResult{DryRun: opts.DryRun, Items: []entity.WorkspaceImportItem{}}
+	for _, wc := range bundle.Connections {
+		item := entity.WorkspaceImportItem{
+			Name:         wc.Name,
+			SourceName:   wc.Name,
+			Type:         wc.Type,
+			Action:       entity.ImportActionCreate,
+			SavedQueries: len(wc.SavedQueries),
+			Tabs:         len(wc.Tabs),
+		}
+		if wc.Name == "" {
+			item.Action = entity.ImportActionInvalid
+			item.Error = ErrNameRequired.Error()
+			result.Invalid++
+			result.Items = append(result.Items, item)
+			continue
+		}
+
+		var replace *entity.Connection
+		key := strings.ToLower(wc.Name)
+		if taken[key] {
+			switch {
+			case onDuplicate == entity.OnDuplicateRename:
+				item.Name = uniqueConnectionName(wc.Name, taken)
+				item.Action = entity.ImportActionRename
+			case onDuplicate == entity.OnDuplicateReplace && byName[key] != nil:
+				replace = byName[key]
+				delete(byName, key)
+				item.Name = replace.Name
+				item.Action = entity.ImportActionReplace
+				item.ConnectionID = replace.ID
+			default:
+				item.Action = entity.ImportActionSkip
+				result.Skipped++
+				result.Items = append(result.Items, item)
+				continue
+			}
+		}
+
+		credentials := make(map[string]any, len(wc.Credentials))
+		maps.Copy(credentials, wc.Credentials)
+		maps.Copy(credentials, secrets[wc.Name])
+		// Decided before local secrets are merged back in: those were
+		// already trusted here and do not come from the bundle.
+		trusted := importTrust(credentials, replace, opts.TrustReferences)
+		if !trusted && hasLocalReference(credentials) {
+			item.Warnings = append(item.Warnings, untrustedReferenceWarning)
+		}
+		var missing []string
+		for _, field := range wc.OmittedSecrets {
+			// Replacing keeps the local secret, so a shared bundle without
+			// passwords does not wipe the ones already configured here.
+			if replace != nil && replace.Type == wc.Type && hasCredential(replace.Credentials, field) {
+				credentials[field] = replace.Credentials[field]
+				continue
+			}
+			missing = append(missing, field)
+		}
+		if bundle.Secrets != nil && opts.SkipSecrets {
+			item.Warnings = append(item.Warnings, "encrypted secrets were not imported")
+		}
+
+		normalized, err := u.connections.ValidateCredentials(wc.Type, credentials)
+		if err != nil {
+			if len(missing) == 0 || !errors.Is(err, ErrInvalidCredentials) {
+				item.Action = entity.ImportActionInvalid
+				item.Error = err.Error()
+				result.Invalid++
+				result.Items = append(result.Items, item)
+				continue
+			}
+			// The bundle left these out on purpose; save what is there and
+			// let the user fill in the rest.
+			normalized = credentials
+		}
+		if len(missing) > 0 {
+			item.Warnings = append(item.Warnings, fmt.Sprintf("%s not included in the bundle; edit the connection to add it", strings.Join(missing, ", ")))
+		}
+		taken[strings.ToLower(item.Name)] = true
+
+		if !opts.DryRun {
+			var conn *entity.Connection
+			if replace != nil {
+				conn, err = u.connections.Update(replace.ID, entity.UpdateConnectionRequest{
+					Name:            item.Name,
+					Type:            wc.Type,
+					Credentials:     normalized,
+					Group:           &wc.Group,
+					Tags:            &wc.Tags,
+					Environment:     &wc.Environment,
+					Color:           &wc.Color,
+					TrustReferences: &trusted,
+				})
+			} else {
+				conn, err = u.connections.Create(entity.CreateConnectionRequest{
+					Name:            item.Name,
+					Type:            wc.Type,
+					Credentials:     normalized,
+					Group:           wc.Group,
+					Tags:            wc.Tags,
+					Environment:     wc.Environment,
+					Color:           wc.Color,
+					TrustReferences: trusted,
+				})
+			}
+			if err != nil {
+				item.Action = entity.ImportActionInvalid
+				item.Error = err.Error()
+				result.Invalid++
+				result.Items = append(result.Items, item)
+				continue
+			}
+			item.ConnectionID = conn.ID
+			// The connection is already saved, so a failure here is reported
+			// on its item instead of aborting the rest of the bundle.
+			if err := u.importContent(conn.ID, wc, replace != nil); err != nil {
+				item.Error = "connection saved, but its saved queries and tabs were not fully imported: " + err.Error()
+			}
+		}
+
+		if replace != nil {
+			result.Replaced++
+		} else {
+			result.Created++
+		}
+		result.Items = append(result.Items, item)
+	}
+
+	for key, layout := range bundle.Layouts {
+		current, err := u.appState.GetLayout(key)
+		if err != nil {
+			return nil, err
+		}
+		if current != "" && onDuplicate != entity.OnDuplicateReplace {
+			continue
+		}
+		if !opts.DryRun {
+			if err := u.appState.SaveLayout(key, layout); err != nil {
+				return nil, err
+			}
+		}
+		result.Layouts++
+	}
+	return result, nil
+}
+
+func (u *WorkspaceUsecase) importContent(connectionID int64, wc entity.WorkspaceConnection, merge bool) error {
+	existing := make(map[string]entity.SavedQuery)
+	if merge {
+		queries, err := u.savedQueries.ListByConnection(connectionID)
+		if err != nil {
+			return err
+		}
+		for _, q := range queries {
+			existing[strings.ToLower(q.Name)] = q
+		}
+	}
+	for _, q := range wc.SavedQueries {
+		if current, ok := existing[strings.ToLower(q.Name)]; ok {
+			if _, err := u.savedQueries.Update(current.ID, current.Name, q.Query); err != nil {
+				return err
+			}
+			continue
+		}
+		if _, err := u.savedQueries.Create(connectionID, q.Name, q.Query); err != nil {
+			return err
+		}
+	}
+
+	if len(wc.Tabs) == 0 {
+		return nil
+	}
+	tabs := make([]entity.Tab, len(wc.Tabs))
+	for i, tab := range wc.Tabs {
+		tab.ConnectionID = int(connectionID)
+		tabs[i] = tab
+	}
+	return u.appState.SaveTabs(int(connectionID), tabs)
+}
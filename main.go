@@ -11,16 +11,20 @@ import (
 	"os"
 
 	"github.com/3-lines-studio/bifrost"
-	webview "github.com/webview/webview_go"
 
+	"github.com/3-lines-studio/datafrost/internal/adapter/cli"
+	"github.com/3-lines-studio/datafrost/internal/adapter/connimport"
 	"github.com/3-lines-studio/datafrost/internal/adapter/database"
+	"github.com/3-lines-studio/datafrost/internal/adapter/export"
 	adapterHttp "github.com/3-lines-studio/datafrost/internal/adapter/http"
+	"github.com/3-lines-studio/datafrost/internal/adapter/importer"
+	"github.com/3-lines-studio/datafrost/internal/adapter/mcp"
 	"github.com/3-lines-studio/datafrost/internal/adapter/repository"
+	"github.com/3-lines-studio/datafrost/internal/adapter/secret"
 	"github.com/3-lines-studio/datafrost/internal/usecase"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
-	"github.com/go-chi/cors"
 )
 
 var version = "dev"
@@ -63,68 +67,84 @@ func main() {
 	connectionRepo := repository.NewConnectionRepository(sqlDB)
 	savedQueryRepo := repository.NewSavedQueryRepository(sqlDB)
 	appStateRepo := repository.NewAppStateRepository(sqlDB)
+	schemaIndexRepo := repository.NewSchemaIndexRepository(sqlDB)
 
-	factory := database.NewFactory()
-	adapterCache := database.NewAdapterCache()
+	credentialResolver := secret.NewResolver()
+	factory := database.NewFactory(credentialResolver)
+	adapterCache := database.NewAdapterCache(credentialResolver)
 	defer adapterCache.Close()
+	metadataCache := database.NewMetadataCache()
 
-	connectionUsecase := usecase.NewConnectionUsecase(connectionRepo, factory, adapterCache)
-	tableUsecase := usecase.NewTableUsecase(connectionRepo, adapterCache)
+	connectionUsecase := usecase.NewConnectionUsecase(connectionRepo, factory, adapterCache, metadataCache, schemaIndexRepo, appStateRepo)
+	tableUsecase := usecase.NewTableUsecase(connectionRepo, adapterCache, metadataCache, appStateRepo)
 	queryUsecase := usecase.NewQueryUsecase(connectionRepo, adapterCache)
 	savedQueryUsecase := usecase.NewSavedQueryUsecase(savedQueryRepo)
 	appStateUsecase := usecase.NewAppStateUsecase(appStateRepo)
 	adapterUsecase := usecase.NewAdapterUsecase(factory)
+	searchUsecase := usecase.NewSearchUsecase(schemaIndexRepo, connectionRepo, adapterCache, tableUsecase)
+	completionUsecase := usecase.NewCompletionUsecase(connectionRepo, factory, tableUsecase)
+	jobUsecase := usecase.NewJobUsecase()
+	exportWriters := export.NewFactory()
+	exportUsecase := usecase.NewExportUsecase(connectionRepo, savedQueryRepo, adapterCache, exportWriters, jobUsecase)
+	importUsecase := usecase.NewImportUsecase(connectionRepo, factory, adapterCache, metadataCache, importer.NewFactory(), jobUsecase)
+	copyUsecase := usecase.NewCopyUsecase(connectionRepo, factory, adapterCache, metadataCache, jobUsecase)
+	compareUsecase := usecase.NewCompareUsecase(connectionRepo, adapterCache)
+	schemaDiffUsecase := usecase.NewSchemaDiffUsecase(connectionRepo, factory, tableUsecase, database.NewMigrationGenerator())
+	profileUsecase := usecase.NewProfileUsecase(connectionRepo, adapterCache, tableUsecase, database.NewProfileQueryBuilder())
+	connectionImportUsecase := usecase.NewConnectionImportUsecase(connectionUsecase, connectionRepo, connimport.NewReader())
+	workspaceUsecase := usecase.NewWorkspaceUsecase(connectionUsecase, savedQueryUsecase, appStateUsecase, secret.NewPassphraseSealer())
+
+	if len(flag.Args()) > 0 && flag.Args()[0] == "mcp" {
+		server := mcp.New(connectionUsecase, tableUsecase, queryUsecase, exportWriters, version, os.Stdin, os.Stdout, os.Stderr)
+		code := server.Run(flag.Args()[1:])
+		adapterCache.Close()
+		_ = configDB.Close()
+		os.Exit(code)
+	}
+
+	commands := cli.New(connectionUsecase, connectionImportUsecase, queryUsecase, savedQueryUsecase, workspaceUsecase, exportWriters, os.Stdin, os.Stdout, os.Stderr)
+	if code, ok := commands.Run(flag.Args()); ok {
+		adapterCache.Close()
+		_ = configDB.Close()
+		os.Exit(code)
+	}
 
-	connectionsHandler := adapterHttp.NewConnectionsHandler(connectionUsecase)
-	tablesHandler := adapterHttp.NewTablesHandler(tableUsecase)
-	queryHandler := adapterHttp.NewQueryHandler(queryUsecase)
-	savedQueriesHandler := adapterHttp.NewSavedQueriesHandler(savedQueryUsecase)
-	tabsHandler := adapterHttp.NewTabsHandler(appStateUsecase)
-	themeHandler := adapterHttp.NewThemeHandler(appStateUsecase)
-	layoutHandler := adapterHttp.NewLayoutHandler(appStateUsecase)
-	adapterHandler := adapterHttp.NewAdapterHandler(adapterUsecase)
+	apiSpec := adapterHttp.NewOpenAPI(version)
+	handlers := adapterHttp.Handlers{
+		OpenAPI:          apiSpec,
+		Connections:      adapterHttp.NewConnectionsHandler(connectionUsecase),
+		ConnectionImport: adapterHttp.NewConnectionImportHandler(connectionImportUsecase),
+		Tables:           adapterHttp.NewTablesHandler(tableUsecase),
+		Query:            adapterHttp.NewQueryHandler(queryUsecase),
+		SavedQueries:     adapterHttp.NewSavedQueriesHandler(savedQueryUsecase),
+		Tabs:             adapterHttp.NewTabsHandler(appStateUsecase),
+		Theme:            adapterHttp.NewThemeHandler(appStateUsecase),
+		Layout:           adapterHttp.NewLayoutHandler(appStateUsecase),
+		Adapters:         adapterHttp.NewAdapterHandler(adapterUsecase),
+		Search:           adapterHttp.NewSearchHandler(searchUsecase),
+		Completion:       adapterHttp.NewCompletionHandler(completionUsecase),
+		Export:           adapterHttp.NewExportHandler(exportUsecase),
+		Jobs:             adapterHttp.NewJobsHandler(jobUsecase),
+		Import:           adapterHttp.NewImportHandler(importUsecase),
+		Copy:             adapterHttp.NewCopyHandler(copyUsecase),
+		Compare:          adapterHttp.NewCompareHandler(compareUsecase),
+		SchemaDiff:       adapterHttp.NewSchemaDiffHandler(schemaDiffUsecase),
+		Profile:          adapterHttp.NewProfileHandler(profileUsecase),
+	}
 
 	apiRouter := chi.NewRouter()
 	apiRouter.Use(middleware.Logger)
 	apiRouter.Use(middleware.Recoverer)
-	apiRouter.Use(cors.Handler(cors.Options{
-		AllowedOrigins: []string{"*"},
-		AllowedMethods: []string{"GET", "POST", "DELETE", "OPTIONS"},
-		AllowedHeaders: []string{"Accept", "Authorization", "Content-Type"},
-	}))
-
-	apiRouter.Route("/api", func(r chi.Router) {
-		r.Route("/connections", func(r chi.Router) {
-			r.Get("/", connectionsHandler.List)
-			r.Post("/", connectionsHandler.Create)
-			r.Route("/{id}", func(r chi.Router) {
-				r.Delete("/", connectionsHandler.Delete)
-				r.Put("/", connectionsHandler.Update)
-				r.Post("/select", connectionsHandler.SetLastConnected)
-				r.Post("/test", connectionsHandler.TestExisting)
-				r.Get("/tables", tablesHandler.List)
-				r.Get("/tables/{name}", tablesHandler.GetData)
-				r.Get("/tables/{name}/schema", tablesHandler.GetSchema)
-				r.Post("/query", queryHandler.Execute)
-				r.Get("/tabs", tabsHandler.Get)
-				r.Post("/tabs", tabsHandler.Save)
-				r.Route("/queries", func(r chi.Router) {
-					r.Get("/", savedQueriesHandler.List)
-					r.Post("/", savedQueriesHandler.Create)
-					r.Route("/{queryId}", func(r chi.Router) {
-						r.Put("/", savedQueriesHandler.Update)
-						r.Delete("/", savedQueriesHandler.Delete)
-					})
-				})
-			})
-			r.Post("/test", connectionsHandler.Test)
-		})
-		r.Get("/adapters", adapterHandler.List)
-		r.Get("/theme", themeHandler.Get)
-		r.Post("/theme", themeHandler.Update)
-		r.Get("/layouts/{key}", layoutHandler.Get)
-		r.Post("/layouts/{key}", layoutHandler.Save)
-	})
+	if os.Getenv("BIFROST_DEV") == "1" {
+		apiRouter.Use(apiSpec.ValidateResponses)
+	}
+	apiRouter.Use(adapterHttp.SameOrigin)
+
+	adapterHttp.RegisterRoutes(apiRouter, handlers)
+
+	if len(flag.Args()) > 0 && flag.Args()[0] == "openapi" {
+		os.Exit(openAPICommand(apiSpec, apiRouter, flag.Args()[1:], os.Stdout, os.Stderr))
+	}
 
 	app := bifrost.New(
 		bifrostFS,
@@ -132,18 +152,25 @@ func main() {
 	)
 	defer func() { _ = app.Stop() }()
 
-	server := &http.Server{
-		Handler: app.Wrap(apiRouter),
-		Addr:    "127.0.0.1:0",
+	if len(flag.Args()) > 0 && flag.Args()[0] == "serve" {
+		handlers.Connections.DisableTrust()
+		code := serve(app.Wrap(apiRouter), func() bool { return jobUsecase.Running() > 0 }, flag.Args()[1:], os.Stderr)
+		_ = app.Stop()
+		adapterCache.Close()
+		_ = configDB.Close()
+		os.Exit(code)
 	}
 
-	listener, err := net.Listen("tcp", server.Addr)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
 	if err != nil {
 		log.Fatalf("Failed to create listener: %v", err)
 	}
 	defer func() { _ = listener.Close() }()
 
 	localURL := fmt.Sprintf("http://%s", listener.Addr().String())
+	server := &http.Server{
+		Handler: adapterHttp.AllowedHosts(listener.Addr().String())(app.Wrap(apiRouter)),
+	}
 
 	go func() {
 		if err := server.Serve(listener); err != nil && err != http.ErrServerClosed {
@@ -151,18 +178,9 @@ func main() {
 		}
 	}()
 
-	debug := os.Getenv("BIFROST_DEV") == "1"
-
-	w := webview.New(debug)
-	defer w.Destroy()
-
-	w.SetTitle("Datafrost")
-	w.SetSize(1200, 800, webview.HintNone)
-	w.Navigate(localURL)
-
-	setupMacEditMenu()
-
-	w.Run()
+	if err := runWindow(localURL); err != nil {
+		log.Print(err)
+	}
 
 	if err := server.Shutdown(context.Background()); err != nil {
 		log.Printf("Server shutdown error: %v", err)
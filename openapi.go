@@ -0,0 +1,48 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+
+	adapterHttp "github.com/3-lines-studio/datafrost/internal/adapter/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+func openAPICommand(spec *adapterHttp.OpenAPI, routes chi.Routes, args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("openapi", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	check := fs.Bool("check", false, "Verify every API route is documented instead of printing the document")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: datafrost openapi [--check] > openapi.json")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+
+	if !*check {
+		_, _ = stdout.Write(spec.Document())
+		fmt.Fprintln(stdout)
+		return 0
+	}
+
+	problems, err := spec.CheckRoutes(routes)
+	if err != nil {
+		fmt.Fprintf(stderr, "datafrost: %v\n", err)
+		return 1
+	}
+	for _, problem := range problems {
+		fmt.Fprintln(stderr, problem)
+	}
+	if len(problems) > 0 {
+		return 1
+	}
+	fmt.Fprintln(stderr, "OpenAPI document matches the API routes.")
+	return 0
+}
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	adapterHttp "github.com/3-lines-studio/datafrost/internal/adapter/http"
+)
+
+func serve(handler http.Handler, busy func() bool, args []string, stderr io.Writer) int {
+	fs := flag.NewFlagSet("serve", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	addr := fs.String("addr", "127.0.0.1:8080", "Address to listen on")
+	token := fs.String("token", os.Getenv("DATAFROST_TOKEN"), "Access token (default $DATAFROST_TOKEN, or a random one)")
+	idleTimeout := fs.Duration("idle-timeout", 0, "Shut down after no requests or jobs for this long (0 disables)")
+	allowHosts := fs.String("allow-host", "", "Comma-separated extra host names the server may be reached as, e.g. behind a proxy")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: datafrost serve [--addr host:port] [--token TOKEN] [--idle-timeout 30m] [--allow-host NAME,...]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintf(stderr, "datafrost: unexpected argument %q\n", fs.Arg(0))
+		return 2
+	}
+	if *idleTimeout < 0 {
+		fmt.Fprintln(stderr, "datafrost: --idle-timeout must not be negative")
+		return 2
+	}
+	if *token == "" {
+		b := make([]byte, 24)
+		if _, err := rand.Read(b); err != nil {
+			fmt.Fprintf(stderr, "datafrost: failed to generate token: %v\n", err)
+			return 1
+		}
+		*token = hex.EncodeToString(b)
+	}
+
+	listener, err := net.Listen("tcp", *addr)
+	if err != nil {
+		fmt.Fprintf(stderr, "datafrost: %v\n", err)
+		return 1
+	}
+
+	handler = adapterHttp.TokenAuth(*token)(handler)
+	handler = adapterHttp.AllowedHosts(servedHosts(listener.Addr(), *allowHosts)...)(handler)
+	var idle <-chan struct{}
+	if *idleTimeout > 0 {
+		tracker := adapterHttp.NewIdleTracker(*idleTimeout, busy)
+		handler = tracker.Middleware(handler)
+		idle = tracker.Idle()
+	}
+
+	server := &http.Server{
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	host, port, _ := net.SplitHostPort(listener.Addr().String())
+	if ip := net.ParseIP(host); ip != nil && ip.IsUnspecified() {
+		host = "localhost"
+	}
+	fmt.Fprintf(stderr, "Datafrost is serving at http://%s/?token=%s\n", net.JoinHostPort(host, port), *token)
+	if *idleTimeout > 0 {
+		fmt.Fprintf(stderr, "Shutting down after %s without activity.\n", *idleTimeout)
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.Serve(listener)
+	}()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	select {
+	case err := <-serveErr:
+		if err != nil && err != http.ErrServerClosed {
+			fmt.Fprintf(stderr, "datafrost: %v\n", err)
+			return 1
+		}
+		return 0
+	case <-signals:
+		fmt.Fprintln(stderr, "Shutting down...")
+	case <-idle:
+		fmt.Fprintln(stderr, "Idle timeout reached, shutting down...")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := server.Shutdown(ctx); err != nil {
+		log.Printf("Server shutdown error: %v", err)
+	}
+	return 0
+}
+
+// servedHosts lists the names the server answers to: loopback on any port,
+// since SSH forwards often change it, the bound address, every local address
+// and the machine name when bound to all interfaces, and any --allow-host
+// names. Anything else is a rebound domain and is refused.
+func servedHosts(addr net.Addr, extra string) []string {
+	hosts := []string{"localhost", "127.0.0.1", "::1"}
+	host, _, _ := net.SplitHostPort(addr.String())
+	if ip := net.ParseIP(host); ip != nil && ip.IsUnspecified() {
+		if addrs, err := net.InterfaceAddrs(); err == nil {
+			for _, a := range addrs {
+				if ipNet, ok := a.(*net.IPNet); ok {
+					hosts = append(hosts, ipNet.IP.String())
+				}
+			}
+		}
+		if name, err := os.Hostname(); err == nil {
+			hosts = append(hosts, name)
+		}
+	} else {
+		hosts = append(hosts, host)
+	}
+	for _, name := range strings.Split(extra, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			hosts = append(hosts, name)
+		}
+	}
+	return hosts
+}
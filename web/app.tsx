@@ -30,6 +30,7 @@ import {
   useDeleteSavedQueryMutation,
   useExecuteQueryMutation,
   useLayoutQuery,
+  useReorderConnectionsMutation,
   useSaveLayoutMutation,
   useSaveTabsMutation,
   useSavedQueriesQuery,
@@ -42,10 +43,17 @@ import {
   useTestExistingConnectionMutation,
   useThemeQuery,
   useUpdateConnectionMutation,
+  useTrustConnectionMutation,
   useUpdateSavedQueryMutation,
   useUpdateThemeMutation,
 } from "@/lib/hooks";
-import type { Connection, QueryResult, SavedQuery, Tab } from "@/types";
+import type {
+  Connection,
+  ConnectionOrganization,
+  QueryResult,
+  SavedQuery,
+  Tab,
+} from "@/types";
 
 interface AlertState {
   open: boolean;
@@ -155,11 +163,16 @@ function PageContent() {
   const deleteMutation = useDeleteConnectionMutation();
   const setLastConnectedMutation = useSetLastConnectedMutation();
   const updateMutation = useUpdateConnectionMutation();
+  const trustMutation = useTrustConnectionMutation();
+  const reorderMutation = useReorderConnectionsMutation();
   const testMutation = useTestConnectionMutation();
   const testExistingMutation = useTestExistingConnectionMutation();
 
   const connections = connectionsData?.connections || [];
   const lastId = connectionsData?.last_id || 0;
+  const currentConnection = connections.find(
+    (c) => c.id === selectedConnection,
+  );
 
   const activeTab = useMemo(() => {
     return tabs.find((t) => t.id === activeTabId) || null;
@@ -520,15 +533,53 @@ function PageContent() {
     name: string,
     type: string,
     credentials: Record<string, any>,
+    organization: ConnectionOrganization,
+    trustReferences: boolean,
   ) => {
+    let saved: Connection | undefined;
     if (dialogMode === "add") {
-      await createMutation.mutateAsync({ name, type, credentials });
+      saved = await createMutation.mutateAsync({
+        name,
+        type,
+        credentials,
+        ...organization,
+      });
     } else if (dialogMode === "edit" && editingConnection) {
-      await updateMutation.mutateAsync({
+      saved = await updateMutation.mutateAsync({
         id: editingConnection.id,
-        data: { name, type, credentials },
+        data: {
+          name,
+          type,
+          credentials,
+          ...organization,
+        },
       });
     }
+    if (!saved || saved.trust_references === trustReferences) return;
+
+    const id = saved.id;
+    if (!trustReferences) {
+      await trustMutation.mutateAsync({ id, trusted: false });
+      return;
+    }
+    setAlertState({
+      open: true,
+      title: "Trust Connection",
+      description: `"${name}" will read the files and run the commands in its \${file:} and \${cmd:} references on this machine every time it connects. Only trust credentials you wrote or checked yourself.`,
+      type: "confirm",
+      onConfirm: async () => {
+        try {
+          await trustMutation.mutateAsync({ id, trusted: true });
+        } catch (err: any) {
+          setAlertState({
+            open: true,
+            title: "Trust Connection",
+            description: err.message,
+            type: "error",
+          });
+        }
+      },
+    });
   };
 
   const handleOpenAddDialog = () => {
@@ -789,6 +840,7 @@ function PageContent() {
               onAddConnection={handleOpenAddDialog}
               onEditConnection={handleEditConnection}
               onDeleteConnection={handleDeleteConnection}
+              onReorderConnections={(ids) => reorderMutation.mutate(ids)}
               onTestConnection={handleTestConnection}
               onDisconnectConnection={handleDisconnectConnection}
               onNewQuery={handleNewQueryTab}
@@ -811,6 +863,7 @@ function PageContent() {
                 tabs={tabs}
                 activeTabId={activeTabId}
                 hasConnection={!!selectedConnection}
+                organization={currentConnection}
                 isLoading={tabsLoading}
                 onTabClick={handleTabClick}
                 onTabClose={handleTabClose}
@@ -1,4 +1,12 @@
-import type { AdapterInfo, Connection, FieldConfig, UIMode } from "@/types";
+import { ENVIRONMENTS } from "@/lib/utils";
+import type {
+  AdapterInfo,
+  Connection,
+  ConnectionOrganization,
+  Environment,
+  FieldConfig,
+  UIMode,
+} from "@/types";
 import { Check, Eye, EyeOff, FileJson, Loader2, X } from "lucide-react";
 import { useEffect, useState } from "react";
 import { Button } from "../ui/button";
@@ -25,6 +33,8 @@ interface ConnectionDialogProps {
     name: string,
     type: string,
     credentials: Record<string, any>,
+    organization: ConnectionOrganization,
+    trustReferences: boolean,
   ) => Promise<void>;
   onTest: (type: string, credentials: Record<string, any>) => Promise<void>;
   testLoading: boolean;
@@ -44,6 +54,11 @@ export function ConnectionDialog({
   const [name, setName] = useState("");
   const [selectedType, setSelectedType] = useState("");
   const [credentials, setCredentials] = useState<Record<string, any>>({});
+  const [group, setGroup] = useState("");
+  const [tags, setTags] = useState("");
+  const [environment, setEnvironment] = useState<Environment>("");
+  const [color, setColor] = useState("");
+  const [trustReferences, setTrustReferences] = useState(false);
   const [showPassword, setShowPassword] = useState<Record<string, boolean>>({});
   const [saveLoading, setSaveLoading] = useState(false);
   const [testResult, setTestResult] = useState<{
@@ -53,6 +68,22 @@ export function ConnectionDialog({
   const [fileError, setFileError] = useState<string | null>(null);
 
   const selectedAdapter = adapters.find((a) => a.type === selectedType);
+  const secretKeys = new Set(
+    [
+      ...(selectedAdapter?.ui_config.fields ?? []),
+      ...(selectedAdapter?.ui_config.modes ?? []).flatMap((m) => m.fields),
+    ]
+      .filter((field) => field.secret)
+      .map((field) => field.key),
+  );
+  const needsTrust = Object.entries(credentials).some(
+    ([key, value]) =>
+      typeof value === "string" &&
+      (value.includes("${file:") ||
+        value.includes("${cmd:") ||
+        (value.includes("${env:") &&
+          !(secretKeys.has(key) && /^\$\{env:[^}]*\}$/.test(value)))),
+  );
   const hasMultipleModes =
     selectedAdapter?.ui_config.modes &&
     selectedAdapter.ui_config.modes.length > 0;
@@ -71,10 +102,20 @@ export function ConnectionDialog({
         setName(connection.name);
         setSelectedType(connection.type);
         setCredentials(connection.credentials || {});
+        setGroup(connection.group || "");
+        setTags((connection.tags || []).join(", "));
+        setEnvironment(connection.environment || "");
+        setColor(connection.color || "");
+        setTrustReferences(connection.trust_references);
       } else {
         setName("");
         setSelectedType("");
         setCredentials({});
+        setGroup("");
+        setTags("");
+        setEnvironment("");
+        setColor("");
+        setTrustReferences(false);
       }
       setTestResult(null);
       setShowPassword({});
@@ -97,7 +138,21 @@ export function ConnectionDialog({
 
     setSaveLoading(true);
     try {
-      await onSave(name, selectedType, credentials);
+      await onSave(
+        name,
+        selectedType,
+        credentials,
+        {
+          group: group.trim(),
+          tags: tags
+            .split(",")
+            .map((tag) => tag.trim())
+            .filter(Boolean),
+          environment,
+          color,
+        },
+        trustReferences,
+      );
       onOpenChange(false);
     } finally {
       setSaveLoading(false);
@@ -293,6 +348,74 @@ export function ConnectionDialog({
             )}
           </div>
 
+          <div className="grid grid-cols-2 gap-4">
+            <div className="space-y-2">
+              <Label htmlFor="group">Group</Label>
+              <Input
+                id="group"
+                value={group}
+                onChange={(e) => setGroup(e.target.value)}
+                placeholder="Optional"
+              />
+            </div>
+            <div className="space-y-2">
+              <Label htmlFor="tags">Tags</Label>
+              <Input
+                id="tags"
+                value={tags}
+                onChange={(e) => setTags(e.target.value)}
+                placeholder="analytics, billing"
+              />
+            </div>
+            <div className="space-y-2">
+              <Label>Environment</Label>
+              <Select
+                value={environment || "none"}
+                onValueChange={(value) =>
+                  setEnvironment(value === "none" ? "" : (value as Environment))
+                }
+              >
+                <SelectTrigger className="w-full">
+                  <SelectValue />
+                </SelectTrigger>
+                <SelectContent>
+                  <SelectItem value="none">None</SelectItem>
+                  {ENVIRONMENTS.map((env) => (
+                    <SelectItem key={env.value} value={env.value}>
+                      {env.label}
+                    </SelectItem>
+                  ))}
+                </SelectContent>
+              </Select>
+            </div>
+            <div className="space-y-2">
+              <Label htmlFor="color">Color</Label>
+              <div className="flex items-center gap-2">
+                <Input
+                  id="color"
+                  type="color"
+                  value={color || "#6b7280"}
+                  onChange={(e) => setColor(e.target.value)}
+                  className="h-9 w-12 p-1"
+                />
+                {color ? (
+                  <Button
+                    type="button"
+                    variant="ghost"
+                    size="sm"
+                    onClick={() => setColor("")}
+                  >
+                    Use environment color
+                  </Button>
+                ) : (
+                  <span className="text-xs text-gray-500">
+                    Environment default
+                  </span>
+                )}
+              </div>
+            </div>
+          </div>
+
           {selectedAdapter && (
             <>
               <div className="border-t pt-4">
@@ -363,6 +486,28 @@ export function ConnectionDialog({
             </>
           )}
 
+          {(needsTrust || trustReferences) && (
+            <label className="flex items-start gap-2 text-sm">
+              <input
+                type="checkbox"
+                checked={trustReferences}
+                onChange={(e) => setTrustReferences(e.target.checked)}
+                className="mt-0.5"
+              />
+              <span>
+                Resolve <code>{"${file:...}"}</code>,{" "}
+                <code>{"${cmd:...}"}</code> and embedded{" "}
+                <code>{"${env:...}"}</code> references
+                <span className="block text-xs text-gray-500">
+                  Reads those files and runs those commands on this machine
+                  when connecting. You confirm this after saving, and Test
+                  Connection only resolves them once the connection is
+                  trusted.
+                </span>
+              </span>
+            </label>
+          )}
+
           {testResult && (
             <div
               className={`flex items-center gap-2 text-sm ${
@@ -1,9 +1,13 @@
+import { connectionColor } from "@/lib/utils";
 import type { Connection, SavedQuery, TableInfo } from "@/types";
 import {
   Activity,
+  ArrowDown,
+  ArrowUp,
   ChevronDown,
   ChevronRight,
   FileSearch,
+  Folder,
   Loader2,
   Moon,
   MoreVertical,
@@ -13,6 +17,7 @@ import {
   Table,
   Trash2,
 } from "lucide-react";
+import { useState } from "react";
 import { SavedQueriesSection } from "../queries/saved-queries-section";
 import { Button } from "../ui/button";
 import {
@@ -37,6 +42,7 @@ interface SidebarProps {
   onAddConnection: () => void;
   onEditConnection: (id: number) => void;
   onDeleteConnection: (id: number) => void;
+  onReorderConnections: (ids: number[]) => void;
   onTestConnection: (id: number) => void;
   onDisconnectConnection: () => void;
   onNewQuery: () => void;
@@ -61,6 +67,7 @@ export function Sidebar({
   onAddConnection,
   onEditConnection,
   onDeleteConnection,
+  onReorderConnections,
   onTestConnection,
   onDisconnectConnection,
   onNewQuery,
@@ -70,6 +77,239 @@ export function Sidebar({
   onToggleTheme,
   onViewSchema,
 }: SidebarProps) {
+  const [collapsedGroups, setCollapsedGroups] = useState<Set<string>>(
+    new Set(),
+  );
+
+  // Groups appear in the order of their first connection, so moving a
+  // connection never reshuffles groups it does not belong to.
+  const groups: { name: string; connections: Connection[] }[] = [];
+  for (const conn of connections ?? []) {
+    const name = conn.group || "";
+    let entry = groups.find((g) => g.name === name);
+    if (!entry) {
+      entry = { name, connections: [] };
+      groups.push(entry);
+    }
+    entry.connections.push(conn);
+  }
+
+  const toggleGroup = (name: string) => {
+    setCollapsedGroups((prev) => {
+      const next = new Set(prev);
+      if (next.has(name)) {
+        next.delete(name);
+      } else {
+        next.add(name);
+      }
+      return next;
+    });
+  };
+
+  const moveConnection = (
+    groupName: string,
+    index: number,
+    offset: number,
+  ) => {
+    const ordered = groups.map((g) => [...g.connections]);
+    const siblings = ordered[groups.findIndex((g) => g.name === groupName)];
+    const target = index + offset;
+    if (target < 0 || target >= siblings.length) return;
+    [siblings[index], siblings[target]] = [siblings[target], siblings[index]];
+    onReorderConnections(ordered.flat().map((c) => c.id));
+  };
+
+  const renderConnection = (
+    conn: Connection,
+    index: number,
+    group: { name: string; connections: Connection[] },
+  ) => {
+    const marker = connectionColor(conn);
+    return (
+      <div key={conn.id}>
+        <div
+          className={`
+            group flex items-center justify-between px-2 py-2 rounded-md cursor-pointer text-sm
+            ${
+              selectedConnection === conn.id
+                ? "bg-gray-200 dark:bg-gray-800"
+                : "hover:bg-gray-100 dark:hover:bg-gray-900"
+            }
+            ${
+              lastId === conn.id && selectedConnection !== conn.id
+                ? "border-l-2 border-blue-500"
+                : ""
+            }
+          `}
+        >
+          <div
+            className="flex items-center gap-2 flex-1 min-w-0"
+            onClick={() => onSelectConnection(conn.id)}
+          >
+            {selectedConnection === conn.id ? (
+              <ChevronDown className="h-4 w-4 shrink-0 text-gray-500" />
+            ) : (
+              <ChevronRight className="h-4 w-4 shrink-0 text-gray-500" />
+            )}
+            {marker && (
+              <span
+                className="h-2 w-2 shrink-0 rounded-full"
+                style={{ backgroundColor: marker }}
+                title={conn.environment || undefined}
+              />
+            )}
+            <span className="truncate">{conn.name}</span>
+            {lastId === conn.id && (
+              <span className="text-xs text-blue-500">&#8226;</span>
+            )}
+            {conn.tags?.map((tag) => (
+              <span
+                key={tag}
+                className="shrink-0 rounded bg-gray-200 dark:bg-gray-800 px-1 text-[10px] text-gray-600 dark:text-gray-400"
+              >
+                {tag}
+              </span>
+            ))}
+          </div>
+          <DropdownMenu>
+            <DropdownMenuTrigger asChild>
+              <Button
+                variant="ghost"
+                size="icon"
+                className="h-6 w-6 opacity-0 group-hover:opacity-100 transition-opacity"
+                onClick={(e) => e.stopPropagation()}
+              >
+                <MoreVertical className="h-3 w-3 text-gray-500" />
+              </Button>
+            </DropdownMenuTrigger>
+            <DropdownMenuContent align="end" className="w-40">
+              {selectedConnection === conn.id && (
+                <DropdownMenuItem
+                  onClick={(e) => {
+                    e.stopPropagation();
+                    onDisconnectConnection();
+                  }}
+                >
+                  <Activity className="mr-2 h-4 w-4" />
+                  Disconnect
+                </DropdownMenuItem>
+              )}
+              <DropdownMenuItem
+                onClick={(e) => {
+                  e.stopPropagation();
+                  onTestConnection(conn.id);
+                }}
+              >
+                <Activity className="mr-2 h-4 w-4" />
+                Test Connection
+              </DropdownMenuItem>
+              <DropdownMenuItem
+                onClick={(e) => {
+                  e.stopPropagation();
+                  onEditConnection(conn.id);
+                }}
+              >
+                <Pencil className="mr-2 h-4 w-4" />
+                Edit
+              </DropdownMenuItem>
+              <DropdownMenuItem
+                disabled={index === 0}
+                onClick={(e) => {
+                  e.stopPropagation();
+                  moveConnection(group.name, index, -1);
+                }}
+              >
+                <ArrowUp className="mr-2 h-4 w-4" />
+                Move Up
+              </DropdownMenuItem>
+              <DropdownMenuItem
+                disabled={index === group.connections.length - 1}
+                onClick={(e) => {
+                  e.stopPropagation();
+                  moveConnection(group.name, index, 1);
+                }}
+              >
+                <ArrowDown className="mr-2 h-4 w-4" />
+                Move Down
+              </DropdownMenuItem>
+              <DropdownMenuSeparator />
+              <DropdownMenuItem
+                variant="destructive"
+                onClick={(e) => {
+                  e.stopPropagation();
+                  onDeleteConnection(conn.id);
+                }}
+              >
+                <Trash2 className="mr-2 h-4 w-4" />
+                Delete
+              </DropdownMenuItem>
+            </DropdownMenuContent>
+          </DropdownMenu>
+        </div>
+
+        {selectedConnection === conn.id && (
+          <>
+            {tablesLoading ? (
+              <div className="mt-2 flex items-center justify-center py-4">
+                <Loader2 className="h-4 w-4 animate-spin text-gray-500" />
+              </div>
+            ) : tables?.length > 0 ? (
+              <div className="mt-1 space-y-1">
+                {tables.map((table) => (
+                  <div
+                    key={table.name}
+                    className="group flex items-center justify-between px-2 py-1.5 rounded-md cursor-pointer text-sm hover:bg-gray-100 dark:hover:bg-gray-900"
+                  >
+                    <div
+                      className="flex items-center gap-2 flex-1 min-w-0"
+                      onClick={() => onSelectTable(table.name)}
+                    >
+                      <Table className="h-3.5 w-3.5 text-gray-500" />
+                      <span className="truncate">{table.name}</span>
+                    </div>
+                    {onViewSchema && (
+                      <DropdownMenu>
+                        <DropdownMenuTrigger asChild>
+                          <Button
+                            variant="ghost"
+                            size="icon"
+                            className="h-5 w-5 opacity-0 group-hover:opacity-100 transition-opacity"
+                            onClick={(e) => e.stopPropagation()}
+                          >
+                            <MoreVertical className="h-3 w-3 text-gray-500" />
+                          </Button>
+                        </DropdownMenuTrigger>
+                        <DropdownMenuContent align="end" className="w-36">
+                          <DropdownMenuItem
+                            onClick={(e) => {
+                              e.stopPropagation();
+                              onViewSchema(table.name);
+                            }}
+                          >
+                            <FileSearch className="mr-2 h-4 w-4" />
+                            View Schema
+                          </DropdownMenuItem>
+                        </DropdownMenuContent>
+                      </DropdownMenu>
+                    )}
+                  </div>
+                ))}
+              </div>
+            ) : null}
+            <SavedQueriesSection
+              queries={savedQueries}
+              isLoading={savedQueriesLoading}
+              onNewQuery={onNewQuery}
+              onOpenQuery={onOpenSavedQuery}
+              onRenameQuery={onRenameSavedQuery}
+              onDeleteQuery={onDeleteSavedQuery}
+            />
+          </>
+        )}
+      </div>
+    );
+  };
+
   return (
     <div className="h-full border-r border-gray-200 dark:border-gray-800 flex flex-col bg-gray-50 dark:bg-gray-950">
       <div className="px-2 border-b border-gray-200 dark:border-gray-800">
@@ -107,157 +347,35 @@ export function Sidebar({
               No connections
             </p>
           ) : (
-            connections?.map((conn) => (
-              <div key={conn.id}>
-                <div
-                  className={`
-                    group flex items-center justify-between px-2 py-2 rounded-md cursor-pointer text-sm
-                    ${
-                      selectedConnection === conn.id
-                        ? "bg-gray-200 dark:bg-gray-800"
-                        : "hover:bg-gray-100 dark:hover:bg-gray-900"
-                    }
-                    ${
-                      lastId === conn.id && selectedConnection !== conn.id
-                        ? "border-l-2 border-blue-500"
-                        : ""
-                    }
-                  `}
-                >
+            groups.map((group) =>
+              group.name === "" ? (
+                group.connections.map((conn, index) =>
+                  renderConnection(conn, index, group),
+                )
+              ) : (
+                <div key={`group:${group.name}`}>
                   <div
-                    className="flex items-center gap-2 flex-1 min-w-0"
-                    onClick={() => onSelectConnection(conn.id)}
+                    className="flex items-center gap-1.5 px-1 py-1 text-xs font-medium text-gray-500 cursor-pointer select-none"
+                    onClick={() => toggleGroup(group.name)}
                   >
-                    {selectedConnection === conn.id ? (
-                      <ChevronDown className="h-4 w-4 shrink-0 text-gray-500" />
+                    {collapsedGroups.has(group.name) ? (
+                      <ChevronRight className="h-3.5 w-3.5" />
                     ) : (
-                      <ChevronRight className="h-4 w-4 shrink-0 text-gray-500" />
-                    )}
-                    <span className="truncate">{conn.name}</span>
-                    {lastId === conn.id && (
-                      <span className="text-xs text-blue-500">&#8226;</span>
+                      <ChevronDown className="h-3.5 w-3.5" />
                     )}
+                    <Folder className="h-3.5 w-3.5" />
+                    <span className="truncate">{group.name}</span>
                   </div>
-                  <DropdownMenu>
-                    <DropdownMenuTrigger asChild>
-                      <Button
-                        variant="ghost"
-                        size="icon"
-                        className="h-6 w-6 opacity-0 group-hover:opacity-100 transition-opacity"
-                        onClick={(e) => e.stopPropagation()}
-                      >
-                        <MoreVertical className="h-3 w-3 text-gray-500" />
-                      </Button>
-                    </DropdownMenuTrigger>
-                    <DropdownMenuContent align="end" className="w-40">
-                      {selectedConnection === conn.id && (
-                        <DropdownMenuItem
-                          onClick={(e) => {
-                            e.stopPropagation();
-                            onDisconnectConnection();
-                          }}
-                        >
-                          <Activity className="mr-2 h-4 w-4" />
-                          Disconnect
-                        </DropdownMenuItem>
+                  {!collapsedGroups.has(group.name) && (
+                    <div className="pl-2 space-y-1">
+                      {group.connections.map((conn, index) =>
+                        renderConnection(conn, index, group),
                       )}
-                      <DropdownMenuItem
-                        onClick={(e) => {
-                          e.stopPropagation();
-                          onTestConnection(conn.id);
-                        }}
-                      >
-                        <Activity className="mr-2 h-4 w-4" />
-                        Test Connection
-                      </DropdownMenuItem>
-                      <DropdownMenuItem
-                        onClick={(e) => {
-                          e.stopPropagation();
-                          onEditConnection(conn.id);
-                        }}
-                      >
-                        <Pencil className="mr-2 h-4 w-4" />
-                        Edit
-                      </DropdownMenuItem>
-                      <DropdownMenuSeparator />
-                      <DropdownMenuItem
-                        variant="destructive"
-                        onClick={(e) => {
-                          e.stopPropagation();
-                          onDeleteConnection(conn.id);
-                        }}
-                      >
-                        <Trash2 className="mr-2 h-4 w-4" />
-                        Delete
-                      </DropdownMenuItem>
-                    </DropdownMenuContent>
-                  </DropdownMenu>
+                    </div>
+                  )}
                 </div>
-
-                {selectedConnection === conn.id && (
-                  <>
-                    {tablesLoading ? (
-                      <div className="mt-2 flex items-center justify-center py-4">
-                        <Loader2 className="h-4 w-4 animate-spin text-gray-500" />
-                      </div>
-                    ) : tables?.length > 0 ? (
-                      <div className="mt-1 space-y-1">
-                        {tables.map((table) => (
-                          <div
-                            key={table.name}
-                            className="group flex items-center justify-between px-2 py-1.5 rounded-md cursor-pointer text-sm hover:bg-gray-100 dark:hover:bg-gray-900"
-                          >
-                            <div
-                              className="flex items-center gap-2 flex-1 min-w-0"
-                              onClick={() => onSelectTable(table.name)}
-                            >
-                              <Table className="h-3.5 w-3.5 text-gray-500" />
-                              <span className="truncate">{table.name}</span>
-                            </div>
-                            {onViewSchema && (
-                              <DropdownMenu>
-                                <DropdownMenuTrigger asChild>
-                                  <Button
-                                    variant="ghost"
-                                    size="icon"
-                                    className="h-5 w-5 opacity-0 group-hover:opacity-100 transition-opacity"
-                                    onClick={(e) => e.stopPropagation()}
-                                  >
-                                    <MoreVertical className="h-3 w-3 text-gray-500" />
-                                  </Button>
-                                </DropdownMenuTrigger>
-                                <DropdownMenuContent
-                                  align="end"
-                                  className="w-36"
-                                >
-                                  <DropdownMenuItem
-                                    onClick={(e) => {
-                                      e.stopPropagation();
-                                      onViewSchema(table.name);
-                                    }}
-                                  >
-                                    <FileSearch className="mr-2 h-4 w-4" />
-                                    View Schema
-                                  </DropdownMenuItem>
-                                </DropdownMenuContent>
-                              </DropdownMenu>
-                            )}
-                          </div>
-                        ))}
-                      </div>
-                    ) : null}
-                    <SavedQueriesSection
-                      queries={savedQueries}
-                      isLoading={savedQueriesLoading}
-                      onNewQuery={onNewQuery}
-                      onOpenQuery={onOpenSavedQuery}
-                      onRenameQuery={onRenameSavedQuery}
-                      onDeleteQuery={onDeleteSavedQuery}
-                    />
-                  </>
-                )}
-              </div>
-            ))
+              ),
+            )
           )}
         </div>
       </div>
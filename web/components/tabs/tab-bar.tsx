@@ -1,4 +1,5 @@
-import type { Tab, TabType } from "@/types";
+import { connectionColor } from "@/lib/utils";
+import type { ConnectionOrganization, Tab, TabType } from "@/types";
 import { FileCode, Loader2, Plus, RefreshCw, Table, X } from "lucide-react";
 import { Button } from "../ui/button";
 
@@ -6,6 +7,7 @@ interface TabBarProps {
   tabs: Tab[];
   activeTabId: string | null;
   hasConnection: boolean;
+  organization?: Pick<ConnectionOrganization, "environment" | "color">;
   isLoading?: boolean;
   onTabClick: (id: string) => void;
   onTabClose: (id: string) => void;
@@ -24,6 +26,7 @@ export function TabBar({
   tabs,
   activeTabId,
   hasConnection,
+  organization,
   isLoading,
   onTabClick,
   onTabClose,
@@ -31,6 +34,8 @@ export function TabBar({
   onRefresh,
 }: TabBarProps) {
   const activeTab = tabs.find((tab) => tab.id === activeTabId);
+  const marker = organization ? connectionColor(organization) : undefined;
+  const isProd = organization?.environment === "prod";
 
   return (
     <div className="flex items-center border-b border-gray-200 dark:border-gray-800 bg-gray-50 dark:bg-gray-950 h-9">
@@ -56,8 +61,10 @@ export function TabBar({
                     onTabClose(tab.id);
                   }
                 }}
+                style={marker ? { borderTopColor: marker } : undefined}
                 className={`
                   group flex items-center gap-2 px-3 py-2 cursor-pointer border-r border-gray-200 dark:border-gray-800
+                  ${marker ? "border-t-2" : ""}
                   min-w-[120px] max-w-[200px] select-none
                   ${
                     isActive
@@ -70,6 +77,11 @@ export function TabBar({
                 <span className="flex-1 truncate text-sm text-gray-700 dark:text-gray-300">
                   {tab.title}
                 </span>
+                {isProd && (
+                  <span className="shrink-0 rounded px-1 text-[10px] font-semibold leading-4 text-white bg-red-600">
+                    PROD
+                  </span>
+                )}
                 <Button
                   type="button"
                   variant="ghost"
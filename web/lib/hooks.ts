@@ -1,6 +1,8 @@
 import { useMutation, useQuery, useQueryClient } from "@tanstack/react-query";
 import type {
+  Connection,
   ConnectionsResponse,
+  ConnectionFilter,
   QueryResult,
   TableInfo,
   SavedQuery,
@@ -8,14 +10,48 @@ import type {
   AdapterInfo,
   CreateConnectionRequest,
   UpdateConnectionRequest,
+  TrustConnectionRequest,
   TestConnectionRequest,
   TableSchema,
+  TableDDL,
+  ColumnValues,
+  ERDiagram,
+  ERDFilter,
+  SearchResponse,
+  CompletionResponse,
+  QueryPlan,
+  ExplainRequest,
+  ExportRequest,
+  Job,
+  ImportOptions,
+  ImportPreview,
+  ImportRequest,
+  ImportUpload,
+  CopyRequest,
+  CompareRequest,
+  CompareResult,
+  SchemaDiff,
+  SchemaDiffRequest,
+  ProfileRequest,
+  ProfileReport,
+  ConnectionSource,
+  ConnectionImportRequest,
+  ConnectionImportResult,
 } from "@/types";
 
 const API_BASE = "";
 
-const fetchConnections = async (): Promise<ConnectionsResponse> => {
-  const res = await fetch(`${API_BASE}/api/connections`);
+const fetchConnections = async (
+  filter: ConnectionFilter = {},
+): Promise<ConnectionsResponse> => {
+  const params = new URLSearchParams();
+  if (filter.tag) params.set("tag", filter.tag);
+  if (filter.environment) params.set("environment", filter.environment);
+  if (filter.group) params.set("group", filter.group);
+  const query = params.toString();
+  const res = await fetch(
+    `${API_BASE}/api/connections${query ? `?${query}` : ""}`,
+  );
   if (!res.ok) throw new Error("Failed to fetch connections");
   return res.json();
 };
@@ -55,13 +91,14 @@ const fetchTableData = async (
 
 const createConnectionApi = async (
   data: CreateConnectionRequest,
-): Promise<void> => {
+): Promise<Connection> => {
   const res = await fetch(`${API_BASE}/api/connections`, {
     method: "POST",
     headers: { "Content-Type": "application/json" },
     body: JSON.stringify(data),
   });
   if (!res.ok) throw new Error("Failed to create connection");
+  return res.json();
 };
 
 const deleteConnectionApi = async (id: number): Promise<void> => {
@@ -81,13 +118,30 @@ const setLastConnectedApi = async (id: number): Promise<void> => {
 const updateConnectionApi = async (
   id: number,
   data: UpdateConnectionRequest,
-): Promise<void> => {
+): Promise<Connection> => {
   const res = await fetch(`${API_BASE}/api/connections/${id}`, {
     method: "PUT",
     headers: { "Content-Type": "application/json" },
     body: JSON.stringify(data),
   });
   if (!res.ok) throw new Error("Failed to update connection");
+  return res.json();
+};
+
+const trustConnectionApi = async (
+  id: number,
+  data: TrustConnectionRequest,
+): Promise<Connection> => {
+  const res = await fetch(`${API_BASE}/api/connections/${id}/trust`, {
+    method: "POST",
+    headers: { "Content-Type": "application/json" },
+    body: JSON.stringify(data),
+  });
+  if (!res.ok) {
+    const err = await res.json();
+    throw new Error(err.error || "Failed to change connection trust");
+  }
+  return res.json();
 };
 
 const testConnectionApi = async (
@@ -130,10 +184,10 @@ const executeQueryApi = async (
   return res.json();
 };
 
-export function useConnectionsQuery() {
+export function useConnectionsQuery(filter: ConnectionFilter = {}) {
   return useQuery({
-    queryKey: ["connections"],
-    queryFn: fetchConnections,
+    queryKey: ["connections", filter],
+    queryFn: () => fetchConnections(filter),
   });
 }
 
@@ -206,6 +260,34 @@ export function useUpdateConnectionMutation() {
   });
 }
 
+export function useTrustConnectionMutation() {
+  const queryClient = useQueryClient();
+  return useMutation({
+    mutationFn: ({ id, trusted }: { id: number; trusted: boolean }) =>
+      trustConnectionApi(id, { trusted }),
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["connections"] });
+    },
+  });
+}
+
+export function useReorderConnectionsMutation() {
+  const queryClient = useQueryClient();
+  return useMutation({
+    mutationFn: async (ids: number[]): Promise<void> => {
+      const res = await fetch(`${API_BASE}/api/connections/order`, {
+        method: "PUT",
+        headers: { "Content-Type": "application/json" },
+        body: JSON.stringify({ ids }),
+      });
+      if (!res.ok) throw new Error("Failed to reorder connections");
+    },
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["connections"] });
+    },
+  });
+}
+
 export function useTestConnectionMutation() {
   return useMutation({
     mutationFn: testConnectionApi,
@@ -479,3 +561,477 @@ export function useTableSchemaQuery(
     enabled: !!connectionId && !!tableName,
   });
 }
+
+const fetchTableDDL = async (
+  connectionId: number,
+  tableName: string,
+): Promise<TableDDL> => {
+  const res = await fetch(
+    `${API_BASE}/api/connections/${connectionId}/tables/${encodeURIComponent(tableName)}/ddl`,
+  );
+  if (!res.ok) throw new Error("Failed to fetch table DDL");
+  return res.json();
+};
+
+export function useTableDDLQuery(
+  connectionId: number | null,
+  tableName: string | null,
+) {
+  return useQuery({
+    queryKey: ["tableDDL", connectionId, tableName],
+    queryFn: () => fetchTableDDL(connectionId!, tableName!),
+    enabled: !!connectionId && !!tableName,
+  });
+}
+
+const fetchColumnValues = async (
+  connectionId: number,
+  tableName: string,
+  column: string,
+  search: string,
+  filters: ColumnFilter[],
+  page: number,
+): Promise<ColumnValues> => {
+  const params = new URLSearchParams({ page: page.toString() });
+  if (search) params.set("search", search);
+  const activeFilters = filters.filter((f) => f.column !== column);
+  if (activeFilters.length > 0) {
+    params.set("filters", JSON.stringify(activeFilters));
+  }
+
+  const res = await fetch(
+    `${API_BASE}/api/connections/${connectionId}/tables/${encodeURIComponent(tableName)}/columns/${encodeURIComponent(column)}/values?${params.toString()}`,
+  );
+  if (!res.ok) throw new Error("Failed to fetch column values");
+  return res.json();
+};
+
+export function useColumnValuesQuery(
+  connectionId: number | null,
+  tableName: string | null,
+  column: string | null,
+  search = "",
+  filters: ColumnFilter[] = [],
+  page = 1,
+) {
+  return useQuery({
+    queryKey: [
+      "columnValues",
+      connectionId,
+      tableName,
+      column,
+      search,
+      filters,
+      page,
+    ],
+    queryFn: () =>
+      fetchColumnValues(
+        connectionId!,
+        tableName!,
+        column!,
+        search,
+        filters,
+        page,
+      ),
+    enabled: !!connectionId && !!tableName && !!column,
+  });
+}
+
+const fetchERDiagram = async (
+  connectionId: number,
+  filter: ERDFilter,
+): Promise<ERDiagram> => {
+  const params = new URLSearchParams();
+  if (filter.schema) params.set("schema", filter.schema);
+  if (filter.tables) params.set("tables", filter.tables);
+
+  const res = await fetch(
+    `${API_BASE}/api/connections/${connectionId}/erd?${params.toString()}`,
+  );
+  if (!res.ok) {
+    const err = await res.json();
+    throw new Error(err.error || "Failed to fetch ER diagram");
+  }
+  return res.json();
+};
+
+export function useERDiagramQuery(
+  connectionId: number | null,
+  filter: ERDFilter = {},
+) {
+  return useQuery({
+    queryKey: ["erd", connectionId, filter],
+    queryFn: () => fetchERDiagram(connectionId!, filter),
+    enabled: !!connectionId,
+  });
+}
+
+const fetchSchemaSearch = async (
+  connectionId: number | null,
+  term: string,
+): Promise<SearchResponse> => {
+  const params = new URLSearchParams({ q: term });
+  const path = connectionId
+    ? `/api/connections/${connectionId}/search`
+    : "/api/search";
+  const res = await fetch(`${API_BASE}${path}?${params.toString()}`);
+  if (!res.ok) throw new Error("Failed to search schema");
+  return res.json();
+};
+
+export function useSchemaSearchQuery(connectionId: number | null, term: string) {
+  return useQuery({
+    queryKey: ["schemaSearch", connectionId, term],
+    queryFn: () => fetchSchemaSearch(connectionId, term),
+    enabled: term.length > 0,
+  });
+}
+
+const reindexSchemaApi = async (connectionId: number): Promise<void> => {
+  const res = await fetch(
+    `${API_BASE}/api/connections/${connectionId}/search/reindex`,
+    { method: "POST" },
+  );
+  if (!res.ok) {
+    const err = await res.json();
+    throw new Error(err.error || "Failed to rebuild schema index");
+  }
+};
+
+export function useReindexSchemaMutation() {
+  const queryClient = useQueryClient();
+  return useMutation({
+    mutationFn: reindexSchemaApi,
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["schemaSearch"] });
+    },
+  });
+}
+
+const refreshMetadataApi = async (connectionId: number): Promise<TableInfo[]> => {
+  const res = await fetch(
+    `${API_BASE}/api/connections/${connectionId}/refresh`,
+    { method: "POST" },
+  );
+  if (!res.ok) {
+    const err = await res.json();
+    throw new Error(err.error || "Failed to refresh metadata");
+  }
+  return res.json();
+};
+
+export function useRefreshMetadataMutation() {
+  const queryClient = useQueryClient();
+  return useMutation({
+    mutationFn: refreshMetadataApi,
+    onSuccess: (tables, connectionId) => {
+      queryClient.setQueryData(["tables", connectionId], tables);
+      queryClient.invalidateQueries({ queryKey: ["tableSchema", connectionId] });
+      queryClient.invalidateQueries({ queryKey: ["erd", connectionId] });
+    },
+  });
+}
+
+export const fetchCompletions = async (
+  connectionId: number,
+  sql?: string,
+  cursor?: number,
+): Promise<CompletionResponse> => {
+  const res = await fetch(
+    `${API_BASE}/api/connections/${connectionId}/completions`,
+    {
+      method: "POST",
+      headers: { "Content-Type": "application/json" },
+      body: JSON.stringify({ sql: sql ?? "", cursor: cursor ?? -1 }),
+    },
+  );
+  if (!res.ok) throw new Error("Failed to fetch completions");
+  return res.json();
+};
+
+export function useCompletionsQuery(connectionId: number | null) {
+  return useQuery({
+    queryKey: ["completions", connectionId],
+    queryFn: () => fetchCompletions(connectionId!),
+    enabled: !!connectionId,
+    staleTime: 5 * 60 * 1000,
+  });
+}
+
+const explainQueryApi = async (
+  connectionId: number,
+  req: ExplainRequest,
+): Promise<QueryPlan> => {
+  const res = await fetch(`${API_BASE}/api/connections/${connectionId}/explain`, {
+    method: "POST",
+    headers: { "Content-Type": "application/json" },
+    body: JSON.stringify(req),
+  });
+  if (!res.ok) {
+    const err = await res.json();
+    throw new Error(err.error || "Explain failed");
+  }
+  return res.json();
+};
+
+export function useExplainQueryMutation(connectionId: number | null) {
+  return useMutation({
+    mutationFn: (req: ExplainRequest) => {
+      if (!connectionId) throw new Error("No connection selected");
+      return explainQueryApi(connectionId, req);
+    },
+  });
+}
+
+const startExportApi = async (
+  connectionId: number,
+  req: ExportRequest,
+): Promise<Job> => {
+  const res = await fetch(`${API_BASE}/api/connections/${connectionId}/export`, {
+    method: "POST",
+    headers: { "Content-Type": "application/json" },
+    body: JSON.stringify(req),
+  });
+  if (!res.ok) {
+    const err = await res.json();
+    throw new Error(err.error || "Export failed");
+  }
+  return res.json();
+};
+
+export function useStartExportMutation(connectionId: number | null) {
+  const queryClient = useQueryClient();
+  return useMutation({
+    mutationFn: (req: ExportRequest) => {
+      if (!connectionId) throw new Error("No connection selected");
+      return startExportApi(connectionId, req);
+    },
+    onSuccess: (job) => {
+      queryClient.setQueryData(["job", job.id], job);
+    },
+  });
+}
+
+const fetchJob = async (jobId: string): Promise<Job> => {
+  const res = await fetch(`${API_BASE}/api/jobs/${jobId}`);
+  if (!res.ok) throw new Error("Failed to fetch job");
+  return res.json();
+};
+
+export function useJobQuery(jobId: string | null) {
+  return useQuery({
+    queryKey: ["job", jobId],
+    queryFn: () => fetchJob(jobId!),
+    enabled: !!jobId,
+    refetchInterval: (query) =>
+      query.state.data?.status === "running" ? 1000 : false,
+  });
+}
+
+export function useCancelJobMutation() {
+  const queryClient = useQueryClient();
+  return useMutation({
+    mutationFn: async (jobId: string): Promise<Job> => {
+      const res = await fetch(`${API_BASE}/api/jobs/${jobId}/cancel`, {
+        method: "POST",
+      });
+      if (!res.ok) throw new Error("Failed to cancel job");
+      return res.json();
+    },
+    onSuccess: (job) => {
+      queryClient.setQueryData(["job", job.id], job);
+    },
+  });
+}
+
+export function useUploadImportFileMutation() {
+  return useMutation({
+    mutationFn: async (file: File): Promise<ImportUpload> => {
+      const form = new FormData();
+      form.append("file", file);
+      const res = await fetch(`${API_BASE}/api/import/upload`, {
+        method: "POST",
+        body: form,
+      });
+      if (!res.ok) {
+        const err = await res.json();
+        throw new Error(err.error || "Upload failed");
+      }
+      return res.json();
+    },
+  });
+}
+
+export function useImportPreviewMutation(connectionId: number | null) {
+  return useMutation({
+    mutationFn: async (req: {
+      path: string;
+      options?: ImportOptions;
+    }): Promise<ImportPreview> => {
+      if (!connectionId) throw new Error("No connection selected");
+      const res = await fetch(
+        `${API_BASE}/api/connections/${connectionId}/import/preview`,
+        {
+          method: "POST",
+          headers: { "Content-Type": "application/json" },
+          body: JSON.stringify(req),
+        },
+      );
+      if (!res.ok) {
+        const err = await res.json();
+        throw new Error(err.error || "Preview failed");
+      }
+      return res.json();
+    },
+  });
+}
+
+export function useStartImportMutation(connectionId: number | null) {
+  const queryClient = useQueryClient();
+  return useMutation({
+    mutationFn: async (req: ImportRequest): Promise<Job> => {
+      if (!connectionId) throw new Error("No connection selected");
+      const res = await fetch(
+        `${API_BASE}/api/connections/${connectionId}/import`,
+        {
+          method: "POST",
+          headers: { "Content-Type": "application/json" },
+          body: JSON.stringify(req),
+        },
+      );
+      if (!res.ok) {
+        const err = await res.json();
+        throw new Error(err.error || "Import failed");
+      }
+      return res.json();
+    },
+    onSuccess: (job) => {
+      queryClient.setQueryData(["job", job.id], job);
+    },
+  });
+}
+
+export function useCopyTableMutation(connectionId: number | null) {
+  const queryClient = useQueryClient();
+  return useMutation({
+    mutationFn: async (req: CopyRequest): Promise<Job> => {
+      if (!connectionId) throw new Error("No connection selected");
+      const res = await fetch(
+        `${API_BASE}/api/connections/${connectionId}/copy`,
+        {
+          method: "POST",
+          headers: { "Content-Type": "application/json" },
+          body: JSON.stringify(req),
+        },
+      );
+      if (!res.ok) {
+        const err = await res.json();
+        throw new Error(err.error || "Copy failed");
+      }
+      return res.json();
+    },
+    onSuccess: (job) => {
+      queryClient.setQueryData(["job", job.id], job);
+    },
+  });
+}
+
+export function useCompareResultsMutation() {
+  return useMutation({
+    mutationFn: async (req: CompareRequest): Promise<CompareResult> => {
+      const res = await fetch(`${API_BASE}/api/compare`, {
+        method: "POST",
+        headers: { "Content-Type": "application/json" },
+        body: JSON.stringify(req),
+      });
+      if (!res.ok) {
+        const err = await res.json();
+        throw new Error(err.error || "Compare failed");
+      }
+      return res.json();
+    },
+  });
+}
+
+export function useSchemaDiffMutation() {
+  return useMutation({
+    mutationFn: async (req: SchemaDiffRequest): Promise<SchemaDiff> => {
+      const res = await fetch(`${API_BASE}/api/schema-diff`, {
+        method: "POST",
+        headers: { "Content-Type": "application/json" },
+        body: JSON.stringify(req),
+      });
+      if (!res.ok) {
+        const err = await res.json();
+        throw new Error(err.error || "Schema diff failed");
+      }
+      return res.json();
+    },
+  });
+}
+
+export function useProfileMutation(connectionId: number | null) {
+  return useMutation({
+    mutationFn: async (req: ProfileRequest): Promise<ProfileReport> => {
+      if (!connectionId) throw new Error("No connection selected");
+      const res = await fetch(
+        `${API_BASE}/api/connections/${connectionId}/profile`,
+        {
+          method: "POST",
+          headers: { "Content-Type": "application/json" },
+          body: JSON.stringify(req),
+        },
+      );
+      if (!res.ok) {
+        const err = await res.json();
+        throw new Error(err.error || "Profile failed");
+      }
+      return res.json();
+    },
+  });
+}
+
+const importConnectionsApi = async (
+  path: string,
+  req: ConnectionImportRequest,
+): Promise<ConnectionImportResult> => {
+  const res = await fetch(`${API_BASE}/api/connections/${path}`, {
+    method: "POST",
+    headers: { "Content-Type": "application/json" },
+    body: JSON.stringify(req),
+  });
+  if (!res.ok) {
+    const err = await res.json();
+    throw new Error(err.error || "Import failed");
+  }
+  return res.json();
+};
+
+export function useConnectionImportSources() {
+  return useQuery({
+    queryKey: ["connection-import-sources"],
+    queryFn: async (): Promise<ConnectionSource[]> => {
+      const res = await fetch(`${API_BASE}/api/connections/import/sources`);
+      if (!res.ok) throw new Error("Failed to fetch import sources");
+      return res.json();
+    },
+  });
+}
+
+export function useConnectionImportPreviewMutation() {
+  return useMutation({
+    mutationFn: (req: ConnectionImportRequest) =>
+      importConnectionsApi("import/preview", req),
+  });
+}
+
+export function useConnectionImportMutation() {
+  const queryClient = useQueryClient();
+  return useMutation({
+    mutationFn: (req: ConnectionImportRequest) =>
+      importConnectionsApi("import", req),
+    onSuccess: () => {
+      queryClient.invalidateQueries({ queryKey: ["connections"] });
+    },
+  });
+}
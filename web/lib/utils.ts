@@ -1,6 +1,20 @@
 import { type ClassValue, clsx } from "clsx";
 import { twMerge } from "tailwind-merge";
+import type { ConnectionOrganization, Environment } from "@/types";
 
 export function cn(...inputs: ClassValue[]) {
   return twMerge(clsx(inputs));
 }
+
+export const ENVIRONMENTS: { value: Exclude<Environment, "">; label: string; color: string }[] = [
+  { value: "dev", label: "Development", color: "#16a34a" },
+  { value: "staging", label: "Staging", color: "#d97706" },
+  { value: "prod", label: "Production", color: "#dc2626" },
+];
+
+export function connectionColor(
+  conn: Pick<ConnectionOrganization, "environment" | "color">,
+): string | undefined {
+  if (conn.color) return conn.color;
+  return ENVIRONMENTS.find((env) => env.value === conn.environment)?.color;
+}
@@ -1,14 +1,26 @@
-export interface Connection {
+export type Environment = "" | "dev" | "staging" | "prod";
+
+export interface ConnectionOrganization {
+  group: string;
+  tags: string[];
+  environment: Environment;
+  color: string;
+}
+
+export interface Connection extends ConnectionOrganization {
   id: number;
   name: string;
   type: string;
   credentials: Record<string, any>;
+  sort_order: number;
+  trust_references: boolean;
   created_at: string;
 }
 
 export interface TableInfo {
   name: string;
   type: string;
+  schema?: string;
 }
 
 export interface QueryResult {
@@ -25,18 +37,28 @@ export interface ConnectionsResponse {
   last_id: number;
 }
 
-export interface CreateConnectionRequest {
+export interface CreateConnectionRequest extends Partial<ConnectionOrganization> {
   name: string;
   type: string;
   credentials: Record<string, any>;
 }
 
-export interface UpdateConnectionRequest {
+export interface UpdateConnectionRequest extends Partial<ConnectionOrganization> {
   name: string;
   type: string;
   credentials: Record<string, any>;
 }
 
+export interface TrustConnectionRequest {
+  trusted: boolean;
+}
+
+export interface ConnectionFilter {
+  tag?: string;
+  environment?: Environment;
+  group?: string;
+}
+
 export interface TestConnectionRequest {
   type: string;
   credentials: Record<string, any>;
@@ -46,6 +68,7 @@ export interface AdapterInfo {
   type: string;
   name: string;
   description: string;
+  local_file: boolean;
   ui_config: UIConfig;
 }
 
@@ -68,6 +91,7 @@ export interface FieldConfig {
   type: string;
   required: boolean;
   placeholder?: string;
+  secret?: boolean;
 }
 
 export type TabType = "table" | "query" | "schema";
@@ -118,6 +142,21 @@ export interface ColumnInfo {
   nullable: boolean;
   default_value: string;
   is_primary_key: boolean;
+  enum_values?: string[];
+}
+
+export interface ColumnValue {
+  value: any;
+  count: number;
+}
+
+export interface ColumnValues {
+  column: string;
+  source: "data" | "enum" | "check";
+  values: ColumnValue[];
+  page: number;
+  limit: number;
+  has_more: boolean;
 }
 
 export interface IndexInfo {
@@ -133,9 +172,375 @@ export interface ConstraintInfo {
   definition: string;
 }
 
+export interface ForeignKeyInfo {
+  name: string;
+  columns: string[];
+  referenced_table: string;
+  referenced_columns: string[];
+}
+
 export interface TableSchema {
   table_name: string;
   columns: ColumnInfo[];
   indexes: IndexInfo[];
   constraints: ConstraintInfo[];
+  foreign_keys: ForeignKeyInfo[] | null;
+}
+
+export interface TableDDL {
+  table_name: string;
+  type: string;
+  ddl: string;
+}
+
+export interface ERDTable {
+  name: string;
+  schema?: string;
+  type: string;
+  columns: ColumnInfo[];
+  primary_key: string[];
+}
+
+export interface ERDRelationship {
+  name: string;
+  from_table: string;
+  from_columns: string[];
+  to_table: string;
+  to_columns: string[];
+}
+
+export interface ERDiagram {
+  tables: ERDTable[];
+  relationships: ERDRelationship[];
+  errors?: { table: string; error: string }[];
+}
+
+export interface ERDFilter {
+  schema?: string;
+  tables?: string;
+}
+
+export interface SearchResult {
+  connection_id: number;
+  connection_name: string;
+  table_name: string;
+  table_type: string;
+  column_name?: string;
+  column_type?: string;
+  comment?: string;
+  matched_on: "table" | "column" | "type" | "comment";
+}
+
+export interface SearchResponse {
+  results: SearchResult[];
+  indexing: number[];
+}
+
+export interface CompletionColumn {
+  name: string;
+  type: string;
+}
+
+export interface CompletionTable {
+  name: string;
+  type: string;
+  columns: CompletionColumn[];
+}
+
+export interface CompletionItem {
+  label: string;
+  kind: "column" | "table" | "function" | "keyword";
+  detail?: string;
+}
+
+export interface CompletionResponse {
+  dialect: string;
+  keywords: string[];
+  functions: string[];
+  types: string[];
+  tables: CompletionTable[];
+  context?: "table" | "column" | "keyword";
+  suggestions?: CompletionItem[];
+}
+
+export interface PlanNode {
+  operation: string;
+  relation?: string;
+  detail?: string;
+  startup_cost?: number;
+  total_cost?: number;
+  estimated_rows?: number;
+  actual_rows?: number;
+  actual_time_ms?: number;
+  extra?: Record<string, any>;
+  children: PlanNode[];
+}
+
+export interface QueryPlan {
+  dialect: string;
+  analyzed: boolean;
+  root: PlanNode;
+  summary?: Record<string, any>;
+}
+
+export interface ExplainRequest {
+  query: string;
+  analyze?: boolean;
+  confirm_analyze?: boolean;
+}
+
+export interface ExportOptions {
+  delimiter?: string;
+  quote_all?: boolean;
+  omit_header?: boolean;
+  null_value?: string;
+  dialect?: "sqlite" | "postgres" | "bigquery";
+  table_name?: string;
+  batch_size?: number;
+  omit_ddl?: boolean;
+  drop_table?: boolean;
+}
+
+export interface ExportRequest {
+  format: string;
+  path?: string;
+  overwrite?: boolean;
+  query?: string;
+  saved_query_id?: number;
+  table?: string;
+  filters?: ColumnFilter[];
+  options?: ExportOptions;
+}
+
+export interface Job {
+  id: string;
+  kind: string;
+  connection_id: number;
+  status: "running" | "completed" | "failed" | "cancelled";
+  rows_processed: number;
+  path?: string;
+  error?: string;
+  started_at: string;
+  finished_at?: string;
+}
+
+export interface ImportOptions {
+  format?: "csv" | "tsv" | "ndjson" | "json";
+  delimiter?: string;
+  no_header?: boolean;
+}
+
+export interface ImportColumn {
+  name: string;
+  type: string;
+}
+
+export interface ImportUpload {
+  path: string;
+  name: string;
+  size: number;
+}
+
+export interface ImportPreview {
+  format: string;
+  columns: ImportColumn[];
+  rows: any[][];
+  sampled_rows: number;
+}
+
+export interface ImportRequest {
+  path: string;
+  options?: ImportOptions;
+  table: string;
+  create_table?: boolean;
+  columns?: ImportColumn[];
+  confirm_prod?: boolean;
+}
+
+export interface CopyRequest {
+  target_connection_id: number;
+  table?: string;
+  filters?: ColumnFilter[];
+  query?: string;
+  target_table?: string;
+  mode?: "create" | "replace" | "append";
+  batch_size?: number;
+  confirm_prod?: boolean;
+}
+
+export interface CompareSide {
+  connection_id: number;
+  query: string;
+}
+
+export interface CompareRequest {
+  left: CompareSide;
+  right: CompareSide;
+  key_columns: string[];
+  max_rows?: number;
+}
+
+export interface CompareRow {
+  key: any[];
+  left?: any[];
+  right?: any[];
+  changed_columns?: string[];
+}
+
+export interface CompareSummary {
+  left_rows: number;
+  right_rows: number;
+  added: number;
+  removed: number;
+  changed: number;
+  unchanged: number;
+  duplicate_keys: number;
+}
+
+export interface CompareResult {
+  columns: string[];
+  key_columns: string[];
+  left_only_columns?: string[];
+  right_only_columns?: string[];
+  added: CompareRow[];
+  removed: CompareRow[];
+  changed: CompareRow[];
+  summary: CompareSummary;
+  truncated: boolean;
+}
+
+export interface SchemaDiffRequest {
+  source_connection_id: number;
+  target_connection_id: number;
+  schema?: string;
+  tables?: string;
+  refresh?: boolean;
+  generate_script?: boolean;
+}
+
+export interface ColumnDiff {
+  name: string;
+  source_type: string;
+  target_type: string;
+  source_nullable: boolean;
+  target_nullable: boolean;
+  source_default?: string;
+  target_default?: string;
+  type_changed: boolean;
+  nullability_changed: boolean;
+  default_changed: boolean;
+}
+
+export interface IndexDiff {
+  name: string;
+  source: IndexInfo;
+  target: IndexInfo;
+}
+
+export interface TableDiff {
+  table: string;
+  added_columns?: ColumnInfo[];
+  removed_columns?: ColumnInfo[];
+  changed_columns?: ColumnDiff[];
+  source_primary_key?: string[];
+  target_primary_key?: string[];
+  added_indexes?: IndexInfo[];
+  removed_indexes?: IndexInfo[];
+  changed_indexes?: IndexDiff[];
+  added_foreign_keys?: ForeignKeyInfo[];
+  removed_foreign_keys?: ForeignKeyInfo[];
+  added_constraints?: ConstraintInfo[];
+  removed_constraints?: ConstraintInfo[];
+}
+
+export interface SchemaDiff {
+  source_connection_id: number;
+  target_connection_id: number;
+  missing_tables: string[];
+  extra_tables: string[];
+  tables: TableDiff[];
+  errors?: { table: string; error: string }[];
+  dialect?: string;
+  script?: string;
+}
+
+export interface ProfileRequest {
+  table?: string;
+  query?: string;
+  columns?: string[];
+  top_n?: number;
+  buckets?: number;
+  approximate?: boolean;
+}
+
+export interface ProfileValueCount {
+  value: any;
+  count: number;
+}
+
+export interface HistogramBucket {
+  lower: number;
+  upper: number;
+  count: number;
+}
+
+export interface ColumnProfile {
+  name: string;
+  type: string;
+  kind: "numeric" | "temporal" | "boolean" | "text" | "binary" | "complex";
+  null_count: number;
+  distinct_count?: number;
+  distinct_approximate?: boolean;
+  min?: any;
+  max?: any;
+  average?: number;
+  top_values?: ProfileValueCount[];
+  histogram?: HistogramBucket[];
+  error?: string;
+}
+
+export interface ProfileReport {
+  table?: string;
+  query?: string;
+  dialect: string;
+  rows: number;
+  columns: ColumnProfile[];
+  duration_ms: number;
+}
+
+export type ConnectionSource =
+  | "pgpass"
+  | "pg_service"
+  | "env"
+  | "dbeaver"
+  | "tableplus";
+
+export interface ConnectionImportRequest {
+  source: ConnectionSource;
+  path?: string;
+  content?: string;
+  on_duplicate?: "skip" | "rename" | "replace";
+  names?: string[];
+}
+
+export interface ConnectionImportItem {
+  name: string;
+  source_name: string;
+  type: string;
+  origin: string;
+  action: "create" | "rename" | "replace" | "skip" | "invalid";
+  error?: string;
+  warnings?: string[];
+  connection_id?: number;
+}
+
+export interface ConnectionImportResult {
+  source: ConnectionSource;
+  path?: string;
+  dry_run: boolean;
+  items: ConnectionImportItem[];
+  created: number;
+  replaced: number;
+  skipped: number;
+  invalid: number;
 }
@@ -0,0 +1,25 @@
+//go:build !headless
+
+package main
+
+import (
+	"os"
+
+	webview "github.com/webview/webview_go"
+)
+
+func runWindow(localURL string) error {
+	debug := os.Getenv("BIFROST_DEV") == "1"
+
+	w := webview.New(debug)
+	defer w.Destroy()
+
+	w.SetTitle("Datafrost")
+	w.SetSize(1200, 800, webview.HintNone)
+	w.Navigate(localURL)
+
+	setupMacEditMenu()
+
+	w.Run()
+	return nil
+}
@@ -0,0 +1,9 @@
+//go:build headless
+
+package main
+
+import "errors"
+
+func runWindow(localURL string) error {
+	return errors.New("this build has no desktop window; run `datafrost serve` and open the printed URL in a browser")
+}